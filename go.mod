@@ -12,11 +12,12 @@ require (
 	github.com/iancoleman/strcase v0.3.0
 	github.com/microcosm-cc/bluemonday v1.0.27
 	github.com/mrz1836/go-sanitize v1.5.2
+	github.com/yuin/goldmark v1.8.2
+	golang.org/x/net v0.43.0
 	golang.org/x/text v0.28.0
 )
 
 require (
 	github.com/aymerick/douceur v0.2.0 // indirect
 	github.com/gorilla/css v1.0.1 // indirect
-	golang.org/x/net v0.43.0 // indirect
 )
@@ -83,11 +83,98 @@ var (
 	// ErrDoesNotContainSubstring indicates that a string does not contain the expected substring.
 	ErrDoesNotContainSubstring = errors.New("does not contain substring")
 
+	// ErrNoSubstrings indicates that a containment check was given no substrings to look for.
+	ErrNoSubstrings = errors.New("no substrings provided")
+
 	// ErrMissingPrefix indicates that a required prefix is missing from a given string.
 	ErrMissingPrefix = errors.New("missing prefix")
 
 	// ErrMissingSuffix indicates that the required suffix is missing from a string.
 	ErrMissingSuffix = errors.New("missing suffix")
+
+	// ErrInvalidAlgorithm indicates that the provided algorithm is not a supported similarity algorithm.
+	ErrInvalidAlgorithm = errors.New("invalid algorithm")
+
+	// ErrMatrixIndexOutOfRange indicates that a row or column index is outside the bounds of a similarity matrix.
+	ErrMatrixIndexOutOfRange = errors.New("matrix index out of range")
+
+	// ErrNilSimilarityMatrix indicates that a method was called on a nil SimilarityMatrixResult.
+	ErrNilSimilarityMatrix = errors.New("similarity matrix is nil")
+
+	// ErrNilFuzzyIndex indicates that a nil FuzzyIndex was provided for a lookup.
+	ErrNilFuzzyIndex = errors.New("fuzzy index is nil")
+
+	// ErrInvalidFuzzyWeights indicates that algorithm weights for fuzzy matching or consensus scoring are empty,
+	// negative or sum to zero.
+	ErrInvalidFuzzyWeights = errors.New("invalid fuzzy weights")
+
+	// ErrNotSimilar indicates that a string is not similar enough to the string it was compared with.
+	ErrNotSimilar = errors.New("strings are not similar")
+
+	// ErrInvalidPhoneticAlgorithm indicates that the provided algorithm is not a supported phonetic algorithm.
+	ErrInvalidPhoneticAlgorithm = errors.New("invalid phonetic algorithm")
+
+	// ErrInvalidEditCosts indicates that edit distance costs are invalid; insert and delete costs must be at least 1
+	// and substitute and transpose costs must not be negative.
+	ErrInvalidEditCosts = errors.New("invalid edit costs")
+
+	// ErrUnsupportedEditStrategy indicates that an edit distance strategy is unknown or cannot be used with the
+	// given strings and costs.
+	ErrUnsupportedEditStrategy = errors.New("unsupported edit strategy")
+
+	// ErrInvalidPatch indicates that a unified diff is malformed or its hunk line counts do not match their headers.
+	ErrInvalidPatch = errors.New("invalid patch")
+
+	// ErrPatchConflict indicates that a patch hunk's context and deleted lines could not be found in the text.
+	ErrPatchConflict = errors.New("patch does not apply")
+
+	// ErrInvalidHashCount indicates that the number of hash functions for a MinHash signature is less than 1.
+	ErrInvalidHashCount = errors.New("invalid hash count")
+
+	// ErrSignatureMismatch indicates that two signatures were built with different parameters and cannot be compared.
+	ErrSignatureMismatch = errors.New("signatures are not comparable")
+
+	// ErrInvalidSignature indicates that serialized signature data is malformed.
+	ErrInvalidSignature = errors.New("invalid signature data")
+
+	// ErrInvalidLSHBands indicates that the band or row count of an LSH index is less than 1.
+	ErrInvalidLSHBands = errors.New("invalid LSH bands")
+
+	// ErrInvalidCorpusOptions indicates that corpus options select unknown terms or an n-gram length less than 1.
+	ErrInvalidCorpusOptions = errors.New("invalid corpus options")
+
+	// ErrNilCorpus indicates that a nil Corpus was provided for a comparison.
+	ErrNilCorpus = errors.New("corpus is nil")
+
+	// ErrInvalidResultsData indicates that exported comparison manager results are malformed or name an unknown
+	// algorithm or comparison type.
+	ErrInvalidResultsData = errors.New("invalid results data")
+
+	// ErrUnsafePath indicates that a user supplied path is empty, absolute, contains a NUL byte or escapes the
+	// directory it is joined to.
+	ErrUnsafePath = errors.New("unsafe path")
+
+	// ErrMixedScript indicates that a string mixes characters of scripts that are not used together.
+	ErrMixedScript = errors.New("string mixes scripts")
+
+	// ErrRestrictionLevel indicates that a string mixes scripts more freely than the required restriction level
+	// allows.
+	ErrRestrictionLevel = errors.New("string exceeds restriction level")
+
+	// ErrConfusable indicates that a string is visually confusable with a protected string.
+	ErrConfusable = errors.New("string is confusable")
+
+	// ErrBidiControl indicates that a string contains bidirectional formatting characters.
+	ErrBidiControl = errors.New("string contains bidirectional control characters")
+
+	// ErrInvalidPattern indicates that serialized pattern data is malformed or contains a pattern that does not compile.
+	ErrInvalidPattern = errors.New("invalid pattern")
+
+	// ErrSecretFound indicates that a string contains a credential such as an access token or private key.
+	ErrSecretFound = errors.New("string contains a secret")
+
+	// ErrInvalidLoremSchema indicates that a lorem schema is nil or contains an unsupported field definition.
+	ErrInvalidLoremSchema = errors.New("invalid lorem schema")
 )
 
 // CompareErrors compares two error values for equality by checking their string representations.
@@ -1,6 +1,8 @@
 package pattern
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 
 	"github.com/bmj2728/utils/pkg/internal/errors"
@@ -51,3 +53,35 @@ func (cps CustomPatternSet) Exists(name string) bool {
 	_, ok := cps[name]
 	return ok
 }
+
+// MarshalJSON encodes the CustomPatternSet as a JSON object mapping each name to the source of its pattern.
+func (cps CustomPatternSet) MarshalJSON() ([]byte, error) {
+	sources := make(map[string]string, len(cps))
+	for name, pattern := range cps {
+		if pattern != nil {
+			sources[name] = pattern.String()
+		}
+	}
+	return json.Marshal(sources)
+}
+
+// UnmarshalJSON decodes a JSON object mapping names to pattern sources, compiling each pattern and adding it to
+// the CustomPatternSet. Returns an error wrapping ErrInvalidPattern if the data is malformed or a pattern does
+// not compile.
+func (cps *CustomPatternSet) UnmarshalJSON(data []byte) error {
+	var sources map[string]string
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrInvalidPattern, err)
+	}
+	if *cps == nil {
+		*cps = NewCustomPatternSet()
+	}
+	for name, source := range sources {
+		pattern, err := regexp.Compile(source)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", errors.ErrInvalidPattern, name, err)
+		}
+		cps.Add(name, pattern)
+	}
+	return nil
+}
@@ -13,6 +13,11 @@ type StringBuilder struct {
 	originalValue     string
 	comparisonManager *ComparisonManager
 	history           *StringHistory
+	// rejectedValue is the value that failed the Require methods recorded in a ValidationErrors error
+	rejectedValue string
+	// validating is set while consecutive Require methods collect their failures in a ValidationErrors error.
+	// Any other change of the value or the error ends it.
+	validating bool
 }
 
 // TODO builder logic to be updated re: error handling
@@ -101,6 +106,7 @@ func (sb *StringBuilder) Result() (string, error) {
 // setValue sets the value of the StringBuilder to the provided string and returns the updated StringBuilder instance.
 func (sb *StringBuilder) setValue(value string) *StringBuilder {
 	sb.value = value
+	sb.validating = false
 	if sb.history != nil {
 		sb.updateHistory(value)
 	}
@@ -110,12 +116,45 @@ func (sb *StringBuilder) setValue(value string) *StringBuilder {
 // setError sets the error field in the StringBuilder and returns the updated StringBuilder instance.
 func (sb *StringBuilder) setError(err error, fatal bool) *StringBuilder {
 	sb.err = err
+	sb.validating = false
 	if fatal {
 		sb.setValue("")
 	}
 	return sb
 }
 
+// validationValue returns the value Require methods should validate and whether they should run. After a Require
+// method fails, later Require methods keep validating the rejected value so that every failure is reported.
+func (sb *StringBuilder) validationValue() (string, bool) {
+	if sb.validating {
+		return sb.rejectedValue, true
+	}
+	return sb.value, sb.shouldContinueProcessing()
+}
+
+// failValidation records err, a *ValidationError returned by a validator, as a fatal error. Failures of
+// consecutive Require methods are collected in a single ValidationErrors error. A nil err is ignored.
+func (sb *StringBuilder) failValidation(err error) *StringBuilder {
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		return sb
+	}
+	var errs ValidationErrors
+	if sb.validating {
+		errs, _ = sb.err.(ValidationErrors)
+	} else {
+		sb.rejectedValue = sb.value
+	}
+	for _, e := range errs {
+		if e.Rule == ve.Rule && e.Reason == ve.Reason && e.Offset == ve.Offset {
+			return sb
+		}
+	}
+	sb.setError(append(errs[:len(errs):len(errs)], ve), true)
+	sb.validating = true
+	return sb
+}
+
 // shouldContinueProcessing determines whether processing should continue based on the state of the error and the value.
 func (sb *StringBuilder) shouldContinueProcessing() bool {
 	if sb.err != nil {
@@ -130,6 +169,7 @@ func (sb *StringBuilder) shouldContinueProcessing() bool {
 // state based on its history or sets an error if unresolved.
 func (sb *StringBuilder) RevertToOriginal() *StringBuilder {
 	sb.value = sb.originalValue
+	sb.validating = false
 	return sb
 }
 
@@ -140,6 +180,7 @@ func (sb *StringBuilder) RevertToPrevious() *StringBuilder {
 		if err == nil {
 			// this throws an error if there is only an original value
 			sb.value = prev
+			sb.validating = false
 			(*sb.history).transforms = (*sb.history).transforms[:sb.history.Len()-1]
 		} else {
 			// fatal - reversion failed
@@ -165,6 +206,7 @@ func (sb *StringBuilder) RevertToIndex(index int) *StringBuilder {
 		if err == nil {
 			// throws error when invalid index
 			sb.value = ind
+			sb.validating = false
 			(*sb.history).transforms = (*sb.history).transforms[:index+1]
 		} else {
 			// fatal - reversion has failed
@@ -147,8 +147,42 @@ func ShingleSlice(s string, k int) *ShingleSliceResult {
 	return shingleSlice(s, k)
 }
 
+// MinHash computes a MinHash signature of numHashes hashes over the set of k-shingles of s. The fraction of equal
+// hashes in two signatures estimates the Jaccard similarity of their shingle sets with a standard error of about
+// 1/sqrt(numHashes). A non-empty string shorter than k is treated as a single shingle.
+// Returns an error result if k or numHashes is less than 1.
+func MinHash(s string, k, numHashes int) *MinHashResult {
+	return minHash(s, k, numHashes)
+}
+
+// SimHash computes a 64-bit SimHash fingerprint of the k-shingles of s, weighted by how often each occurs.
+// Near-duplicate strings have fingerprints with a small Hamming distance.
+// A non-empty string shorter than k is treated as a single shingle. Returns an error result if k is less than 1.
+func SimHash(s string, k int) *SimHashResult {
+	return simHash(s, k)
+}
+
 // Similarity computes the score between two input strings using the specified algorithm.
 // Returns a SimilarityResult containing the score score or any error encountered during computation.
 func Similarity(s1, s2 string, algorithm Algorithm) *SimilarityResult {
 	return similarity(s1, s2, algorithm)
 }
+
+// SimilarityMatrix computes the pairwise similarity between every pair of items using the specified
+// algorithm and returns the symmetric N×N result. Pairs are computed in parallel and each unordered
+// pair is compared only once.
+func SimilarityMatrix(items []string, algorithm Algorithm) *SimilarityMatrixResult {
+	return similarityMatrix(items, algorithm)
+}
+
+// CompareAll computes the similarity of s to every string in others with each of the given algorithms, or with
+// every algorithm if none are given, and returns a ComparisonManager holding the results. Pairs are compared in
+// parallel. Errors of individual pairs, such as Hamming on strings of unequal length, are kept in their results.
+// Returns ErrInvalidAlgorithm if an algorithm is unknown.
+func CompareAll(s string, others []string, algos ...Algorithm) (*ComparisonManager, error) {
+	cm := NewComparisonManager()
+	if err := compareAll(cm, s, others, algos); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
@@ -21,8 +21,8 @@ func (sb *StringBuilder) LevenshteinDistance(other string) *StringBuilder {
 	}
 	ld := levenshteinDistance(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(ld)
-	if ld.err != nil {
-		return sb.setError(ld.err, false)
+	if ld.GetError() != nil {
+		return sb.setError(ld.GetError(), false)
 	}
 	return sb
 }
@@ -37,8 +37,8 @@ func (sb *StringBuilder) DamerauLevenshteinDistance(other string) *StringBuilder
 	}
 	dld := damerauLevenshteinDistance(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(dld)
-	if dld.err != nil {
-		return sb.setError(dld.err, false)
+	if dld.GetError() != nil {
+		return sb.setError(dld.GetError(), false)
 	}
 	return sb
 }
@@ -54,12 +54,68 @@ func (sb *StringBuilder) OSADamerauLevenshteinDistance(other string) *StringBuil
 	}
 	osadld := osaDamerauLevenshteinDistance(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(osadld)
-	if osadld.err != nil {
-		return sb.setError(osadld.err, false)
+	if osadld.GetError() != nil {
+		return sb.setError(osadld.GetError(), false)
 	}
 	return sb
 }
 
+// LevenshteinDistanceWith calculates the Levenshtein distance between the current string and another string
+// using the given cutoff, costs and strategy, and stores the result in the ComparisonManager.
+// Invalid options record a non-fatal error.
+func (sb *StringBuilder) LevenshteinDistanceWith(other string, opts EditDistanceOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	ld := levenshteinDistanceWith(sb.value, other, opts)
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(ld)
+	if ld.GetError() != nil {
+		return sb.setError(ld.GetError(), false)
+	}
+	return sb
+}
+
+// DamerauLevenshteinDistanceWith calculates the unrestricted Damerau-Levenshtein distance between the current
+// string and another string using the given cutoff and costs, and stores the result in the ComparisonManager.
+// Invalid options record a non-fatal error.
+func (sb *StringBuilder) DamerauLevenshteinDistanceWith(other string, opts EditDistanceOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	dld := damerauLevenshteinDistanceWith(sb.value, other, opts)
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(dld)
+	if dld.GetError() != nil {
+		return sb.setError(dld.GetError(), false)
+	}
+	return sb
+}
+
+// OSADamerauLevenshteinDistanceWith calculates the optimal string alignment distance between the current string
+// and another string using the given cutoff, costs and strategy, and stores the result in the ComparisonManager.
+// Invalid options record a non-fatal error.
+func (sb *StringBuilder) OSADamerauLevenshteinDistanceWith(other string, opts EditDistanceOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	osadld := osaDamerauLevenshteinDistanceWith(sb.value, other, opts)
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(osadld)
+	if osadld.GetError() != nil {
+		return sb.setError(osadld.GetError(), false)
+	}
+	return sb
+}
+
+// EditOperations computes the edit script turning the current string into another string and stores it in the
+// ComparisonManager, where it can be retrieved with GetEditScriptResult and rendered as a diff.
+func (sb *StringBuilder) EditOperations(other string) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	es := editOperations(sb.value, other)
+	sb.WithComparisonManager().comparisonManager.AddEditScriptResult(*es)
+	return sb
+}
+
 // LCS computes the longest common subsequence (LCS) between the current string and the provided string.
 // It updates the comparison manager with the result of the LCS computation and returns the updated StringBuilder.
 // If an error exists in the StringBuilder, it returns itself without performing computations.
@@ -69,8 +125,8 @@ func (sb *StringBuilder) LCS(other string) *StringBuilder {
 	}
 	lcs := lcs(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(lcs)
-	if lcs.err != nil {
-		return sb.setError(lcs.err, false)
+	if lcs.GetError() != nil {
+		return sb.setError(lcs.GetError(), false)
 	}
 	return sb
 }
@@ -83,8 +139,8 @@ func (sb *StringBuilder) LCSEditDistance(other string) *StringBuilder {
 	}
 	l := lcsEditDistance(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(l)
-	if l.err != nil {
-		return sb.setError(l.err, false)
+	if l.GetError() != nil {
+		return sb.setError(l.GetError(), false)
 	}
 	return sb
 }
@@ -98,8 +154,8 @@ func (sb *StringBuilder) LCSBacktrack(other string) *StringBuilder {
 	}
 	lb := lcsBacktrack(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddLCSResult(*lb)
-	if lb.err != nil {
-		return sb.setError(lb.err, false)
+	if lb.GetError() != nil {
+		return sb.setError(lb.GetError(), false)
 	}
 	return sb
 }
@@ -113,8 +169,8 @@ func (sb *StringBuilder) LCSBacktrackAll(other string) *StringBuilder {
 	}
 	lba := lcsBacktrackAll(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddLCSResult(*lba)
-	if lba.err != nil {
-		return sb.setError(lba.err, false)
+	if lba.GetError() != nil {
+		return sb.setError(lba.GetError(), false)
 	}
 	return sb
 }
@@ -129,8 +185,8 @@ func (sb *StringBuilder) LCSDiff(other string) *StringBuilder {
 	}
 	ld := lcsDiff(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddLCSResult(*ld)
-	if ld.err != nil {
-		return sb.setError(ld.err, false)
+	if ld.GetError() != nil {
+		return sb.setError(ld.GetError(), false)
 	}
 	return sb
 }
@@ -144,8 +200,8 @@ func (sb *StringBuilder) HammingDistance(other string) *StringBuilder {
 	}
 	dist := hammingDistance(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(dist)
-	if dist.err != nil {
-		return sb.setError(dist.err, false)
+	if dist.GetError() != nil {
+		return sb.setError(dist.GetError(), false)
 	}
 	return sb
 }
@@ -163,8 +219,8 @@ func (sb *StringBuilder) JaroSimilarity(other string) *StringBuilder {
 	}
 	js := jaroSimilarity(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(js)
-	if js.err != nil {
-		return sb.setError(js.err, false)
+	if js.GetError() != nil {
+		return sb.setError(js.GetError(), false)
 	}
 	return sb
 }
@@ -182,8 +238,8 @@ func (sb *StringBuilder) JaroWinklerSimilarity(other string) *StringBuilder {
 	}
 	jws := jaroWinklerSimilarity(sb.value, other)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(jws)
-	if jws.err != nil {
-		return sb.setError(jws.err, false)
+	if jws.GetError() != nil {
+		return sb.setError(jws.GetError(), false)
 	}
 	return sb
 }
@@ -202,8 +258,8 @@ func (sb *StringBuilder) JaccardSimilarity(other string, splitLength int) *Strin
 	}
 	js := jaccardSimilarity(sb.value, other, splitLength)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(js)
-	if js.err != nil {
-		return sb.setError(js.err, false)
+	if js.GetError() != nil {
+		return sb.setError(js.GetError(), false)
 	}
 	return sb
 }
@@ -222,8 +278,8 @@ func (sb *StringBuilder) CosineSimilarity(other string, splitLength int) *String
 	}
 	cs := cosineSimilarity(sb.value, other, splitLength)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(cs)
-	if cs.err != nil {
-		return sb.setError(cs.err, false)
+	if cs.GetError() != nil {
+		return sb.setError(cs.GetError(), false)
 	}
 	return sb
 }
@@ -241,8 +297,8 @@ func (sb *StringBuilder) SorensenDiceCoefficient(other string, splitLength int)
 	}
 	sdc := sorensenDiceCoefficient(sb.value, other, splitLength)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(sdc)
-	if sdc.err != nil {
-		return sb.setError(sdc.err, false)
+	if sdc.GetError() != nil {
+		return sb.setError(sdc.GetError(), false)
 	}
 	return sb
 }
@@ -256,8 +312,8 @@ func (sb *StringBuilder) QgramDistance(other string, q int) *StringBuilder {
 	}
 	qd := qgramDistance(sb.value, other, q)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(qd)
-	if qd.err != nil {
-		return sb.setError(qd.err, false)
+	if qd.GetError() != nil {
+		return sb.setError(qd.GetError(), false)
 	}
 	return sb
 }
@@ -282,21 +338,21 @@ func (sb *StringBuilder) QgramDistanceCustomNgram(nmapOther map[string]int, cust
 	// if there's no comp manager or matching shingle map - we add the manager, shingles and re-run
 	if sb.comparisonManager == nil {
 		return sb.WithComparisonManager().Shingle(k).QgramDistanceCustomNgram(nmapOther, customName)
-	} else if sb.comparisonManager.ShingleResults[ShinglesMap][k] == nil {
+	} else if sb.comparisonManager.GetShingleResult(ShinglesMap, k) == nil {
 		sb.Shingle(k).QgramDistanceCustomNgram(nmapOther, customName)
 	} else {
 		// get the record and cast it to the correct map type
-		sr := sb.comparisonManager.ShingleResults[ShinglesMap][k]
-		if shingleMap, ok := (*sr).(*ShingleMapResult); ok {
-			if shingleMap.err != nil {
-				return sb.setError(shingleMap.err, false)
+		sr := sb.comparisonManager.GetShingleResult(ShinglesMap, k)
+		if shingleMap, ok := sr.(*ShingleMapResult); ok {
+			if shingleMap.GetError() != nil {
+				return sb.setError(shingleMap.GetError(), false)
 			}
 			//run the comparison and add results
-			qdc := qgramDistanceCustomNgram(shingleMap.shingles, nmapOther, customName)
+			qdc := qgramDistanceCustomNgram(shingleMap.GetShinglesMap(), nmapOther, customName)
 			sb.WithComparisonManager().comparisonManager.AddComparisonResult(qdc)
-			if qdc.err != nil {
+			if qdc.GetError() != nil {
 				//return with error if exists
-				return sb.setError(qdc.err, false)
+				return sb.setError(qdc.GetError(), false)
 			}
 		}
 	}
@@ -312,8 +368,8 @@ func (sb *StringBuilder) QgramSimilarity(other string, q int) *StringBuilder {
 	}
 	qs := qgramSimilarity(sb.value, other, q)
 	sb.WithComparisonManager().comparisonManager.AddComparisonResult(qs)
-	if qs.err != nil {
-		return sb.setError(qs.err, false)
+	if qs.GetError() != nil {
+		return sb.setError(qs.GetError(), false)
 	}
 	return sb
 }
@@ -326,8 +382,8 @@ func (sb *StringBuilder) Shingle(k int) *StringBuilder {
 	}
 	shingle := shingle(sb.value, k)
 	sb.WithComparisonManager().comparisonManager.AddShingleResult(shingle)
-	if shingle.err != nil {
-		return sb.setError(shingle.err, false)
+	if shingle.GetError() != nil {
+		return sb.setError(shingle.GetError(), false)
 	}
 	return sb
 }
@@ -339,8 +395,36 @@ func (sb *StringBuilder) ShingleSlice(k int) *StringBuilder {
 	}
 	shingle := shingleSlice(sb.value, k)
 	sb.WithComparisonManager().comparisonManager.AddShingleResult(shingle)
-	if shingle.err != nil {
-		return sb.setError(shingle.err, false)
+	if shingle.GetError() != nil {
+		return sb.setError(shingle.GetError(), false)
+	}
+	return sb
+}
+
+// MinHash computes a MinHash signature of the k-shingles of the current string and stores it using the
+// ComparisonManager. Updates the error state if k or numHashes is less than 1.
+func (sb *StringBuilder) MinHash(k, numHashes int) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	mh := minHash(sb.value, k, numHashes)
+	sb.WithComparisonManager().comparisonManager.AddShingleResult(mh)
+	if mh.GetError() != nil {
+		return sb.setError(mh.GetError(), false)
+	}
+	return sb
+}
+
+// SimHash computes a 64-bit SimHash fingerprint of the k-shingles of the current string and stores it using the
+// ComparisonManager. Updates the error state if k is less than 1.
+func (sb *StringBuilder) SimHash(k int) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sh := simHash(sb.value, k)
+	sb.WithComparisonManager().comparisonManager.AddShingleResult(sh)
+	if sh.GetError() != nil {
+		return sb.setError(sh.GetError(), false)
 	}
 	return sb
 }
@@ -354,8 +438,140 @@ func (sb *StringBuilder) Similarity(other string, algorithm Algorithm) *StringBu
 	}
 	sr := similarity(sb.value, other, algorithm)
 	sb.WithComparisonManager().comparisonManager.AddSimilarityResult(*sr)
-	if sr.err != nil {
-		return sb.setError(sr.err, false)
+	if sr.GetError() != nil {
+		return sb.setError(sr.GetError(), false)
+	}
+	return sb
+}
+
+// SimilarityMatrix computes the similarity matrix over the StringBuilder's value followed by the
+// provided strings, storing the matrix in the ComparisonManager.
+// The value's own row is also added to the similarity results so it can be looked up by comparison string.
+func (sb *StringBuilder) SimilarityMatrix(others []string, algorithm Algorithm) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	items := append([]string{sb.value}, others...)
+	sm := similarityMatrix(items, algorithm)
+	sb.WithComparisonManager().comparisonManager.AddSimilarityMatrix(*sm)
+	if sm.err != nil {
+		return sb.setError(sm.err, false)
+	}
+	for j := 1; j < sm.Len(); j++ {
+		score := sm.scores[0][j]
+		sb.comparisonManager.AddSimilarityResult(*NewSimilarityResult(algorithm, sb.value, items[j], &score, nil))
+	}
+	return sb
+}
+
+// CompareAll computes the similarity of the StringBuilder's value to every string in others with each of the
+// given algorithms, or with every algorithm if none are given, filling the ComparisonManager in parallel.
+// Errors of individual pairs are kept in their results rather than set on the builder.
+// Sets a non-fatal error if an algorithm is unknown.
+func (sb *StringBuilder) CompareAll(others []string, algos ...Algorithm) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	if err := compareAll(sb.WithComparisonManager().comparisonManager, sb.value, others, algos); err != nil {
+		return sb.setError(err, false)
+	}
+	return sb
+}
+
+// FuzzySearch looks up the StringBuilder's value in the provided FuzzyIndex, adding every item within
+// maxDistance Levenshtein edits to the ComparisonManager as a Levenshtein SimilarityResult.
+// Sets a non-fatal error if the index is nil.
+func (sb *StringBuilder) FuzzySearch(index *FuzzyIndex, maxDistance int) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	if index == nil {
+		return sb.setError(errors.ErrNilFuzzyIndex, false)
+	}
+	sb.WithComparisonManager()
+	for _, r := range index.Search(sb.value, maxDistance) {
+		sb.comparisonManager.AddSimilarityResult(r)
+	}
+	return sb
+}
+
+// FuzzyTopK finds the k items in the provided FuzzyIndex most similar to the StringBuilder's value
+// under the given algorithm and adds them to the ComparisonManager as SimilarityResults.
+// Sets a non-fatal error if the index is nil.
+func (sb *StringBuilder) FuzzyTopK(index *FuzzyIndex, k int, algorithm Algorithm) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	if index == nil {
+		return sb.setError(errors.ErrNilFuzzyIndex, false)
+	}
+	sb.WithComparisonManager()
+	for _, r := range index.TopK(sb.value, k, algorithm) {
+		sb.comparisonManager.AddSimilarityResult(r)
+	}
+	return sb
+}
+
+// TFIDFSimilarity computes the cosine similarity between the TF-IDF vectors of the StringBuilder's value and
+// another string, weighted by the provided Corpus, and stores the result in the ComparisonManager.
+// Sets a non-fatal error if the corpus is nil.
+func (sb *StringBuilder) TFIDFSimilarity(other string, corpus *Corpus) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	if corpus == nil {
+		return sb.setError(errors.ErrNilCorpus, false)
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(corpus.Similarity(sb.value, other))
+	return sb
+}
+
+// Ratio computes the normalized Indel similarity between the StringBuilder's value and another string,
+// after processing both as configured by opts, and stores the result in the ComparisonManager.
+func (sb *StringBuilder) Ratio(other string, opts TokenizeOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(ratio(sb.value, other, opts))
+	return sb
+}
+
+// PartialRatio computes the best Ratio between the shorter of the StringBuilder's value and another string
+// and the substrings of the longer one, and stores the result in the ComparisonManager.
+func (sb *StringBuilder) PartialRatio(other string, opts TokenizeOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(partialRatio(sb.value, other, opts))
+	return sb
+}
+
+// TokenSortRatio computes the Ratio between the sorted tokens of the StringBuilder's value and another string,
+// and stores the result in the ComparisonManager.
+func (sb *StringBuilder) TokenSortRatio(other string, opts TokenizeOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(tokenSortRatio(sb.value, other, opts))
+	return sb
+}
+
+// TokenSetRatio computes the best Ratio between the shared and remaining tokens of the StringBuilder's value
+// and another string, and stores the result in the ComparisonManager.
+func (sb *StringBuilder) TokenSetRatio(other string, opts TokenizeOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(tokenSetRatio(sb.value, other, opts))
+	return sb
+}
+
+// WeightedRatio computes the best length-weighted token ratio between the StringBuilder's value and
+// another string, and stores the result in the ComparisonManager.
+func (sb *StringBuilder) WeightedRatio(other string, opts TokenizeOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
 	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(weightedRatio(sb.value, other, opts))
 	return sb
 }
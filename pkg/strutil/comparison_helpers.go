@@ -2,7 +2,10 @@ package strutil
 
 import (
 	"errors"
+	"maps"
+	"runtime"
 	"slices"
+	"sync"
 
 	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
 
@@ -13,7 +16,7 @@ import (
 // It calculates the minimum number of edits (insertions, deletions, substitutions)
 // required to transform one string into another.
 func levenshteinDistance(s1, s2 string) *ComparisonResultInt {
-	ld := edlib.LevenshteinDistance(s1, s2)
+	ld := unitEditDistance(s1, s2, false)
 	return NewComparisonResultInt(LevDist, s1, s2, nil, &ld, nil)
 }
 
@@ -31,7 +34,7 @@ func damerauLevenshteinDistance(str1, str2 string) *ComparisonResultInt {
 // The returned structure includes the calculated distance, input strings, and any
 // potential error encountered during computation.
 func osaDamerauLevenshteinDistance(str1, str2 string) *ComparisonResultInt {
-	osaDLD := edlib.OSADamerauLevenshteinDistance(str1, str2)
+	osaDLD := unitEditDistance(str1, str2, true)
 	return NewComparisonResultInt(OSADamLevDist, str1, str2, nil, &osaDLD, nil)
 }
 
@@ -220,7 +223,12 @@ func shingleSlice(s string, k int) *ShingleSliceResult {
 
 // similarity calculates the similarity between two strings using
 // the specified algorithm and returns a SimilarityResult.
+// Token ratio algorithms are scored by this package using NewTokenizeOptions.
 func similarity(s1, s2 string, algorithm Algorithm) *SimilarityResult {
+	if scorer, ok := tokenRatioAlgorithms[algorithm]; ok {
+		sim, err := scorer(s1, s2, NewTokenizeOptions()).GetScoreFloat()
+		return NewSimilarityResult(algorithm, s1, s2, &sim, err)
+	}
 	sim, err := edlib.StringsSimilarity(s1, s2, edlib.Algorithm(algorithm))
 	if err != nil {
 		// underlying returns 0.0, errors.New("Illegal argument for algorithm method")
@@ -229,3 +237,83 @@ func similarity(s1, s2 string, algorithm Algorithm) *SimilarityResult {
 	}
 	return NewSimilarityResult(algorithm, s1, s2, &sim, err)
 }
+
+// similarityMatrix computes the symmetric N×N similarity matrix for the given items using the specified algorithm.
+// Only the upper triangle is computed; rows are distributed across GOMAXPROCS workers and each
+// score is mirrored into the lower triangle. The diagonal is set to 1.
+func similarityMatrix(items []string, algorithm Algorithm) *SimilarityMatrixResult {
+	if _, ok := AlgorithmTypeMap[algorithm]; !ok {
+		return NewSimilarityMatrixResult(algorithm, items, nil, errors2.ErrInvalidAlgorithm)
+	}
+	n := len(items)
+	scores := make([][]float32, n)
+	for i := range scores {
+		scores[i] = make([]float32, n)
+		scores[i][i] = 1
+	}
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	workers := min(runtime.GOMAXPROCS(0), max(n, 1))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				for j := i + 1; j < n; j++ {
+					// each (i, j) pair is owned by row i's worker, so cells are never written concurrently
+					sim, err := similarity(items[i], items[j], algorithm).GetScore()
+					if err != nil {
+						sim = 0
+					}
+					scores[i][j] = sim
+					scores[j][i] = sim
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		rows <- i
+	}
+	close(rows)
+	wg.Wait()
+	return NewSimilarityMatrixResult(algorithm, items, scores, nil)
+}
+
+// compareAll computes the similarity of s to every string in others with every algorithm in algos, or with every
+// algorithm in AlgorithmTypeMap if algos is empty. Pairs are distributed across GOMAXPROCS workers and each
+// result is added to cm as soon as it is computed. Returns ErrInvalidAlgorithm before comparing anything if an
+// algorithm is unknown.
+func compareAll(cm *ComparisonManager, s string, others []string, algos []Algorithm) error {
+	if len(algos) == 0 {
+		algos = slices.Sorted(maps.Keys(AlgorithmTypeMap))
+	}
+	for _, algo := range algos {
+		if _, ok := AlgorithmTypeMap[algo]; !ok {
+			return errors2.ErrInvalidAlgorithm
+		}
+	}
+	type pair struct {
+		other string
+		algo  Algorithm
+	}
+	pairs := make(chan pair)
+	var wg sync.WaitGroup
+	workers := min(runtime.GOMAXPROCS(0), max(len(others)*len(algos), 1))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range pairs {
+				cm.AddSimilarityResult(*similarity(s, p.other, p.algo))
+			}
+		}()
+	}
+	for _, other := range others {
+		for _, algo := range algos {
+			pairs <- pair{other: other, algo: algo}
+		}
+	}
+	close(pairs)
+	wg.Wait()
+	return nil
+}
@@ -1,115 +1,151 @@
 package strutil
 
-// ComparisonManager is a structure for managing comparison, score, shingle, and LCS results.
+import "sync"
+
+// ComparisonManager is a structure for managing comparison, score, shingle, LCS, matrix and edit script results.
+// Comparison, score, shingle and LCS results are held as Results in ResultStores keyed by their kind and
+// comparison string or n-gram length. Its methods are safe for concurrent use, and the maps returned by the
+// Get*Map and Copy*Map methods are copies that do not change when results are added.
 type ComparisonManager struct {
+	// Deprecated: ComparisonResults mirrors the comparison results added through AddComparisonResult and is kept
+	// for existing callers. Reading it while another goroutine adds results is a data race, and changes made to
+	// it are not seen by the manager's methods. Use GetComparisonResult or GetComparisonResultsMap instead.
 	ComparisonResults ComparisonResultsMap
+	// Deprecated: SimilarityResults mirrors the score results added through AddSimilarityResult and is kept for
+	// existing callers. Reading it while another goroutine adds results is a data race, and changes made to it are
+	// not seen by the manager's methods. Use GetSimilarityResult or GetSimilarityResultsMap instead.
 	SimilarityResults SimilarityResultsMap
-	ShingleResults    ShingleResultsMap
-	LCSResults        LCSResultsMap
-}
-
-// NewComparisonManager initializes and returns a new instance of ComparisonManager with empty result maps.
+	// Deprecated: ShingleResults mirrors the shingle results added through AddShingleResult and is kept for
+	// existing callers. Reading it while another goroutine adds results is a data race, and changes made to it are
+	// not seen by the manager's methods. Use GetShingleResult or GetShingleResultsMap instead.
+	ShingleResults ShingleResultsMap
+	// Deprecated: LCSResults mirrors the LCS results added through AddLCSResult and is kept for existing callers.
+	// Reading it while another goroutine adds results is a data race, and changes made to it are not seen by the
+	// manager's methods. Use GetLCSResult or GetLCSResultsMap instead.
+	LCSResults LCSResultsMap
+
+	mu           sync.RWMutex
+	comparisons  *comparisonStores
+	similarities *ResultStore[similarityKey, float32]
+	shingles     *shingleStores
+	lcsResults   *ResultStore[lcsKey, []string]
+	matrices     SimilarityMatrixMap
+	editScripts  EditScriptResultsMap
+}
+
+// NewComparisonManager initializes and returns a new instance of ComparisonManager with empty result stores.
 func NewComparisonManager() *ComparisonManager {
 	return &ComparisonManager{
 		ComparisonResults: NewComparisonResultsMap(),
 		SimilarityResults: NewSimilarityResultsMap(),
 		ShingleResults:    NewShingleResultsMap(),
 		LCSResults:        NewLCSResultsMap(),
+		comparisons:       newComparisonStores(),
+		similarities:      NewResultStore[similarityKey, float32](),
+		shingles:          newShingleStores(),
+		lcsResults:        NewResultStore[lcsKey, []string](),
+		matrices:          NewSimilarityMatrixMap(),
+		editScripts:       NewEditScriptResultsMap(),
 	}
 }
 
 // Comparison Results
 
 // GetComparisonResultsMap returns the ComparisonResultsMap from the ComparisonManager instance.
+// It returns a copy, like CopyComparisonResultsMap, so the map can be read while other goroutines add results; earlier
+// versions returned the ComparisonResults field itself. Changes made to the copy are not seen by the manager.
 func (cm *ComparisonManager) GetComparisonResultsMap() ComparisonResultsMap {
-	if cm.ComparisonResults == nil {
-		return nil
-	}
-	return cm.ComparisonResults
+	return cm.CopyComparisonResultsMap()
 }
 
-// CopyComparisonResultsMap returns a copy of the ComparisonResultsMap from the ComparisonManager instance.
+// CopyComparisonResultsMap returns the stored comparison results as a new ComparisonResultsMap,
+// or nil if none were ever added.
 func (cm *ComparisonManager) CopyComparisonResultsMap() ComparisonResultsMap {
-	if cm.ComparisonResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.comparisons == nil {
 		return nil
 	}
-	return cm.ComparisonResults.GetCopy()
+	return cm.comparisons.resultsMap()
 }
 
-// AddComparisonResult inserts a ComparisonResult into the ComparisonResultsMap, organizing it by type and first string.
+// AddComparisonResult stores a ComparisonResult, replacing any result with the same type and second string.
 func (cm *ComparisonManager) AddComparisonResult(result ComparisonResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.comparisons == nil {
+		cm.comparisons = newComparisonStores()
+	}
+	cm.comparisons.add(result)
 	if cm.ComparisonResults == nil {
 		cm.ComparisonResults = NewComparisonResultsMap()
 	}
 	cm.ComparisonResults.Add(result)
 }
 
-// GetComparisonResult retrieves a ComparisonResult from the ComparisonResults map based on the
-// provided type and string key.
+// GetComparisonResult retrieves the ComparisonResult stored for the provided type and string key.
+// Returns nil if no result is found.
 func (cm *ComparisonManager) GetComparisonResult(compResType ComparisonResultType, compStr string) ComparisonResult {
-	if cm.ComparisonResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.comparisons == nil {
 		return nil
 	}
-	return cm.ComparisonResults.Get(compResType, compStr)
+	return cm.comparisons.get(comparisonKey{compResType, compStr})
 }
 
 // FilterComparisonResultsByType filters and returns a map of ComparisonResults matching the specified type.
 func (cm *ComparisonManager) FilterComparisonResultsByType(compType ComparisonResultType) ComparisonResultsMap {
-	if cm.ComparisonResults == nil {
-		return nil
-	}
-	return cm.ComparisonResults.FilterByType(compType)
+	return cm.CopyComparisonResultsMap().FilterByType(compType)
 }
 
-// GetComparisonResultsByType retrieves all ComparisonResults of the
-// specified ComparisonResultType from the ComparisonResults map.
+// GetComparisonResultsByType retrieves all stored ComparisonResults of the specified ComparisonResultType.
 func (cm *ComparisonManager) GetComparisonResultsByType(compResType ComparisonResultType) []ComparisonResult {
-	if cm.ComparisonResults == nil {
-		return nil
-	}
-	return cm.ComparisonResults.GetByType(compResType)
+	return cm.CopyComparisonResultsMap().GetByType(compResType)
 }
 
 // FilterComparisonResultsByComparisonString filters and returns a ComparisonResultsMap containing
 // entries matching compStr.
 func (cm *ComparisonManager) FilterComparisonResultsByComparisonString(compStr string) ComparisonResultsMap {
-	if cm.ComparisonResults == nil {
-		return nil
-	}
-	return cm.ComparisonResults.FilterByComparisonString(compStr)
+	return cm.CopyComparisonResultsMap().FilterByComparisonString(compStr)
 }
 
-// GetComparisonResultsByString retrieves all ComparisonResult objects
-// associated with the given string key from ComparisonResults.
+// GetComparisonResultsByString retrieves all stored ComparisonResult objects associated with the given string key.
 func (cm *ComparisonManager) GetComparisonResultsByString(compStr string) []ComparisonResult {
-	if cm.ComparisonResults == nil {
-		return nil
-	}
-	return cm.ComparisonResults.GetByComparisonString(compStr)
+	return cm.CopyComparisonResultsMap().GetByComparisonString(compStr)
 }
 
 // Similarity Results
 
 // GetSimilarityResultsMap retrieves the SimilarityResultsMap, containing score
 // results organized by algorithm and comparison string.
+// It returns a copy, like CopySimilarityResultsMap, so the map can be read while other goroutines add results; earlier
+// versions returned the SimilarityResults field itself. Changes made to the copy are not seen by the manager.
 func (cm *ComparisonManager) GetSimilarityResultsMap() SimilarityResultsMap {
-	if cm.SimilarityResults == nil {
-		return nil
-	}
-	return cm.SimilarityResults
+	return cm.CopySimilarityResultsMap()
 }
 
-// CopySimilarityResultsMap returns a copy of the SimilarityResultsMap held by the ComparisonManager instance.
-// If the SimilarityResults map is nil, it returns nil.
+// CopySimilarityResultsMap returns the stored score results as a new SimilarityResultsMap.
+// If no results were ever added, it returns nil.
 func (cm *ComparisonManager) CopySimilarityResultsMap() SimilarityResultsMap {
-	if cm.SimilarityResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.similarities == nil {
 		return nil
 	}
-	return cm.SimilarityResults.GetCopy()
+	smr := NewSimilarityResultsMap()
+	storedIndex(resultIndex[Algorithm, string, SimilarityResult](smr), cm.similarities, wrapSimilarity)
+	return smr
 }
 
-// AddSimilarityResult adds a given SimilarityResult to the SimilarityResultsMap of the ComparisonManager instance.
+// AddSimilarityResult stores a SimilarityResult, replacing any result with the same algorithm and second string.
 func (cm *ComparisonManager) AddSimilarityResult(result SimilarityResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.similarities == nil {
+		cm.similarities = NewResultStore[similarityKey, float32]()
+	}
+	cm.similarities.Add(similarityKey{result.algorithm, result.GetString2()}, result.ToResult())
 	if cm.SimilarityResults == nil {
 		cm.SimilarityResults = NewSimilarityResultsMap()
 	}
@@ -119,66 +155,69 @@ func (cm *ComparisonManager) AddSimilarityResult(result SimilarityResult) {
 // GetSimilarityResult retrieves a SimilarityResult for the specified algorithm and comparison string.
 // Returns nil if no results are found.
 func (cm *ComparisonManager) GetSimilarityResult(algo Algorithm, compStr string) *SimilarityResult {
-	if cm.SimilarityResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.similarities == nil {
+		return nil
+	}
+	r, ok := cm.similarities.Get(similarityKey{algo, compStr})
+	if !ok {
 		return nil
 	}
-	return cm.SimilarityResults.Get(algo, compStr)
+	result := wrapSimilarity(algo, r)
+	return &result
 }
 
 // GetSimilarityResultsByType retrieves all SimilarityResults corresponding to the specified Algorithm type.
 func (cm *ComparisonManager) GetSimilarityResultsByType(algo Algorithm) []SimilarityResult {
-	if cm.SimilarityResults == nil {
-		return nil
-	}
-	return cm.SimilarityResults.GetByType(algo)
+	return cm.CopySimilarityResultsMap().GetByType(algo)
 }
 
 // FilterSimilarityResultsByType filters the SimilarityResultsMap to include entries
 // matching the specified algorithm type.
 func (cm *ComparisonManager) FilterSimilarityResultsByType(algo Algorithm) SimilarityResultsMap {
-	if cm.SimilarityResults == nil {
-		return nil
-	}
-	return cm.SimilarityResults.FilterByType(algo)
+	return cm.CopySimilarityResultsMap().FilterByType(algo)
 }
 
 // GetSimilarityResultsByComparisonString retrieves a slice of SimilarityResult associated with
 // the given comparison string.
 func (cm *ComparisonManager) GetSimilarityResultsByComparisonString(compStr string) []SimilarityResult {
-	if cm.SimilarityResults == nil {
-		return nil
-	}
-	return cm.SimilarityResults.GetByComparisonString(compStr)
+	return cm.CopySimilarityResultsMap().GetByComparisonString(compStr)
 }
 
 // FilterSimilarityResultsByComparisonString filters the SimilarityResultsMap based on a given comparison string.
 func (cm *ComparisonManager) FilterSimilarityResultsByComparisonString(compStr string) SimilarityResultsMap {
-	if cm.SimilarityResults == nil {
-		return nil
-	}
-	return cm.SimilarityResults.FilterByComparisonString(compStr)
+	return cm.CopySimilarityResultsMap().FilterByComparisonString(compStr)
 }
 
 // Shingle Data
 
 // GetShingleResultsMap retrieves the ShingleResultsMap from the ComparisonManager instance.
+// It returns a copy, like CopyShingleResultsMap, so the map can be read while other goroutines add results; earlier
+// versions returned the ShingleResults field itself. Changes made to the copy are not seen by the manager.
 func (cm *ComparisonManager) GetShingleResultsMap() ShingleResultsMap {
-	if cm.ShingleResults == nil {
-		return nil
-	}
-	return cm.ShingleResults
+	return cm.CopyShingleResultsMap()
 }
 
-// CopyShingleResultsMap returns a copy of the ShingleResultsMap if it exists; otherwise, it returns nil.
+// CopyShingleResultsMap returns the stored shingle results as a new ShingleResultsMap if any were ever added;
+// otherwise, it returns nil.
 func (cm *ComparisonManager) CopyShingleResultsMap() ShingleResultsMap {
-	if cm.ShingleResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.shingles == nil {
 		return nil
 	}
-	return cm.ShingleResults.GetCopy()
+	return cm.shingles.resultsMap()
 }
 
-// AddShingleResult inserts a ShingleResult into the ShingleResults map organized by type and n-gram length.
+// AddShingleResult stores a ShingleResult, replacing any result with the same type and n-gram length.
 func (cm *ComparisonManager) AddShingleResult(result ShingleResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.shingles == nil {
+		cm.shingles = newShingleStores()
+	}
+	cm.shingles.add(result)
 	if cm.ShingleResults == nil {
 		cm.ShingleResults = NewShingleResultsMap()
 	}
@@ -186,69 +225,68 @@ func (cm *ComparisonManager) AddShingleResult(result ShingleResult) {
 }
 
 // GetShingleResult retrieves a ShingleResult based on the specified ShingleResultType and n-gram length.
-// Returns nil if no matching result exists or if the ShingleResults map is uninitialized.
+// Returns nil if no matching result exists.
 func (cm *ComparisonManager) GetShingleResult(resType ShingleResultType, ngramLength int) ShingleResult {
-	if cm.ShingleResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.shingles == nil {
 		return nil
 	}
-	return cm.ShingleResults.Get(resType, ngramLength)
+	return cm.shingles.get(shingleKey{resType, ngramLength})
 }
 
-// GetShingleResultsByType retrieves all ShingleResults of the specified ShingleResultType from the ShingleResults map.
+// GetShingleResultsByType retrieves all stored ShingleResults of the specified ShingleResultType.
 func (cm *ComparisonManager) GetShingleResultsByType(resType ShingleResultType) []ShingleResult {
-	if cm.ShingleResults == nil {
-		return nil
-	}
-	return cm.ShingleResults.GetByType(resType)
+	return cm.CopyShingleResultsMap().GetByType(resType)
 }
 
 // FilterShingleResultsByType filters the ShingleResultsMap to include entries matching the specified ShingleResultType.
 func (cm *ComparisonManager) FilterShingleResultsByType(resType ShingleResultType) ShingleResultsMap {
-	if cm.ShingleResults == nil {
-		return nil
-	}
-	return cm.ShingleResults.FilterByType(resType)
+	return cm.CopyShingleResultsMap().FilterByType(resType)
 }
 
 // GetShingleResultsByNGramLength retrieves a slice of ShingleResult based on the specified n-gram length.
 // Returns nil if no ShingleResults are available.
 func (cm *ComparisonManager) GetShingleResultsByNGramLength(ngramLength int) []ShingleResult {
-	if cm.ShingleResults == nil {
-		return nil
-	}
-	return cm.ShingleResults.GetByNGramLength(ngramLength)
+	return cm.CopyShingleResultsMap().GetByNGramLength(ngramLength)
 }
 
 // FilterShingleResultsByNGramLength filters ShingleResultsMap to include entries matching the specified n-gram length.
 func (cm *ComparisonManager) FilterShingleResultsByNGramLength(ngramLength int) ShingleResultsMap {
-	if cm.ShingleResults == nil {
-		return nil
-	}
-	return cm.ShingleResults.FilterByNGramLength(ngramLength)
+	return cm.CopyShingleResultsMap().FilterByNGramLength(ngramLength)
 }
 
 // LCS Data
 
 // GetLCSResultsMap retrieves the LCSResultsMap containing LCS results organized by type and input string.
+// It returns a copy, like CopyLCSResultsMap, so the map can be read while other goroutines add results; earlier
+// versions returned the LCSResults field itself. Changes made to the copy are not seen by the manager.
 func (cm *ComparisonManager) GetLCSResultsMap() LCSResultsMap {
-	if cm.LCSResults == nil {
-		return nil
-	}
-	return cm.LCSResults
+	return cm.CopyLCSResultsMap()
 }
 
-// CopyLCSResultsMap returns a copy of the LCSResultsMap from the ComparisonManager,
-// or nil if LCSResults is uninitialized.
+// CopyLCSResultsMap returns the stored LCS results as a new LCSResultsMap,
+// or nil if none were ever added.
 func (cm *ComparisonManager) CopyLCSResultsMap() LCSResultsMap {
-	if cm.LCSResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.lcsResults == nil {
 		return nil
 	}
-	return cm.LCSResults.GetCopy()
+	lrm := NewLCSResultsMap()
+	storedIndex(resultIndex[LCSResultType, string, LCSResult](lrm), cm.lcsResults, wrapLCS)
+	return lrm
 }
 
-// AddLCSResult adds an LCSResult to the LCSResults map in the ComparisonManager,
-// organizing it by type and input string.
+// AddLCSResult stores an LCSResult in the ComparisonManager, replacing any result with the same type and
+// second string.
 func (cm *ComparisonManager) AddLCSResult(result LCSResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.lcsResults == nil {
+		cm.lcsResults = NewResultStore[lcsKey, []string]()
+	}
+	cm.lcsResults.Add(lcsKey{result.resultType, result.GetString2()}, result.ToResult())
 	if cm.LCSResults == nil {
 		cm.LCSResults = NewLCSResultsMap()
 	}
@@ -256,44 +294,118 @@ func (cm *ComparisonManager) AddLCSResult(result LCSResult) {
 }
 
 // GetLCSResult retrieves the LCSResult object based on the provided LCSResultType and input string.
-// Returns nil if the LCSResults map is uninitialized.
+// Returns nil if no result is found.
 func (cm *ComparisonManager) GetLCSResult(lcsType LCSResultType, inputStr string) *LCSResult {
-	if cm.LCSResults == nil {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.lcsResults == nil {
 		return nil
 	}
-	return cm.LCSResults.Get(lcsType, inputStr)
+	r, ok := cm.lcsResults.Get(lcsKey{lcsType, inputStr})
+	if !ok {
+		return nil
+	}
+	result := wrapLCS(lcsType, r)
+	return &result
 }
 
 // GetLCSResultsByType retrieves LCSResults filtered by the specified LCSResultType from the ComparisonManager.
 func (cm *ComparisonManager) GetLCSResultsByType(lcsType LCSResultType) []LCSResult {
-	if cm.LCSResults == nil {
-		return nil
-	}
-	return cm.LCSResults.GetByType(lcsType)
+	return cm.CopyLCSResultsMap().GetByType(lcsType)
 }
 
 // FilterLCSResultsByType filters the LCS results based on the given LCSResultType and returns the filtered results map.
 func (cm *ComparisonManager) FilterLCSResultsByType(lcsType LCSResultType) LCSResultsMap {
-	if cm.LCSResults == nil {
-		return nil
-	}
-	return cm.LCSResults.FilterByType(lcsType)
+	return cm.CopyLCSResultsMap().FilterByType(lcsType)
 }
 
 // GetLCSResultsByComparisonString retrieves LCS results that match the provided comparison string.
 // It returns a slice of LCSResult or nil if no results are available.
 func (cm *ComparisonManager) GetLCSResultsByComparisonString(compStr string) []LCSResult {
-	if cm.LCSResults == nil {
-		return nil
-	}
-	return cm.LCSResults.GetByComparisonString(compStr)
+	return cm.CopyLCSResultsMap().GetByComparisonString(compStr)
 }
 
 // FilterLCSResultsByComparisonString filters LCS results based on the provided
 // comparison string and returns a filtered map.
 func (cm *ComparisonManager) FilterLCSResultsByComparisonString(compStr string) LCSResultsMap {
-	if cm.LCSResults == nil {
+	return cm.CopyLCSResultsMap().FilterByComparisonString(compStr)
+}
+
+// Similarity Matrices
+
+// GetSimilarityMatrixMap retrieves the SimilarityMatrixMap containing the matrices ingested by the manager.
+// It returns a copy, like CopySimilarityMatrixMap, so the map can be read while other goroutines add results.
+func (cm *ComparisonManager) GetSimilarityMatrixMap() SimilarityMatrixMap {
+	return cm.CopySimilarityMatrixMap()
+}
+
+// CopySimilarityMatrixMap returns a copy of the SimilarityMatrixMap, or nil if it is uninitialized.
+func (cm *ComparisonManager) CopySimilarityMatrixMap() SimilarityMatrixMap {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.matrices == nil {
+		return nil
+	}
+	return cm.matrices.GetCopy()
+}
+
+// AddSimilarityMatrix ingests a SimilarityMatrixResult, replacing any matrix previously stored for its algorithm.
+func (cm *ComparisonManager) AddSimilarityMatrix(result SimilarityMatrixResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.matrices == nil {
+		cm.matrices = NewSimilarityMatrixMap()
+	}
+	cm.matrices.Add(result)
+}
+
+// GetSimilarityMatrix retrieves the SimilarityMatrixResult stored for the given algorithm.
+// Returns nil if no matrix exists or the map is uninitialized.
+func (cm *ComparisonManager) GetSimilarityMatrix(algo Algorithm) *SimilarityMatrixResult {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.matrices == nil {
+		return nil
+	}
+	return cm.matrices.Get(algo)
+}
+
+// Edit Scripts
+
+// GetEditScriptResultsMap retrieves the EditScriptResultsMap containing edit scripts keyed by comparison string.
+// It returns a copy, like CopyEditScriptResultsMap, so the map can be read while other goroutines add results.
+func (cm *ComparisonManager) GetEditScriptResultsMap() EditScriptResultsMap {
+	return cm.CopyEditScriptResultsMap()
+}
+
+// CopyEditScriptResultsMap returns a copy of the EditScriptResultsMap, or nil if it is uninitialized.
+func (cm *ComparisonManager) CopyEditScriptResultsMap() EditScriptResultsMap {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.editScripts == nil {
+		return nil
+	}
+	return cm.editScripts.GetCopy()
+}
+
+// AddEditScriptResult ingests an EditScriptResult, replacing any edit script previously stored for its
+// comparison string.
+func (cm *ComparisonManager) AddEditScriptResult(result EditScriptResult) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.editScripts == nil {
+		cm.editScripts = NewEditScriptResultsMap()
+	}
+	cm.editScripts.Add(result)
+}
+
+// GetEditScriptResult retrieves the EditScriptResult stored for the given comparison string.
+// Returns nil if no edit script exists or the map is uninitialized.
+func (cm *ComparisonManager) GetEditScriptResult(compStr string) *EditScriptResult {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.editScripts == nil {
 		return nil
 	}
-	return cm.LCSResults.FilterByComparisonString(compStr)
+	return cm.editScripts.Get(compStr)
 }
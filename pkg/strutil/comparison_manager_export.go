@@ -0,0 +1,311 @@
+package strutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// Kinds of exported comparison manager results.
+const (
+	resultKindSimilarity = "similarity"
+	resultKindInt        = "int"
+	resultKindFloat      = "float"
+)
+
+// resultsCSVHeader is the header row written by ToCSV and expected by ImportCSV.
+var resultsCSVHeader = []string{"kind", "type", "string1", "string2", "split_length", "score", "error"}
+
+// managerResultJSON is the serialized form of one SimilarityResult or ComparisonResult.
+type managerResultJSON struct {
+	Kind        string   `json:"kind"`
+	Type        string   `json:"type"`
+	String1     string   `json:"string1"`
+	String2     string   `json:"string2"`
+	SplitLength *int     `json:"splitLength,omitempty"`
+	Score       *float64 `json:"score,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// ToJSON renders the similarity and comparison results of the manager as a JSON array of objects holding the
+// kind, the algorithm or comparison type name, the strings, the split length, the score and the error message.
+// Results are ordered by kind, type and comparison string. NaN and infinite scores are left out like missing ones.
+func (cm *ComparisonManager) ToJSON() (string, error) {
+	out, err := json.Marshal(cm.exportResults())
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ToCSV renders the similarity and comparison results of the manager as CSV with a header row and the same
+// columns and order as ToJSON. Missing split lengths, scores and errors are left empty, as are NaN and infinite
+// scores.
+func (cm *ComparisonManager) ToCSV() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(resultsCSVHeader); err != nil {
+		return "", err
+	}
+	for _, r := range cm.exportResults() {
+		record := []string{r.Kind, r.Type, r.String1, r.String2, "", "", r.Error}
+		if r.SplitLength != nil {
+			record[4] = strconv.Itoa(*r.SplitLength)
+		}
+		if r.Score != nil {
+			record[5] = strconv.FormatFloat(*r.Score, 'f', -1, 64)
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ImportJSON adds the results in data, as rendered by ToJSON, to the manager, replacing stored results with the
+// same type and comparison string. Errors with the message of an error returned by this package's comparisons,
+// such as ErrHammingDistanceFailure, are restored as that error; other messages are restored as plain errors.
+// Returns ErrInvalidResultsData and adds nothing if data is malformed.
+func (cm *ComparisonManager) ImportJSON(data string) error {
+	var records []managerResultJSON
+	if err := json.Unmarshal([]byte(data), &records); err != nil {
+		return errors2.ErrInvalidResultsData
+	}
+	return cm.importResults(records)
+}
+
+// ImportCSV adds the results in data, as rendered by ToCSV, to the manager, replacing stored results with the
+// same type and comparison string. Errors with the message of an error returned by this package's comparisons,
+// such as ErrHammingDistanceFailure, are restored as that error; other messages are restored as plain errors.
+// Returns ErrInvalidResultsData and adds nothing if data is malformed.
+func (cm *ComparisonManager) ImportCSV(data string) error {
+	r := csv.NewReader(bytes.NewBufferString(data))
+	r.FieldsPerRecord = len(resultsCSVHeader)
+	header, err := r.Read()
+	if err != nil || !slices.Equal(header, resultsCSVHeader) {
+		return errors2.ErrInvalidResultsData
+	}
+	var records []managerResultJSON
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors2.ErrInvalidResultsData
+		}
+		result := managerResultJSON{Kind: record[0], Type: record[1], String1: record[2], String2: record[3],
+			Error: record[6]}
+		if record[4] != "" {
+			splitLength, err := strconv.Atoi(record[4])
+			if err != nil {
+				return errors2.ErrInvalidResultsData
+			}
+			result.SplitLength = &splitLength
+		}
+		if record[5] != "" {
+			score, err := strconv.ParseFloat(record[5], 64)
+			if err != nil {
+				return errors2.ErrInvalidResultsData
+			}
+			result.Score = &score
+		}
+		records = append(records, result)
+	}
+	return cm.importResults(records)
+}
+
+// exportResults converts the similarity and comparison results of the manager into their serialized form.
+func (cm *ComparisonManager) exportResults() []managerResultJSON {
+	records := make([]managerResultJSON, 0)
+	for _, r := range cm.similarityResults(nil) {
+		record := managerResultJSON{Kind: resultKindSimilarity, Type: r.GetAlgorithmName(), String1: r.GetString1(),
+			String2: r.GetString2(), Score: float64Pointer(r.score), Error: errorMessage(r.GetError())}
+		records = append(records, record)
+	}
+	records = append(records, cm.exportComparisonResults()...)
+	sort.Slice(records, func(a, b int) bool {
+		if records[a].Kind != records[b].Kind {
+			return records[a].Kind > records[b].Kind
+		}
+		if records[a].Type != records[b].Type {
+			return records[a].Type < records[b].Type
+		}
+		return records[a].String2 < records[b].String2
+	})
+	return records
+}
+
+// exportComparisonResults converts the stored comparison results into their serialized form.
+func (cm *ComparisonManager) exportComparisonResults() []managerResultJSON {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.comparisons == nil {
+		return nil
+	}
+	var records []managerResultJSON
+	for key, r := range cm.comparisons.ints.All() {
+		records = append(records, exportComparisonResult(wrapComparisonInt(key.kind, r)))
+	}
+	for key, r := range cm.comparisons.floats.All() {
+		records = append(records, exportComparisonResult(wrapComparisonFloat(key.kind, r)))
+	}
+	return records
+}
+
+// exportComparisonResult converts a ComparisonResult into its serialized form.
+func exportComparisonResult(result ComparisonResult) managerResultJSON {
+	record := managerResultJSON{Type: result.GetTypeName(), String1: result.GetString1(),
+		String2: result.GetString2(), Error: errorMessage(result.GetError())}
+	if splitLength, err := result.GetSplitLength(); err == nil {
+		record.SplitLength = &splitLength
+	}
+	switch r := result.(type) {
+	case *ComparisonResultInt:
+		record.Kind = resultKindInt
+		if r.score != nil {
+			score := float64(*r.score)
+			record.Score = &score
+		}
+	case *ComparisonResultFloat:
+		record.Kind = resultKindFloat
+		record.Score = float64Pointer(r.score)
+	}
+	return record
+}
+
+// importResults validates every record before adding any of them to the manager.
+func (cm *ComparisonManager) importResults(records []managerResultJSON) error {
+	similarities := make([]SimilarityResult, 0, len(records))
+	comparisons := make([]ComparisonResult, 0, len(records))
+	for _, r := range records {
+		var err error
+		if r.Error != "" {
+			err = importedError(r.Error)
+		}
+		if r.Kind == resultKindSimilarity {
+			algo, ok := algorithmByName(r.Type)
+			if !ok {
+				return errors2.ErrInvalidResultsData
+			}
+			similarities = append(similarities, *NewSimilarityResult(algo, r.String1, r.String2,
+				float32Pointer(r.Score), err))
+			continue
+		}
+		compType, ok := comparisonResultTypeByName(r.Type)
+		if !ok {
+			return errors2.ErrInvalidResultsData
+		}
+		switch r.Kind {
+		case resultKindInt:
+			var score *int
+			if r.Score != nil {
+				s := int(*r.Score)
+				score = &s
+			}
+			comparisons = append(comparisons, NewComparisonResultInt(compType, r.String1, r.String2,
+				r.SplitLength, score, err))
+		case resultKindFloat:
+			comparisons = append(comparisons, NewComparisonResultFloat(compType, r.String1, r.String2,
+				r.SplitLength, float32Pointer(r.Score), err))
+		default:
+			return errors2.ErrInvalidResultsData
+		}
+	}
+	for _, r := range similarities {
+		cm.AddSimilarityResult(r)
+	}
+	for _, r := range comparisons {
+		cm.AddComparisonResult(r)
+	}
+	return nil
+}
+
+// algorithmByName returns the Algorithm whose AlgorithmTypeMap name is name.
+func algorithmByName(name string) (Algorithm, bool) {
+	for algo, n := range AlgorithmTypeMap {
+		if n == name {
+			return algo, true
+		}
+	}
+	return 0, false
+}
+
+// comparisonResultTypeByName returns the ComparisonResultType whose ComparisonResultTypeMap name is name.
+func comparisonResultTypeByName(name string) (ComparisonResultType, bool) {
+	for t, n := range ComparisonResultTypeMap {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// importableErrors are the errors restored by importedError. Each is matched by its message, on its own or as the
+// first line of a joined error.
+var importableErrors = []error{
+	errors2.ErrHammingDistanceFailure,
+	errors2.ErrInvalidAlgorithm,
+	errors2.ErrInvalidLengthRange,
+	errors2.ErrInvalidNgramMap,
+	errors2.ErrNilScore,
+	errors2.ErrNoSplitLengthSet,
+	errors2.ErrUnknownError,
+}
+
+// importedError returns the error exported with message msg. A message starting with the message of one of the
+// importableErrors is restored as that error, joined with a plain error holding the rest of the message.
+func importedError(msg string) error {
+	for _, sentinel := range importableErrors {
+		if msg == sentinel.Error() {
+			return sentinel
+		}
+		if rest, ok := strings.CutPrefix(msg, sentinel.Error()+"\n"); ok {
+			return errors.Join(sentinel, errors.New(rest))
+		}
+	}
+	return errors.New(msg)
+}
+
+// errorMessage returns the message of err, or "" if err is nil.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// float64Pointer converts an optional float32 to the float64 with the same shortest decimal representation,
+// so exported scores read as they print. Returns nil for NaN and infinite scores, which JSON cannot represent.
+func float64Pointer(f *float32) *float64 {
+	if f == nil || math.IsNaN(float64(*f)) || math.IsInf(float64(*f), 0) {
+		return nil
+	}
+	v, err := strconv.ParseFloat(strconv.FormatFloat(float64(*f), 'f', -1, 32), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// float32Pointer converts an optional float64 to an optional float32.
+func float32Pointer(f *float64) *float32 {
+	if f == nil {
+		return nil
+	}
+	v := float32(*f)
+	return &v
+}
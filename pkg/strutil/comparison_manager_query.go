@@ -0,0 +1,307 @@
+package strutil
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SimilarityPredicate reports whether a SimilarityResult is kept by ComparisonManager.Where.
+type SimilarityPredicate func(result SimilarityResult) bool
+
+// ScoreAbove keeps results scoring strictly more than threshold. Results with an error are dropped.
+func ScoreAbove(threshold float32) SimilarityPredicate {
+	return func(result SimilarityResult) bool {
+		score, err := result.GetScore()
+		return err == nil && score > threshold
+	}
+}
+
+// ScoreAtLeast keeps results scoring threshold or more. Results with an error are dropped.
+func ScoreAtLeast(threshold float32) SimilarityPredicate {
+	return func(result SimilarityResult) bool {
+		score, err := result.GetScore()
+		return err == nil && score >= threshold
+	}
+}
+
+// ScoreBelow keeps results scoring strictly less than threshold. Results with an error are dropped.
+func ScoreBelow(threshold float32) SimilarityPredicate {
+	return func(result SimilarityResult) bool {
+		score, err := result.GetScore()
+		return err == nil && score < threshold
+	}
+}
+
+// WithAlgorithm keeps results computed with any of the given algorithms.
+func WithAlgorithm(algos ...Algorithm) SimilarityPredicate {
+	return func(result SimilarityResult) bool {
+		for _, algo := range algos {
+			if result.algorithm == algo {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllOf keeps results accepted by every one of the given predicates.
+func AllOf(predicates ...SimilarityPredicate) SimilarityPredicate {
+	return func(result SimilarityResult) bool {
+		for _, p := range predicates {
+			if !p(result) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ConsensusScore is the combined similarity of one comparison string across the algorithms that scored it.
+type ConsensusScore struct {
+	String2    string  // the comparison string
+	Score      float32 // the mean or weighted mean of the scores
+	Algorithms int     // the number of algorithms that contributed a score
+}
+
+// AlgorithmSummary describes the similarity results stored for one algorithm.
+type AlgorithmSummary struct {
+	Algorithm Algorithm
+	Scored    int     // results with a finite score
+	Unscored  int     // results without an error whose score is missing, NaN or infinite
+	Errors    int     // results with an error
+	Min       float32 // lowest score
+	Max       float32 // highest score
+	Mean      float32 // mean score
+	Best      string  // comparison string with the highest score
+	Worst     string  // comparison string with the lowest score
+}
+
+// Rank returns the scored SimilarityResults of the given algorithms, or of every algorithm if none are given,
+// ordered by descending score, then by algorithm and comparison string. Results with an error or without a
+// finite score, such as the NaN some algorithms return for two empty strings, are left out.
+func (cm *ComparisonManager) Rank(algos ...Algorithm) []SimilarityResult {
+	var results []SimilarityResult
+	for _, r := range cm.similarityResults(algos) {
+		if _, ok := finiteScore(r); ok {
+			results = append(results, r)
+		}
+	}
+	rankSimilarityResults(results)
+	return results
+}
+
+// Best returns the highest scoring SimilarityResult of the given algorithms, or of every algorithm if none are
+// given. Returns nil if there is no scored result.
+func (cm *ComparisonManager) Best(algos ...Algorithm) *SimilarityResult {
+	ranked := cm.Rank(algos...)
+	if len(ranked) == 0 {
+		return nil
+	}
+	return &ranked[0]
+}
+
+// Worst returns the lowest scoring SimilarityResult of the given algorithms, or of every algorithm if none are
+// given. Returns nil if there is no scored result.
+func (cm *ComparisonManager) Worst(algos ...Algorithm) *SimilarityResult {
+	ranked := cm.Rank(algos...)
+	if len(ranked) == 0 {
+		return nil
+	}
+	return &ranked[len(ranked)-1]
+}
+
+// Where returns every SimilarityResult accepted by predicate in the order of Rank, followed by accepted results
+// without a finite score and then by accepted results with an error, each ordered by algorithm and comparison
+// string.
+func (cm *ComparisonManager) Where(predicate SimilarityPredicate) []SimilarityResult {
+	var results []SimilarityResult
+	for _, r := range cm.similarityResults(nil) {
+		if predicate == nil || predicate(r) {
+			results = append(results, r)
+		}
+	}
+	rankSimilarityResults(results)
+	return results
+}
+
+// MeanScores returns the unweighted mean score of every comparison string across the algorithms that scored it,
+// ordered by descending score and then by comparison string.
+func (cm *ComparisonManager) MeanScores() []ConsensusScore {
+	weights := make(map[Algorithm]float32)
+	for _, r := range cm.similarityResults(nil) {
+		weights[r.algorithm] = 1
+	}
+	return cm.consensus(weights)
+}
+
+// WeightedConsensus returns the weighted mean score of every comparison string across the weighted algorithms
+// that scored it, ordered by descending score and then by comparison string. Algorithms without a weight are
+// ignored. Returns an error if weights is empty, contains a negative weight, sums to zero or contains an invalid
+// algorithm.
+func (cm *ComparisonManager) WeightedConsensus(weights map[Algorithm]float32) ([]ConsensusScore, error) {
+	if _, err := fuzzyWeights(weights); err != nil {
+		return nil, err
+	}
+	return cm.consensus(weights), nil
+}
+
+// Summary returns an AlgorithmSummary for every algorithm with stored similarity results, ordered by algorithm.
+func (cm *ComparisonManager) Summary() []AlgorithmSummary {
+	byAlgorithm := make(map[Algorithm]*AlgorithmSummary)
+	sums := make(map[Algorithm]float32)
+	for _, r := range cm.similarityResults(nil) {
+		summary := byAlgorithm[r.algorithm]
+		if summary == nil {
+			summary = &AlgorithmSummary{Algorithm: r.algorithm}
+			byAlgorithm[r.algorithm] = summary
+		}
+		if r.GetError() != nil {
+			summary.Errors++
+			continue
+		}
+		score, ok := finiteScore(r)
+		if !ok {
+			summary.Unscored++
+			continue
+		}
+		if summary.Scored == 0 || score > summary.Max {
+			summary.Max, summary.Best = score, r.GetString2()
+		}
+		if summary.Scored == 0 || score < summary.Min {
+			summary.Min, summary.Worst = score, r.GetString2()
+		}
+		summary.Scored++
+		sums[r.algorithm] += score
+	}
+	summaries := make([]AlgorithmSummary, 0, len(byAlgorithm))
+	for algo, summary := range byAlgorithm {
+		if summary.Scored > 0 {
+			summary.Mean = sums[algo] / float32(summary.Scored)
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(a, b int) bool {
+		return summaries[a].Algorithm < summaries[b].Algorithm
+	})
+	return summaries
+}
+
+// Report renders the Summary of every algorithm followed by the MeanScores of every comparison string.
+func (cm *ComparisonManager) Report() string {
+	summaries := cm.Summary()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Similarity Report: %d algorithms\n", len(summaries)))
+	for _, s := range summaries {
+		sb.WriteString(fmt.Sprintf("%s: %d scored, %d errors", s.Algorithm, s.Scored, s.Errors))
+		if s.Unscored > 0 {
+			sb.WriteString(fmt.Sprintf(", %d unscored", s.Unscored))
+		}
+		if s.Scored > 0 {
+			sb.WriteString(fmt.Sprintf(", min %.4f (%q), mean %.4f, max %.4f (%q)",
+				s.Min, s.Worst, s.Mean, s.Max, s.Best))
+		}
+		sb.WriteString("\n")
+	}
+	means := cm.MeanScores()
+	if len(means) > 0 {
+		sb.WriteString("Mean Scores:\n")
+	}
+	for i, m := range means {
+		sb.WriteString(fmt.Sprintf("%d. %q: %.4f (%d algorithms)\n", i+1, m.String2, m.Score, m.Algorithms))
+	}
+	return sb.String()
+}
+
+// similarityResults returns the stored SimilarityResults of the given algorithms, or of every algorithm if algos
+// is empty.
+func (cm *ComparisonManager) similarityResults(algos []Algorithm) []SimilarityResult {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	if cm.similarities == nil {
+		return nil
+	}
+	var results []SimilarityResult
+	for key, r := range cm.similarities.All() {
+		if len(algos) == 0 || slices.Contains(algos, key.kind) {
+			results = append(results, wrapSimilarity(key.kind, r))
+		}
+	}
+	return results
+}
+
+// consensus combines the scores of the weighted algorithms for every comparison string.
+func (cm *ComparisonManager) consensus(weights map[Algorithm]float32) []ConsensusScore {
+	type total struct {
+		sum, weight float32
+		count       int
+	}
+	totals := make(map[string]*total)
+	for _, r := range cm.Rank() {
+		weight := weights[r.algorithm]
+		if weight == 0 {
+			continue
+		}
+		t := totals[r.GetString2()]
+		if t == nil {
+			t = &total{}
+			totals[r.GetString2()] = t
+		}
+		t.sum += *r.score * weight
+		t.weight += weight
+		t.count++
+	}
+	scores := make([]ConsensusScore, 0, len(totals))
+	for s, t := range totals {
+		scores = append(scores, ConsensusScore{String2: s, Score: t.sum / t.weight, Algorithms: t.count})
+	}
+	sort.Slice(scores, func(a, b int) bool {
+		if scores[a].Score != scores[b].Score {
+			return scores[a].Score > scores[b].Score
+		}
+		return scores[a].String2 < scores[b].String2
+	})
+	return scores
+}
+
+// rankSimilarityResults sorts results by descending score, followed by results without a finite score and then
+// by errored results, each then ordered by algorithm and comparison string.
+func rankSimilarityResults(results []SimilarityResult) {
+	sort.Slice(results, func(a, b int) bool {
+		tierA, tierB := rankTier(results[a]), rankTier(results[b])
+		if tierA != tierB {
+			return tierA < tierB
+		}
+		if tierA == 0 && *results[a].score != *results[b].score {
+			return *results[a].score > *results[b].score
+		}
+		if results[a].algorithm != results[b].algorithm {
+			return results[a].algorithm < results[b].algorithm
+		}
+		return results[a].GetString2() < results[b].GetString2()
+	})
+}
+
+// rankTier returns 0 for a result with a finite score, 1 for a result without an error or a finite score and 2
+// for a result with an error.
+func rankTier(result SimilarityResult) int {
+	if result.GetError() != nil {
+		return 2
+	}
+	if _, ok := finiteScore(result); !ok {
+		return 1
+	}
+	return 0
+}
+
+// finiteScore returns the score of result and true if result has no error and its score is neither NaN nor
+// infinite.
+func finiteScore(result SimilarityResult) (float32, bool) {
+	score, err := result.GetScore()
+	if err != nil || math.IsNaN(float64(score)) || math.IsInf(float64(score), 0) {
+		return 0, false
+	}
+	return score, true
+}
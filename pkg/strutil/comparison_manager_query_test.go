@@ -0,0 +1,359 @@
+package strutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// newQueryTestManager returns a manager holding known similarity scores of "apple" against three strings,
+// one errored result and one comparison result of each kind.
+func newQueryTestManager() *ComparisonManager {
+	cm := NewComparisonManager()
+	scores := []struct {
+		algo  Algorithm
+		s2    string
+		score float32
+	}{
+		{Levenshtein, "apples", 0.8},
+		{Levenshtein, "apply", 0.6},
+		{Levenshtein, "banana", 0.1},
+		{JaroWinkler, "apples", 0.9},
+		{JaroWinkler, "apply", 0.8},
+	}
+	for _, s := range scores {
+		score := s.score
+		cm.AddSimilarityResult(*NewSimilarityResult(s.algo, "apple", s.s2, &score, nil))
+	}
+	cm.AddSimilarityResult(*NewSimilarityResult(Hamming, "apple", "banana", nil, errors2.ErrHammingDistanceFailure))
+	distance, splitLength := 1, 3
+	cm.AddComparisonResult(NewComparisonResultInt(LevDist, "apple", "apples", nil, &distance, nil))
+	jaccard := float32(0.25)
+	cm.AddComparisonResult(NewComparisonResultFloat(JaccardSim, "apple", "apply", &splitLength, &jaccard, nil))
+	return cm
+}
+
+// similarityKeys renders results as algorithm:string2 pairs for comparison.
+func similarityKeys(results []SimilarityResult) string {
+	keys := make([]string, 0, len(results))
+	for _, r := range results {
+		keys = append(keys, r.GetAlgorithmName()+":"+r.GetString2())
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestComparisonManagerRank(t *testing.T) {
+	cm := newQueryTestManager()
+	tests := []struct {
+		name     string
+		algos    []Algorithm
+		expected string
+	}{
+		{"RankAll", nil,
+			"Jaro-Winkler:apples,Levenshtein:apples,Jaro-Winkler:apply,Levenshtein:apply,Levenshtein:banana"},
+		{"RankLevenshtein", []Algorithm{Levenshtein}, "Levenshtein:apples,Levenshtein:apply,Levenshtein:banana"},
+		{"RankErroredOnly", []Algorithm{Hamming}, ""},
+		{"RankMissing", []Algorithm{Cosine}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if keys := similarityKeys(cm.Rank(tt.algos...)); keys != tt.expected {
+				t.Errorf("Rank(%v) = %s; want %s", tt.algos, keys, tt.expected)
+			}
+		})
+	}
+	if best := cm.Best(Levenshtein); best == nil || best.GetString2() != "apples" {
+		t.Errorf("Best(Levenshtein) = %v; want apples", best)
+	}
+	if worst := cm.Worst(); worst == nil || worst.GetString2() != "banana" {
+		t.Errorf("Worst() = %v; want banana", worst)
+	}
+	if best := cm.Best(Hamming); best != nil {
+		t.Errorf("Best(Hamming) = %v; want nil", best)
+	}
+	if worst := NewComparisonManager().Worst(); worst != nil {
+		t.Errorf("Worst() on an empty manager = %v; want nil", worst)
+	}
+}
+
+func TestComparisonManagerWhere(t *testing.T) {
+	cm := newQueryTestManager()
+	tests := []struct {
+		name      string
+		predicate SimilarityPredicate
+		expected  string
+	}{
+		{"WhereAbove", ScoreAbove(0.8), "Jaro-Winkler:apples"},
+		{"WhereAtLeast", ScoreAtLeast(0.8), "Jaro-Winkler:apples,Levenshtein:apples,Jaro-Winkler:apply"},
+		{"WhereBelow", ScoreBelow(0.7), "Levenshtein:apply,Levenshtein:banana"},
+		{"WhereAlgorithm", WithAlgorithm(Hamming, JaroWinkler),
+			"Jaro-Winkler:apples,Jaro-Winkler:apply,Hamming:banana"},
+		{"WhereAllOf", AllOf(WithAlgorithm(Levenshtein), ScoreAtLeast(0.6)),
+			"Levenshtein:apples,Levenshtein:apply"},
+		{"WhereNil", nil, "Jaro-Winkler:apples,Levenshtein:apples,Jaro-Winkler:apply,Levenshtein:apply," +
+			"Levenshtein:banana,Hamming:banana"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if keys := similarityKeys(cm.Where(tt.predicate)); keys != tt.expected {
+				t.Errorf("Where() = %s; want %s", keys, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComparisonManagerConsensus(t *testing.T) {
+	cm := newQueryTestManager()
+	means := cm.MeanScores()
+	expected := []ConsensusScore{{"apples", 0.85, 2}, {"apply", 0.7, 2}, {"banana", 0.1, 1}}
+	if len(means) != len(expected) {
+		t.Fatalf("MeanScores() = %v; want %v", means, expected)
+	}
+	for i, m := range means {
+		if m.String2 != expected[i].String2 || m.Algorithms != expected[i].Algorithms ||
+			!floatsClose(m.Score, expected[i].Score) {
+			t.Errorf("MeanScores()[%d] = %v; want %v", i, m, expected[i])
+		}
+	}
+
+	weighted, err := cm.WeightedConsensus(map[Algorithm]float32{Levenshtein: 1, JaroWinkler: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weighted[0].String2 != "apples" || !floatsClose(weighted[0].Score, 0.875) ||
+		weighted[2].String2 != "banana" || weighted[2].Algorithms != 1 {
+		t.Errorf("WeightedConsensus() = %v; want apples at 0.875 first and banana from one algorithm last", weighted)
+	}
+	only, err := cm.WeightedConsensus(map[Algorithm]float32{JaroWinkler: 1})
+	if err != nil || len(only) != 2 {
+		t.Errorf("WeightedConsensus(JaroWinkler) = %v, %v; want the two Jaro-Winkler strings", only, err)
+	}
+	for _, weights := range []map[Algorithm]float32{nil, {Levenshtein: -1, Jaro: 2}, {Levenshtein: 0}} {
+		if _, err := cm.WeightedConsensus(weights); !errors.Is(err, errors2.ErrInvalidFuzzyWeights) {
+			t.Errorf("WeightedConsensus(%v) error = %v; want %v", weights, err, errors2.ErrInvalidFuzzyWeights)
+		}
+	}
+}
+
+func TestComparisonManagerSummary(t *testing.T) {
+	cm := newQueryTestManager()
+	summaries := cm.Summary()
+	if len(summaries) != 3 {
+		t.Fatalf("Summary() = %v; want 3 algorithms", summaries)
+	}
+	lev := summaries[0]
+	if lev.Algorithm != Levenshtein || lev.Scored != 3 || lev.Errors != 0 || lev.Best != "apples" ||
+		lev.Worst != "banana" || !floatsClose(lev.Max, 0.8) || !floatsClose(lev.Min, 0.1) ||
+		!floatsClose(lev.Mean, 0.5) {
+		t.Errorf("Summary()[0] = %+v; want the Levenshtein scores", lev)
+	}
+	if ham := summaries[1]; ham.Algorithm != Hamming || ham.Scored != 0 || ham.Errors != 1 {
+		t.Errorf("Summary()[1] = %+v; want one Hamming error", ham)
+	}
+
+	report := cm.Report()
+	for _, want := range []string{
+		"Similarity Report: 3 algorithms\n",
+		"Levenshtein: 3 scored, 0 errors, min 0.1000 (\"banana\"), mean 0.5000, max 0.8000 (\"apples\")\n",
+		"Hamming: 0 scored, 1 errors\n",
+		"1. \"apples\": 0.8500 (2 algorithms)\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report() = %q; want it to contain %q", report, want)
+		}
+	}
+}
+
+func TestComparisonManagerExport(t *testing.T) {
+	cm := newQueryTestManager()
+	exported, err := cm.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(exported, `{"kind":"similarity","type":"Jaro-Winkler","string1":"apple",`+
+		`"string2":"apples","score":0.9}`) {
+		t.Errorf("ToJSON() = %s; want a Jaro-Winkler record scored 0.9", exported)
+	}
+	csvOut, err := cm.ToCSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(csvOut, "kind,type,string1,string2,split_length,score,error\n") ||
+		!strings.Contains(csvOut, "float,Jaccard Similarity,apple,apply,3,0.25,\n") {
+		t.Errorf("ToCSV() = %s; want a header and the Jaccard record", csvOut)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		load func(cm *ComparisonManager, data string) error
+	}{
+		{"ImportJSON", exported, (*ComparisonManager).ImportJSON},
+		{"ImportCSV", csvOut, (*ComparisonManager).ImportCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := NewComparisonManager()
+			if err := tt.load(imported, tt.data); err != nil {
+				t.Fatal(err)
+			}
+			for algo, byString := range cm.GetSimilarityResultsMap() {
+				for s2, r := range byString {
+					if !r.IsMatch(imported.GetSimilarityResult(algo, s2)) {
+						t.Errorf("imported %s %s = %v; want %v", algo, s2, imported.GetSimilarityResult(algo, s2), r)
+					}
+				}
+			}
+			for compType, byString := range cm.GetComparisonResultsMap() {
+				for s2, r := range byString {
+					if !(*r).IsMatch(imported.GetComparisonResult(compType, s2)) {
+						t.Errorf("imported %s %s does not match", compType, s2)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestComparisonManagerNonFiniteScores(t *testing.T) {
+	cm, err := CompareAll("", []string{"", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nonFinite int
+	for _, r := range cm.Where(nil) {
+		if r.GetError() == nil && rankTier(r) == 1 {
+			nonFinite++
+		}
+	}
+	if nonFinite == 0 {
+		t.Fatal("CompareAll(\"\", [\"\" \"a\"]) stored no NaN scores; the test needs at least one")
+	}
+
+	for _, r := range cm.Rank() {
+		if _, ok := finiteScore(r); !ok {
+			t.Errorf("Rank() includes %s %q without a finite score", r.GetAlgorithmName(), r.GetString2())
+		}
+	}
+	where := cm.Where(nil)
+	for i := 1; i < len(where); i++ {
+		if rankTier(where[i-1]) > rankTier(where[i]) {
+			t.Errorf("Where(nil) ranks %s %q before %s %q", where[i-1].GetAlgorithmName(), where[i-1].GetString2(),
+				where[i].GetAlgorithmName(), where[i].GetString2())
+		}
+	}
+
+	var unscored int
+	for _, s := range cm.Summary() {
+		unscored += s.Unscored
+	}
+	if unscored != nonFinite {
+		t.Errorf("Summary() counts %d unscored results; want %d", unscored, nonFinite)
+	}
+	if report := cm.Report(); strings.Contains(report, "NaN") || !strings.Contains(report, "unscored") {
+		t.Errorf("Report() = %q; want unscored counts and no NaN", report)
+	}
+
+	exported, err := cm.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v; want NaN scores left out", err)
+	}
+	csvOut, err := cm.ToCSV()
+	if err != nil || strings.Contains(csvOut, "NaN") {
+		t.Errorf("ToCSV() = %q, %v; want NaN scores left empty", csvOut, err)
+	}
+	imported := NewComparisonManager()
+	if err := imported.ImportJSON(exported); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := similarityKeys(imported.Rank()), similarityKeys(cm.Rank()); got != want {
+		t.Errorf("imported Rank() = %s; want %s", got, want)
+	}
+}
+
+func TestComparisonManagerImportErrors(t *testing.T) {
+	cm := NewComparisonManager()
+	cm.AddSimilarityResult(*similarity("abc", "ab", Hamming))
+	cm.AddComparisonResult(hammingDistance("abc", "ab"))
+	cm.AddComparisonResult(NewComparisonResultFloat(JaccardSim, "a", "b", nil, nil, errors2.ErrInvalidLengthRange))
+	cm.AddComparisonResult(NewComparisonResultInt(LCSLength, "a", "b", nil, nil, errors.New("custom failure")))
+	exported, err := cm.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvOut, err := cm.ToCSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		load func(cm *ComparisonManager, data string) error
+	}{
+		{"ImportJSON", exported, (*ComparisonManager).ImportJSON},
+		{"ImportCSV", csvOut, (*ComparisonManager).ImportCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := NewComparisonManager()
+			if err := tt.load(imported, tt.data); err != nil {
+				t.Fatal(err)
+			}
+			hammingErr := imported.GetComparisonResult(HammingDist, "ab").GetError()
+			if !errors.Is(hammingErr, errors2.ErrHammingDistanceFailure) ||
+				hammingErr.Error() != cm.GetComparisonResult(HammingDist, "ab").GetError().Error() {
+				t.Errorf("Hamming error = %v; want %v", hammingErr, errors2.ErrHammingDistanceFailure)
+			}
+			if err := imported.GetComparisonResult(JaccardSim, "b").GetError(); err != errors2.ErrInvalidLengthRange {
+				t.Errorf("Jaccard error = %v; want %v", err, errors2.ErrInvalidLengthRange)
+			}
+			if err := imported.GetComparisonResult(LCSLength, "b").GetError(); err == nil ||
+				err.Error() != "custom failure" {
+				t.Errorf("LCS error = %v; want custom failure", err)
+			}
+			if err := imported.GetSimilarityResult(Hamming, "ab").GetError(); err == nil ||
+				err.Error() != cm.GetSimilarityResult(Hamming, "ab").GetError().Error() {
+				t.Errorf("Hamming similarity error = %v; want the exported message", err)
+			}
+		})
+	}
+}
+
+func TestComparisonManagerImportInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		load func(cm *ComparisonManager, data string) error
+	}{
+		{"JSONMalformed", `{"kind":`, (*ComparisonManager).ImportJSON},
+		{"JSONUnknownAlgorithm", `[{"kind":"similarity","type":"Nope","string1":"a","string2":"b"}]`,
+			(*ComparisonManager).ImportJSON},
+		{"JSONUnknownKind", `[{"kind":"bool","type":"Jaro Similarity","string1":"a","string2":"b"}]`,
+			(*ComparisonManager).ImportJSON},
+		{"CSVHeader", "kind,type\n", (*ComparisonManager).ImportCSV},
+		{"CSVScore", "kind,type,string1,string2,split_length,score,error\nint,LCS Length,a,b,,x,\n",
+			(*ComparisonManager).ImportCSV},
+		{"CSVPartial", "kind,type,string1,string2,split_length,score,error\nsimilarity,Jaro,a,b,,0.5,\n" +
+			"similarity,Nope,a,c,,0.5,\n", (*ComparisonManager).ImportCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewComparisonManager()
+			if err := tt.load(cm, tt.data); !errors.Is(err, errors2.ErrInvalidResultsData) {
+				t.Errorf("import error = %v; want %v", err, errors2.ErrInvalidResultsData)
+			}
+			if len(cm.GetSimilarityResultsMap()) != 0 || len(cm.GetComparisonResultsMap()) != 0 {
+				t.Error("a failed import added results")
+			}
+		})
+	}
+}
+
+// floatsClose reports whether two scores are equal within float32 rounding.
+func floatsClose(a, b float32) bool {
+	d := a - b
+	return d < 1e-6 && d > -1e-6
+}
@@ -0,0 +1,223 @@
+package strutil
+
+// resultKey identifies a result held by the ComparisonManager by its kind, such as an Algorithm or a
+// ComparisonResultType, and its key, the comparison string or the n-gram length.
+type resultKey[K, S comparable] struct {
+	kind K
+	key  S
+}
+
+// comparisonKey identifies a comparison result by its type and comparison string.
+type comparisonKey = resultKey[ComparisonResultType, string]
+
+// similarityKey identifies a similarity result by its algorithm and comparison string.
+type similarityKey = resultKey[Algorithm, string]
+
+// lcsKey identifies an LCS result by its type and comparison string.
+type lcsKey = resultKey[LCSResultType, string]
+
+// shingleKey identifies a shingle result by its type and n-gram length.
+type shingleKey = resultKey[ShingleResultType, int]
+
+// comparisonStores holds the comparison results of a ComparisonManager by score type. A type and comparison
+// string identify at most one result across both stores.
+type comparisonStores struct {
+	ints   *ResultStore[comparisonKey, int]
+	floats *ResultStore[comparisonKey, float32]
+}
+
+// newComparisonStores initializes and returns empty comparisonStores.
+func newComparisonStores() *comparisonStores {
+	return &comparisonStores{
+		ints:   NewResultStore[comparisonKey, int](),
+		floats: NewResultStore[comparisonKey, float32](),
+	}
+}
+
+// add stores result, replacing any result with the same type and comparison string.
+// Other ComparisonResult implementations are ignored.
+func (cs *comparisonStores) add(result ComparisonResult) {
+	switch r := result.(type) {
+	case *ComparisonResultInt:
+		key := comparisonKey{r.comparisonType, r.GetString2()}
+		cs.floats.Remove(key)
+		cs.ints.Add(key, r.ToResult())
+	case *ComparisonResultFloat:
+		key := comparisonKey{r.comparisonType, r.GetString2()}
+		cs.ints.Remove(key)
+		cs.floats.Add(key, r.ToResult())
+	}
+}
+
+// get returns the result stored for the type and comparison string, or nil if there is none.
+func (cs *comparisonStores) get(key comparisonKey) ComparisonResult {
+	if r, ok := cs.ints.Get(key); ok {
+		return wrapComparisonInt(key.kind, r)
+	}
+	if r, ok := cs.floats.Get(key); ok {
+		return wrapComparisonFloat(key.kind, r)
+	}
+	return nil
+}
+
+// resultsMap returns the stored results as a ComparisonResultsMap.
+func (cs *comparisonStores) resultsMap() ComparisonResultsMap {
+	crm := NewComparisonResultsMap()
+	storedIndex(resultIndex[ComparisonResultType, string, ComparisonResult](crm), cs.ints, wrapComparisonInt)
+	storedIndex(resultIndex[ComparisonResultType, string, ComparisonResult](crm), cs.floats, wrapComparisonFloat)
+	return crm
+}
+
+// shingleStores holds the shingle results of a ComparisonManager by value type. A type and n-gram length
+// identify at most one result across all stores.
+type shingleStores struct {
+	maps    *ResultStore[shingleKey, map[string]int]
+	slices  *ResultStore[shingleKey, []string]
+	minHash *ResultStore[shingleKey, []uint64]
+	simHash *ResultStore[shingleKey, uint64]
+}
+
+// newShingleStores initializes and returns empty shingleStores.
+func newShingleStores() *shingleStores {
+	return &shingleStores{
+		maps:    NewResultStore[shingleKey, map[string]int](),
+		slices:  NewResultStore[shingleKey, []string](),
+		minHash: NewResultStore[shingleKey, []uint64](),
+		simHash: NewResultStore[shingleKey, uint64](),
+	}
+}
+
+// add stores result, replacing any result with the same type and n-gram length.
+// Other ShingleResult implementations are ignored.
+func (ss *shingleStores) add(result ShingleResult) {
+	switch result.(type) {
+	case *ShingleMapResult, *ShingleSliceResult, *MinHashResult, *SimHashResult:
+	default:
+		return
+	}
+	key := shingleKey{result.GetType(), result.GetNgramLength()}
+	ss.maps.Remove(key)
+	ss.slices.Remove(key)
+	ss.minHash.Remove(key)
+	ss.simHash.Remove(key)
+	switch r := result.(type) {
+	case *ShingleMapResult:
+		ss.maps.Add(key, r.ToResult())
+	case *ShingleSliceResult:
+		ss.slices.Add(key, r.ToResult())
+	case *MinHashResult:
+		ss.minHash.Add(key, r.ToResult())
+	case *SimHashResult:
+		ss.simHash.Add(key, r.ToResult())
+	}
+}
+
+// get returns the result stored for the type and n-gram length, or nil if there is none.
+func (ss *shingleStores) get(key shingleKey) ShingleResult {
+	if r, ok := ss.maps.Get(key); ok {
+		return wrapShingleMap(key.kind, r)
+	}
+	if r, ok := ss.slices.Get(key); ok {
+		return wrapShingleSlice(key.kind, r)
+	}
+	if r, ok := ss.minHash.Get(key); ok {
+		return wrapMinHash(key.kind, r)
+	}
+	if r, ok := ss.simHash.Get(key); ok {
+		return wrapSimHash(key.kind, r)
+	}
+	return nil
+}
+
+// resultsMap returns the stored results as a ShingleResultsMap.
+func (ss *shingleStores) resultsMap() ShingleResultsMap {
+	srm := NewShingleResultsMap()
+	index := resultIndex[ShingleResultType, int, ShingleResult](srm)
+	storedIndex(index, ss.maps, wrapShingleMap)
+	storedIndex(index, ss.slices, wrapShingleSlice)
+	storedIndex(index, ss.minHash, wrapMinHash)
+	storedIndex(index, ss.simHash, wrapSimHash)
+	return srm
+}
+
+// storedIndex adds every result in store to index, wrapped in its legacy result type.
+func storedIndex[K, S comparable, T, V any](index resultIndex[K, S, V],
+	store *ResultStore[resultKey[K, S], T],
+	wrap func(kind K, result *Result[T]) V) {
+	for key, result := range store.All() {
+		index.add(key.kind, key.key, wrap(key.kind, result))
+	}
+}
+
+// wrapSimilarity returns result as the SimilarityResult of algo.
+func wrapSimilarity(algo Algorithm, result *Result[float32]) SimilarityResult {
+	return SimilarityResult{algorithm: algo, string1: result.input(0), string2: result.input(1),
+		score: result.value, err: result.meta.Err}
+}
+
+// wrapLCS returns result as an LCSResult of the given type.
+func wrapLCS(resultType LCSResultType, result *Result[[]string]) LCSResult {
+	return LCSResult{resultType: resultType, string1: result.input(0), string2: result.input(1),
+		result: result.value, err: result.meta.Err}
+}
+
+// wrapComparisonInt returns result as a ComparisonResultInt of the given type.
+func wrapComparisonInt(comparisonType ComparisonResultType, result *Result[int]) ComparisonResult {
+	return &ComparisonResultInt{comparisonType: comparisonType, string1: result.input(0), string2: result.input(1),
+		splitLength: splitLengthParam(result), score: result.value, err: result.meta.Err}
+}
+
+// wrapComparisonFloat returns result as a ComparisonResultFloat of the given type.
+func wrapComparisonFloat(comparisonType ComparisonResultType, result *Result[float32]) ComparisonResult {
+	return &ComparisonResultFloat{comparisonType: comparisonType, string1: result.input(0),
+		string2: result.input(1), splitLength: splitLengthParam(result), score: result.value, err: result.meta.Err}
+}
+
+// wrapShingleMap returns result as a ShingleMapResult of the given type.
+func wrapShingleMap(resultType ShingleResultType, result *Result[map[string]int]) ShingleResult {
+	var shingles map[string]int
+	if result.value != nil {
+		shingles = *result.value
+	}
+	ngram, _ := result.intParam("ngram")
+	return &ShingleMapResult{resultType: resultType, input: result.input(0), ngram: ngram, shingles: shingles,
+		err: result.meta.Err}
+}
+
+// wrapShingleSlice returns result as a ShingleSliceResult of the given type.
+func wrapShingleSlice(resultType ShingleResultType, result *Result[[]string]) ShingleResult {
+	ngram, _ := result.intParam("ngram")
+	return &ShingleSliceResult{resultType: resultType, input: result.input(0), ngram: ngram,
+		shingles: result.value, err: result.meta.Err}
+}
+
+// wrapMinHash returns result as a MinHashResult of the given type.
+func wrapMinHash(resultType ShingleResultType, result *Result[[]uint64]) ShingleResult {
+	var signature []uint64
+	if result.value != nil {
+		signature = *result.value
+	}
+	ngram, _ := result.intParam("ngram")
+	return &MinHashResult{resultType: resultType, input: result.input(0), ngram: ngram, signature: signature,
+		err: result.meta.Err}
+}
+
+// wrapSimHash returns result as a SimHashResult of the given type.
+func wrapSimHash(resultType ShingleResultType, result *Result[uint64]) ShingleResult {
+	var fingerprint uint64
+	if result.value != nil {
+		fingerprint = *result.value
+	}
+	ngram, _ := result.intParam("ngram")
+	return &SimHashResult{resultType: resultType, input: result.input(0), ngram: ngram, fingerprint: fingerprint,
+		err: result.meta.Err}
+}
+
+// splitLengthParam returns the "split_length" parameter of result, or nil if it is not set.
+func splitLengthParam[T any](result *Result[T]) *int {
+	splitLength, ok := result.intParam("split_length")
+	if !ok {
+		return nil
+	}
+	return &splitLength
+}
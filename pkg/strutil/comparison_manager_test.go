@@ -1,6 +1,10 @@
 package strutil
 
-import "testing"
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
 
 var (
 	testMan1 = New("Hello, World!").
@@ -1043,3 +1047,139 @@ func TestEmptyAdds(t *testing.T) {
 		t.Errorf("Expected result to not be nil")
 	}
 }
+
+// TestComparisonManagerConcurrentAccess shares one manager between writers and readers of every result kind;
+// run it with -race to check the locking.
+func TestComparisonManagerConcurrentAccess(t *testing.T) {
+	cm := NewComparisonManager()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				other := fmt.Sprintf("word%d-%d", w, i)
+				cm.AddSimilarityResult(*similarity("word", other, Levenshtein))
+				cm.AddComparisonResult(levenshteinDistance("word", other))
+				cm.AddShingleResult(shingle(other, 2))
+				cm.AddLCSResult(*lcsBacktrack("word", other))
+				cm.AddEditScriptResult(*editOperations("word", other))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				other := fmt.Sprintf("word%d-%d", w, i)
+				cm.GetSimilarityResult(Levenshtein, other)
+				cm.GetComparisonResultsByString(other)
+				cm.CopyShingleResultsMap()
+				cm.FilterLCSResultsByComparisonString(other)
+				cm.GetEditScriptResult(other)
+				cm.Rank(Levenshtein)
+				cm.Summary()
+			}
+		}()
+	}
+	wg.Wait()
+	if count := cm.CopySimilarityResultsMap().EntryCount(); count != 400 {
+		t.Errorf("concurrent AddSimilarityResult stored %d results; want 400", count)
+	}
+	if count := len(cm.GetEditScriptResultsMap()); count != 400 {
+		t.Errorf("concurrent AddEditScriptResult stored %d results; want 400", count)
+	}
+}
+
+// TestComparisonManagerMapSnapshots reads the maps returned by the Get*Map methods while writers add results;
+// run it with -race to check that the maps are not shared with the manager.
+func TestComparisonManagerMapSnapshots(t *testing.T) {
+	cm := NewComparisonManager()
+	cm.AddSimilarityResult(*similarity("word", "ward", Levenshtein))
+	snapshot := cm.GetSimilarityResultsMap()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				other := fmt.Sprintf("word%d-%d", w, i)
+				cm.AddSimilarityResult(*similarity("word", other, Levenshtein))
+				cm.AddComparisonResult(levenshteinDistance("word", other))
+				cm.AddShingleResult(shingle(other, i%5+1))
+				cm.AddLCSResult(*lcsBacktrack("word", other))
+				cm.AddSimilarityMatrix(*similarityMatrix([]string{"word", other}, Algorithm(i%3)))
+				cm.AddEditScriptResult(*editOperations("word", other))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				count := cm.GetComparisonResultsMap().EntryCount() + cm.GetSimilarityResultsMap().EntryCount() +
+					cm.GetShingleResultsMap().EntryCount() + cm.GetLCSResultsMap().EntryCount() +
+					len(cm.GetSimilarityMatrixMap()) + len(cm.GetEditScriptResultsMap())
+				if count < 1 {
+					t.Errorf("Get*Map read %d results; want at least the first one", count)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if count := snapshot.EntryCount(); count != 1 {
+		t.Errorf("GetSimilarityResultsMap snapshot holds %d results after adds; want 1", count)
+	}
+}
+
+func TestComparisonManagerResultStores(t *testing.T) {
+	cm := NewComparisonManager()
+	cm.AddSimilarityResult(*similarity("apple", "apply", Jaro))
+	got := cm.GetSimilarityResult(Jaro, "apply")
+	if got == nil || got.GetAlgorithm() != Jaro || got.GetString1() != "apple" || !got.IsMatch(
+		similarity("apple", "apply", Jaro)) {
+		t.Fatalf("GetSimilarityResult(Jaro, apply) = %v; want the stored Jaro result", got)
+	}
+	if r, ok := cm.similarities.Get(similarityKey{Jaro, "apply"}); !ok || r.GetInputs()[1] != "apply" {
+		t.Errorf("similarities store holds %v, %v; want the Jaro Result", r, ok)
+	}
+
+	score := float32(2)
+	cm.AddComparisonResult(levenshteinDistance("apple", "apply"))
+	cm.AddComparisonResult(NewComparisonResultFloat(LevDist, "apple", "apply", nil, &score, nil))
+	if n := cm.GetComparisonResultsMap().EntryCount(); n != 1 || cm.comparisons.ints.Len() != 0 {
+		t.Errorf("replacing an int result with a float one left %d results and %d ints; want 1 and 0",
+			n, cm.comparisons.ints.Len())
+	}
+	if r, ok := cm.GetComparisonResult(LevDist, "apply").(*ComparisonResultFloat); !ok || *r.score != score {
+		t.Errorf("GetComparisonResult(LevDist, apply) = %v; want the float result", r)
+	}
+
+	cm.AddShingleResult(shingle("apple", 2))
+	cm.AddShingleResult(NewShingleSliceResult(ShinglesMap, "apple", 2, &[]string{"ap"}, nil))
+	if _, ok := cm.GetShingleResult(ShinglesMap, 2).(*ShingleSliceResult); !ok ||
+		cm.GetShingleResultsMap().EntryCount() != 1 {
+		t.Errorf("GetShingleResult(ShinglesMap, 2) = %v; want only the replacing slice result",
+			cm.GetShingleResult(ShinglesMap, 2))
+	}
+}
+
+func TestComparisonManagerDeprecatedFields(t *testing.T) {
+	var cm ComparisonManager
+	cm.AddComparisonResult(levenshteinDistance("apple", "apply"))
+	cm.AddSimilarityResult(*similarity("apple", "apply", Jaro))
+	cm.AddShingleResult(shingle("apple", 2))
+	cm.AddLCSResult(*lcsBacktrack("apple", "apply"))
+	if cm.ComparisonResults.Get(LevDist, "apply") == nil || cm.SimilarityResults.Get(Jaro, "apply") == nil ||
+		cm.ShingleResults.Get(ShinglesMap, 2) == nil || cm.LCSResults.Get(LCSBacktrackWord, "apply") == nil {
+		t.Errorf("Add* on a zero ComparisonManager did not fill the deprecated fields: %+v", cm.SimilarityResults)
+	}
+
+	score := float32(2)
+	cm.AddComparisonResult(NewComparisonResultFloat(LevDist, "apple", "apply", nil, &score, nil))
+	if _, ok := (*cm.ComparisonResults[LevDist]["apply"]).(*ComparisonResultFloat); !ok ||
+		cm.ComparisonResults.EntryCount() != 1 {
+		t.Errorf("ComparisonResults = %v; want only the replacing float result", cm.ComparisonResults)
+	}
+	snapshot := cm.GetSimilarityResultsMap()
+	delete(snapshot, Jaro)
+	if cm.GetSimilarityResult(Jaro, "apply") == nil || cm.SimilarityResults.Get(Jaro, "apply") == nil {
+		t.Error("changing the GetSimilarityResultsMap copy removed the stored result")
+	}
+}
@@ -35,6 +35,23 @@ func (c ComparisonResultType) String() string {
 // QGramDist represents the comparison result type using Q-Gram distance with default q-gram size.
 // QGramDistCust represents the comparison result type using Q-Gram distance with custom q-gram size.
 // QGramSim represents the comparison result type using Q-Gram score.
+// SoundexMatch represents the comparison result type using matching Soundex codes.
+// RefinedSoundexMatch represents the comparison result type using matching Refined Soundex codes.
+// MetaphoneMatch represents the comparison result type using matching Metaphone codes.
+// DoubleMetaphoneMatch represents the comparison result type using matching Double Metaphone codes.
+// NYSIISMatch represents the comparison result type using matching NYSIIS codes.
+// CaverphoneIIMatch represents the comparison result type using matching Caverphone II codes.
+// ColognePhoneticMatch represents the comparison result type using matching Cologne Phonetic codes.
+// RatioSim represents the comparison result type using the token Ratio.
+// PartialRatioSim represents the comparison result type using the token Partial Ratio.
+// TokenSortRatioSim represents the comparison result type using the Token Sort Ratio.
+// TokenSetRatioSim represents the comparison result type using the Token Set Ratio.
+// WeightedRatioSim represents the comparison result type using the Weighted Ratio.
+// CustomLevDist represents the comparison result type using Levenshtein distance with custom options.
+// CustomDamLevDist represents the comparison result type using Damerau-Levenshtein distance with custom options.
+// CustomOSADamLevDist represents the comparison result type using Optimal String Alignment distance with
+// custom options.
+// TFIDFCosineSim represents the comparison result type using the cosine similarity of TF-IDF vectors of a Corpus.
 const (
 	LCSLength ComparisonResultType = iota
 	LCSDist
@@ -50,6 +67,22 @@ const (
 	QGramDist
 	QGramDistCust
 	QGramSim
+	SoundexMatch
+	RefinedSoundexMatch
+	MetaphoneMatch
+	DoubleMetaphoneMatch
+	NYSIISMatch
+	CaverphoneIIMatch
+	ColognePhoneticMatch
+	RatioSim
+	PartialRatioSim
+	TokenSortRatioSim
+	TokenSetRatioSim
+	WeightedRatioSim
+	CustomLevDist
+	CustomDamLevDist
+	CustomOSADamLevDist
+	TFIDFCosineSim
 )
 
 var ComparisonResultTypeMap = map[ComparisonResultType]string{
@@ -67,6 +100,23 @@ var ComparisonResultTypeMap = map[ComparisonResultType]string{
 	QGramDist:      "Q-Gram Distance",
 	QGramDistCust:  "Q-Gram Distance Custom",
 	QGramSim:       "Q-Gram Similarity",
+
+	SoundexMatch:         "Soundex Match",
+	RefinedSoundexMatch:  "Refined Soundex Match",
+	MetaphoneMatch:       "Metaphone Match",
+	DoubleMetaphoneMatch: "Double Metaphone Match",
+	NYSIISMatch:          "NYSIIS Match",
+	CaverphoneIIMatch:    "Caverphone II Match",
+	ColognePhoneticMatch: "Cologne Phonetic Match",
+	RatioSim:             "Ratio",
+	PartialRatioSim:      "Partial Ratio",
+	TokenSortRatioSim:    "Token Sort Ratio",
+	TokenSetRatioSim:     "Token Set Ratio",
+	WeightedRatioSim:     "Weighted Ratio",
+	CustomLevDist:        "Custom Levenshtein Distance",
+	CustomDamLevDist:     "Custom Damerau-Levenshtein Distance",
+	CustomOSADamLevDist:  "Custom OSA Damerau-Levenshtein Distance",
+	TFIDFCosineSim:       "TF-IDF Cosine Similarity",
 }
 
 // ComparisonResult defines an interface for comparing two strings and retrieving results, types, and errors.
@@ -330,13 +380,16 @@ func CastComparisonResult(raw *ComparisonResult) ComparisonResult {
 		return nil
 	}
 	switch (*raw).GetType() {
-	case LevDist, DamLevDist, OSADamLevDist, LCSLength, LCSDist, HammingDist, QGramDist, QGramDistCust:
+	case LevDist, DamLevDist, OSADamLevDist, LCSLength, LCSDist, HammingDist, QGramDist, QGramDistCust,
+		SoundexMatch, RefinedSoundexMatch, MetaphoneMatch, DoubleMetaphoneMatch, NYSIISMatch, CaverphoneIIMatch,
+		ColognePhoneticMatch, CustomLevDist, CustomDamLevDist, CustomOSADamLevDist:
 		casted, ok := (*raw).(*ComparisonResultInt)
 		if !ok {
 			return nil
 		}
 		return casted
-	case JaroSim, JaroWinklerSim, JaccardSim, CosineSim, SorensenDiceCo, QGramSim:
+	case JaroSim, JaroWinklerSim, JaccardSim, CosineSim, SorensenDiceCo, QGramSim,
+		RatioSim, PartialRatioSim, TokenSortRatioSim, TokenSetRatioSim, WeightedRatioSim, TFIDFCosineSim:
 		casted, ok := (*raw).(*ComparisonResultFloat)
 		if !ok {
 			return nil
@@ -14,27 +14,17 @@ func NewComparisonResultsMap() ComparisonResultsMap {
 // Add inserts a ComparisonResult into the map, organizing it by its type and
 // second string, creating sub-maps as needed.
 func (crm ComparisonResultsMap) Add(result ComparisonResult) {
-	if crm[result.GetType()] == nil {
-		crm[result.GetType()] = make(map[string]*ComparisonResult)
-	}
-	crm[result.GetType()][result.GetString2()] = &result
+	crm.index().add(result.GetType(), result.GetString2(), result)
+}
+
+// index returns the map as the resultIndex its methods delegate to.
+func (crm ComparisonResultsMap) index() resultIndex[ComparisonResultType, string, ComparisonResult] {
+	return resultIndex[ComparisonResultType, string, ComparisonResult](crm)
 }
 
 // GetCopy creates and returns a deep copy of the current ComparisonResultsMap.
 func (crm ComparisonResultsMap) GetCopy() ComparisonResultsMap {
-	cloned := NewComparisonResultsMap()
-	for compType, v := range crm {
-		cloned[compType] = make(map[string]*ComparisonResult)
-		for compStr, v2 := range v {
-			if v2 != nil {
-				// Dereference the pointer to create a copy of the value.
-				resultCopy := *v2
-				// Store the address of the new copy in the cloned map.
-				cloned[compType][compStr] = &resultCopy
-			}
-		}
-	}
-	return cloned
+	return ComparisonResultsMap(crm.index().clone())
 }
 
 // Get retrieves a ComparisonResult from the map using the specified ComparisonResultType and comparison string.
@@ -54,67 +44,31 @@ func (crm ComparisonResultsMap) Get(compResType ComparisonResultType, compStr st
 // GetByType retrieves a slice of ComparisonResult for the specified ComparisonResultType or
 // nil if no results are found.
 func (crm ComparisonResultsMap) GetByType(compResType ComparisonResultType) []ComparisonResult {
-	if crm[compResType] == nil {
-		return nil
-	}
-	var results []ComparisonResult
-	for _, v := range crm[compResType] {
-		results = append(results, CastComparisonResult(v))
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(crm.index().values(func(t ComparisonResultType, _ string) bool {
+		return t == compResType
+	}), CastComparisonResult)
 }
 
 // FilterByType filters the ComparisonResultsMap by the specified ComparisonResultType
 // and returns a new map with the results.
 func (crm ComparisonResultsMap) FilterByType(compResType ComparisonResultType) ComparisonResultsMap {
-	if crm[compResType] == nil {
-		return nil
-	}
-	results := NewComparisonResultsMap()
-	for _, v := range crm[compResType] {
-		results.Add(*v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return ComparisonResultsMap(crm.index().filter(func(t ComparisonResultType, _ string) bool {
+		return t == compResType
+	}))
 }
 
 // GetByComparisonString retrieves a list of ComparisonResult objects associated with the provided comparison string.
 func (crm ComparisonResultsMap) GetByComparisonString(compStr string) []ComparisonResult {
-	if len(crm) == 0 {
-		return nil
-	}
-	var results []ComparisonResult
-	for _, v := range crm {
-		if v[compStr] != nil {
-			results = append(results, CastComparisonResult(v[compStr]))
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(crm.index().values(func(_ ComparisonResultType, s string) bool {
+		return s == compStr
+	}), CastComparisonResult)
 }
 
 // FilterByComparisonString filters the map, returning a new map with results matching the given comparison string key.
 func (crm ComparisonResultsMap) FilterByComparisonString(compStr string) ComparisonResultsMap {
-	if len(crm) == 0 {
-		return nil
-	}
-	results := NewComparisonResultsMap()
-	for _, v := range crm {
-		if v[compStr] != nil {
-			results.Add(*v[compStr])
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return ComparisonResultsMap(crm.index().filter(func(_ ComparisonResultType, s string) bool {
+		return s == compStr
+	}))
 }
 
 // TypeCount returns the number of distinct ComparisonResultType keys in the ComparisonResultsMap.
@@ -124,42 +78,14 @@ func (crm ComparisonResultsMap) TypeCount() int {
 
 // EntryCount returns the total number of non-nil ComparisonResult entries stored in the nested maps of the structure.
 func (crm ComparisonResultsMap) EntryCount() int {
-	mapLength := 0
-	for _, v := range crm {
-		for _, v2 := range v {
-			if v2 != nil {
-				mapLength++
-			}
-		}
-	}
-	return mapLength
+	return crm.index().entryCount()
 }
 
 // IsMatch compares the current ComparisonResultsMap with another map for structural and value equality.
 func (crm ComparisonResultsMap) IsMatch(other ComparisonResultsMap) bool {
-	// return quickly if counts don't match
-	if crm.TypeCount() != other.TypeCount() || crm.EntryCount() != other.EntryCount() {
-		return false
-	}
-	//iterate through the type maps
-	for compType, v := range crm {
-		//if the type map doesn't exist, we return
-		if other[compType] == nil {
-			return false
-		}
-		for compStr, v2 := range v {
-			// if we don't have a match on the comp string, return
-			if other[compType][compStr] == nil {
-				return false
-			} else {
-				// cast the result to the appropriate type, then run the match
-				if !CastComparisonResult(v2).IsMatch(*other[compType][compStr]) {
-					return false
-				}
-			}
-		}
-	}
-	return true
+	return crm.index().isMatch(other.index(), func(a, b *ComparisonResult) bool {
+		return CastComparisonResult(a).IsMatch(*b)
+	})
 }
 
 // Print iterates through the ComparisonResultsMap and prints the comparison results, optionally in verbose mode.
@@ -1574,3 +1574,47 @@ func TestSimilarity(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareAll(t *testing.T) {
+	others := []string{"kitten", "sitting", "mitten", "kitchen", "kitten"}
+	algos := []Algorithm{Levenshtein, JaroWinkler, Hamming, FuzzyTokenSortRatio}
+	cm, err := CompareAll("kitten", others, algos...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := New("kitten").CompareAll(others, algos...)
+	if builder.Error() != nil {
+		t.Fatalf("CompareAll builder error = %v; want errors kept in the results", builder.Error())
+	}
+	if count := cm.GetSimilarityResultsMap().EntryCount(); count != 16 {
+		t.Errorf("CompareAll stored %d results; want 16 unique pairs", count)
+	}
+	for _, other := range others {
+		for _, algo := range algos {
+			expected := similarity("kitten", other, algo)
+			if !expected.IsMatch(cm.GetSimilarityResult(algo, other)) {
+				t.Errorf("CompareAll %s %s = %v; want %v", algo, other, cm.GetSimilarityResult(algo, other), expected)
+			}
+			if !expected.IsMatch(builder.GetComparisonManager().GetSimilarityResult(algo, other)) {
+				t.Errorf("CompareAll builder %s %s does not match Similarity", algo, other)
+			}
+		}
+	}
+	if r := cm.GetSimilarityResult(Hamming, "kitchen"); r == nil || r.GetError() == nil {
+		t.Errorf("CompareAll Hamming on unequal lengths = %v; want an errored result", r)
+	}
+
+	all, err := CompareAll("a", []string{"b"})
+	if err != nil || all.GetSimilarityResultsMap().EntryCount() != len(AlgorithmTypeMap) {
+		t.Errorf("CompareAll without algorithms = %v; want one result per algorithm", err)
+	}
+	if _, err := CompareAll("a", []string{"b"}, Algorithm(99)); !errors.Is(err, errors2.ErrInvalidAlgorithm) {
+		t.Errorf("CompareAll(99) error = %v; want %v", err, errors2.ErrInvalidAlgorithm)
+	}
+	invalid := New("a").CompareAll([]string{"b"}, Levenshtein, Algorithm(99))
+	if !errors.Is(invalid.Error(), errors2.ErrInvalidAlgorithm) || invalid.GetComparisonManager().
+		GetSimilarityResult(Levenshtein, "b") != nil {
+		t.Errorf("CompareAll builder with an invalid algorithm error = %v; want %v and no results",
+			invalid.Error(), errors2.ErrInvalidAlgorithm)
+	}
+}
@@ -0,0 +1,332 @@
+package strutil
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// CorpusTerms selects the terms a Corpus weights.
+type CorpusTerms int
+
+// String returns the string representation of the CorpusTerms using CorpusTermsMap.
+func (c CorpusTerms) String() string {
+	return CorpusTermsMap[c]
+}
+
+// CorpusWords weights word n-grams, i.e. NgramLength consecutive words joined by a space.
+// CorpusCharNgrams weights character n-grams, i.e. the shingles of the processed text.
+const (
+	CorpusWords CorpusTerms = iota
+	CorpusCharNgrams
+)
+
+// CorpusTermsMap maps CorpusTerms constants to their corresponding string representations.
+var CorpusTermsMap = map[CorpusTerms]string{
+	CorpusWords:      "Words",
+	CorpusCharNgrams: "Character N-Grams",
+}
+
+// EnglishStopWords is a list of common English words that carry little meaning, for CorpusOptions.StopWords.
+var EnglishStopWords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and", "any", "are", "as", "at",
+	"be", "because", "been", "before", "being", "below", "between", "both", "but", "by", "can", "could",
+	"did", "do", "does", "doing", "down", "during", "each", "few", "for", "from", "further", "had", "has",
+	"have", "having", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how", "i", "if", "in",
+	"into", "is", "it", "its", "itself", "just", "me", "more", "most", "my", "myself", "no", "nor", "not", "now",
+	"of", "off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own", "same",
+	"she", "should", "so", "some", "such", "than", "that", "the", "their", "theirs", "them", "themselves",
+	"then", "there", "these", "they", "this", "those", "through", "to", "too", "under", "until", "up", "very",
+	"was", "we", "were", "what", "when", "where", "which", "while", "who", "whom", "why", "will", "with",
+	"would", "you", "your", "yours", "yourself", "yourselves",
+}
+
+// CorpusOptions configures how a Corpus turns documents into terms and weights them.
+type CorpusOptions struct {
+	// Terms selects whether word n-grams or character n-grams are weighted.
+	Terms CorpusTerms
+	// NgramLength is the number of words per term for CorpusWords or runes per term for CorpusCharNgrams;
+	// must be at least 1. A document with fewer words or runes becomes a single term.
+	NgramLength int
+	// Tokenize prepares documents and splits them into words before stop words and stemming are applied.
+	Tokenize TokenizeOptions
+	// StopWords are dropped after tokenization. They are tokenized with the same options, so they match
+	// regardless of case when Tokenize lower-cases.
+	StopWords []string
+	// Stem, if set, replaces every remaining word with its stem; words stemmed to "" are dropped.
+	Stem func(word string) string
+	// SublinearTF weights a term occurring n times by 1 + ln(n) instead of n.
+	SublinearTF bool
+}
+
+// NewCorpusOptions returns CorpusOptions weighting single words processed with NewTokenizeOptions,
+// without stop words or stemming.
+func NewCorpusOptions() CorpusOptions {
+	return CorpusOptions{
+		Terms:       CorpusWords,
+		NgramLength: 1,
+		Tokenize:    NewTokenizeOptions(),
+	}
+}
+
+// TFIDFVector maps terms to their TF-IDF weights. Vectors returned by a Corpus have unit length.
+type TFIDFVector map[string]float64
+
+// Cosine returns the cosine similarity between v and other, or 0 if either has no weight.
+func (v TFIDFVector) Cosine(other TFIDFVector) float64 {
+	if len(other) < len(v) {
+		v, other = other, v
+	}
+	dot := 0.0
+	for term, w := range v {
+		dot += w * other[term]
+	}
+	norms := v.norm() * other.norm()
+	if norms == 0 {
+		return 0
+	}
+	return dot / norms
+}
+
+// norm returns the Euclidean length of v.
+func (v TFIDFVector) norm() float64 {
+	sum := 0.0
+	for _, w := range v {
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+// CorpusMatch is a document returned by Corpus.TopK with its cosine similarity to the query.
+type CorpusMatch struct {
+	ID    string
+	Score float64
+}
+
+// Corpus is a collection of documents that learns inverse document frequencies over their terms and scores
+// texts by the cosine similarity of their TF-IDF vectors, so terms that are rare in the corpus count most.
+// Weights follow the smoothed formula tf * (ln((1 + N) / (1 + df)) + 1), where N is the number of documents and
+// df the number containing the term, and change as documents are added or removed.
+// A Corpus is safe for concurrent use.
+type Corpus struct {
+	mu        sync.RWMutex
+	opts      CorpusOptions
+	stopWords map[string]bool
+	docs      map[string]map[string]int
+	postings  map[string]map[string]int
+}
+
+// NewCorpus creates an empty Corpus with the given options.
+// Returns ErrInvalidCorpusOptions if the terms are unknown or the n-gram length is less than 1.
+func NewCorpus(opts CorpusOptions) (*Corpus, error) {
+	if _, ok := CorpusTermsMap[opts.Terms]; !ok || opts.NgramLength < 1 {
+		return nil, errors.ErrInvalidCorpusOptions
+	}
+	c := &Corpus{
+		opts:      opts,
+		stopWords: make(map[string]bool),
+		docs:      make(map[string]map[string]int),
+		postings:  make(map[string]map[string]int),
+	}
+	for _, word := range opts.StopWords {
+		for _, token := range tokenize(word, opts.Tokenize) {
+			c.stopWords[token] = true
+		}
+	}
+	return c, nil
+}
+
+// Add adds the document text under id, replacing any document previously added with the same id.
+func (c *Corpus) Add(id, text string) {
+	terms := c.terms(text)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(id)
+	c.docs[id] = terms
+	for term, count := range terms {
+		if c.postings[term] == nil {
+			c.postings[term] = make(map[string]int)
+		}
+		c.postings[term][id] = count
+	}
+}
+
+// Remove deletes the document with the given id and reports whether it was present.
+func (c *Corpus) Remove(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(id)
+}
+
+// Len returns the number of documents in the corpus.
+func (c *Corpus) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.docs)
+}
+
+// Contains reports whether a document with the given id is in the corpus.
+func (c *Corpus) Contains(id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.docs[id]
+	return ok
+}
+
+// Terms returns the terms of text as the corpus extracts them, with their counts.
+func (c *Corpus) Terms(text string) map[string]int {
+	return c.terms(text)
+}
+
+// IDF returns the inverse document frequency of a term as returned by Terms. Terms missing from every
+// document get the highest weight.
+func (c *Corpus) IDF(term string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.idf(term)
+}
+
+// Vector returns the unit-length TF-IDF vector of text weighted by the current corpus.
+// Returns an empty vector if text has no terms.
+func (c *Corpus) Vector(text string) TFIDFVector {
+	terms := c.terms(text)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.vector(terms)
+}
+
+// DocumentVector returns the unit-length TF-IDF vector of the document with the given id.
+// Reports false if there is no such document.
+func (c *Corpus) DocumentVector(id string) (TFIDFVector, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	terms, ok := c.docs[id]
+	if !ok {
+		return nil, false
+	}
+	return c.vector(terms), true
+}
+
+// Similarity computes the cosine similarity between the TF-IDF vectors of s1 and s2 and returns it as a
+// ComparisonResultFloat. Texts without terms score 0.
+func (c *Corpus) Similarity(s1, s2 string) *ComparisonResultFloat {
+	score := float32(c.Vector(s1).Cosine(c.Vector(s2)))
+	return NewComparisonResultFloat(TFIDFCosineSim, s1, s2, nil, &score, nil)
+}
+
+// TopK returns up to k documents sharing at least one term with the query, ordered by descending cosine
+// similarity of their TF-IDF vectors and then by id. Returns nil if k is less than 1 or nothing matches.
+func (c *Corpus) TopK(query string, k int) []CorpusMatch {
+	if k < 1 {
+		return nil
+	}
+	terms := c.terms(query)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	q := c.vector(terms)
+	dots := make(map[string]float64)
+	for term, w := range q {
+		idf := c.idf(term)
+		for id, count := range c.postings[term] {
+			dots[id] += w * c.tf(count) * idf
+		}
+	}
+	matches := make([]CorpusMatch, 0, len(dots))
+	for id, dot := range dots {
+		if norm := c.weights(c.docs[id]).norm(); norm > 0 {
+			matches = append(matches, CorpusMatch{ID: id, Score: dot / norm})
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		return matches[a].ID < matches[b].ID
+	})
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches
+}
+
+// terms tokenizes text, drops stop words, stems the remaining words and counts the configured n-grams.
+func (c *Corpus) terms(text string) map[string]int {
+	var words []string
+	for _, word := range tokenize(text, c.opts.Tokenize) {
+		if c.stopWords[word] {
+			continue
+		}
+		if c.opts.Stem != nil {
+			word = c.opts.Stem(word)
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	if c.opts.Terms == CorpusCharNgrams {
+		return signatureShingles(strings.Join(words, " "), c.opts.NgramLength)
+	}
+	counts := make(map[string]int)
+	if len(words) > 0 && len(words) < c.opts.NgramLength {
+		counts[strings.Join(words, " ")] = 1
+	}
+	for i := 0; i+c.opts.NgramLength <= len(words); i++ {
+		counts[strings.Join(words[i:i+c.opts.NgramLength], " ")]++
+	}
+	return counts
+}
+
+// remove deletes the document with the given id from the postings. Callers must hold the write lock.
+func (c *Corpus) remove(id string) bool {
+	terms, ok := c.docs[id]
+	if !ok {
+		return false
+	}
+	for term := range terms {
+		delete(c.postings[term], id)
+		if len(c.postings[term]) == 0 {
+			delete(c.postings, term)
+		}
+	}
+	delete(c.docs, id)
+	return true
+}
+
+// idf returns the smoothed inverse document frequency of term. Callers must hold the read lock.
+func (c *Corpus) idf(term string) float64 {
+	return math.Log(float64(1+len(c.docs))/float64(1+len(c.postings[term]))) + 1
+}
+
+// tf returns the weight of a term occurring count times.
+func (c *Corpus) tf(count int) float64 {
+	if c.opts.SublinearTF {
+		return 1 + math.Log(float64(count))
+	}
+	return float64(count)
+}
+
+// weights returns the TF-IDF weights of terms. Callers must hold the read lock.
+func (c *Corpus) weights(terms map[string]int) TFIDFVector {
+	v := make(TFIDFVector, len(terms))
+	for term, count := range terms {
+		v[term] = c.tf(count) * c.idf(term)
+	}
+	return v
+}
+
+// vector returns the TF-IDF weights of terms scaled to unit length. Callers must hold the read lock.
+func (c *Corpus) vector(terms map[string]int) TFIDFVector {
+	v := c.weights(terms)
+	if norm := v.norm(); norm > 0 {
+		for term := range v {
+			v[term] /= norm
+		}
+	}
+	return v
+}
@@ -0,0 +1,167 @@
+package strutil
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// corpusDocuments is a small corpus where "golang" is rare and "the" appears everywhere.
+var corpusDocuments = map[string]string{
+	"go":     "The Go gopher writes golang code.",
+	"python": "The python snake writes python code.",
+	"cats":   "The cat sleeps on the mat.",
+	"dogs":   "The dog sleeps on the porch.",
+}
+
+// newTestCorpus builds a Corpus over corpusDocuments.
+func newTestCorpus(t *testing.T, opts CorpusOptions) *Corpus {
+	t.Helper()
+	c, err := NewCorpus(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, doc := range corpusDocuments {
+		c.Add(id, doc)
+	}
+	return c
+}
+
+func TestNewCorpusInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CorpusOptions
+	}{
+		{"InvalidNgram", CorpusOptions{Terms: CorpusWords}},
+		{"InvalidTerms", CorpusOptions{Terms: CorpusTerms(9), NgramLength: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCorpus(tt.opts); !errors.Is(err, errors2.ErrInvalidCorpusOptions) {
+				t.Errorf("NewCorpus(%+v) error = %v; want %v", tt.opts, err, errors2.ErrInvalidCorpusOptions)
+			}
+		})
+	}
+}
+
+func TestCorpusTerms(t *testing.T) {
+	stem := func(word string) string { return strings.TrimSuffix(word, "s") }
+	tests := []struct {
+		name     string
+		opts     CorpusOptions
+		text     string
+		expected map[string]int
+	}{
+		{"TermsWords", NewCorpusOptions(), "The cat, the hat!", map[string]int{"the": 2, "cat": 1, "hat": 1}},
+		{"TermsStopWords", CorpusOptions{Terms: CorpusWords, NgramLength: 1, Tokenize: NewTokenizeOptions(),
+			StopWords: []string{"THE"}}, "The cat, the hat!", map[string]int{"cat": 1, "hat": 1}},
+		{"TermsStem", CorpusOptions{Terms: CorpusWords, NgramLength: 1, Tokenize: NewTokenizeOptions(),
+			StopWords: EnglishStopWords, Stem: stem}, "the cats and cat", map[string]int{"cat": 2}},
+		{"TermsBigrams", CorpusOptions{Terms: CorpusWords, NgramLength: 2, Tokenize: NewTokenizeOptions()},
+			"new york new york", map[string]int{"new york": 2, "york new": 1}},
+		{"TermsShortBigram", CorpusOptions{Terms: CorpusWords, NgramLength: 2, Tokenize: NewTokenizeOptions()},
+			"york", map[string]int{"york": 1}},
+		{"TermsCharNgrams", CorpusOptions{Terms: CorpusCharNgrams, NgramLength: 3, Tokenize: NewTokenizeOptions()},
+			"Abab!", map[string]int{"aba": 1, "bab": 1}},
+		{"TermsEmpty", NewCorpusOptions(), " !? ", map[string]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCorpus(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if terms := c.Terms(tt.text); !reflect.DeepEqual(terms, tt.expected) {
+				t.Errorf("Terms(%q) = %v; want %v", tt.text, terms, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCorpusWeights(t *testing.T) {
+	c := newTestCorpus(t, NewCorpusOptions())
+	if c.Len() != 4 || !c.Contains("go") || c.Contains("rust") {
+		t.Fatalf("Len() = %d; want 4 documents including go", c.Len())
+	}
+	if idf := c.IDF("golang"); math.Abs(idf-(math.Log(5.0/2)+1)) > 1e-12 {
+		t.Errorf("IDF(golang) = %f; want %f", idf, math.Log(5.0/2)+1)
+	}
+	if idf := c.IDF("the"); idf != 1 {
+		t.Errorf("IDF(the) = %f; want 1", idf)
+	}
+	v, ok := c.DocumentVector("go")
+	if !ok || math.Abs(v.norm()-1) > 1e-12 || v["golang"] <= v["the"] {
+		t.Errorf("DocumentVector(go) = %v; want a unit vector weighting golang above the", v)
+	}
+	if _, ok := c.DocumentVector("rust"); ok {
+		t.Error("DocumentVector(rust) reported a missing document")
+	}
+	if v := c.Vector("!!"); len(v) != 0 || v.Cosine(c.Vector("golang")) != 0 {
+		t.Errorf("Vector(!!) = %v; want an empty vector with cosine 0", v)
+	}
+}
+
+func TestCorpusSimilarity(t *testing.T) {
+	c := newTestCorpus(t, NewCorpusOptions())
+	if score, err := c.Similarity("golang code", "golang code").GetScoreFloat(); err != nil || math.Abs(
+		float64(score)-1) > 1e-6 {
+		t.Errorf("Similarity of identical texts = %f, %v; want 1", score, err)
+	}
+	rare, err := c.Similarity("the golang", "golang the").GetScoreFloat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common, err := c.Similarity("the golang", "the python").GetScoreFloat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rare <= common {
+		t.Errorf("sharing golang scored %f, sharing the scored %f; want the rare term to weigh more", rare, common)
+	}
+
+	builder := New("the golang").TFIDFSimilarity("golang the", c)
+	result := builder.GetComparisonManager().GetComparisonResult(TFIDFCosineSim, "golang the")
+	if !c.Similarity("the golang", "golang the").IsMatch(result) {
+		t.Errorf("TFIDFSimilarity builder result = %v; want the Corpus.Similarity result", result)
+	}
+	if err := New("x").TFIDFSimilarity("y", nil).Error(); !errors.Is(err, errors2.ErrNilCorpus) {
+		t.Errorf("TFIDFSimilarity(nil) error = %v; want %v", err, errors2.ErrNilCorpus)
+	}
+}
+
+func TestCorpusTopK(t *testing.T) {
+	c := newTestCorpus(t, CorpusOptions{Terms: CorpusWords, NgramLength: 1, Tokenize: NewTokenizeOptions(),
+		StopWords: EnglishStopWords})
+	matches := c.TopK("who sleeps on the mat", 3)
+	if len(matches) != 2 || matches[0].ID != "cats" || matches[1].ID != "dogs" {
+		t.Fatalf("TopK(sleeps on the mat) = %v; want cats then dogs", matches)
+	}
+	expected, err := c.Similarity("who sleeps on the mat", corpusDocuments["cats"]).GetScoreFloat()
+	if err != nil || math.Abs(matches[0].Score-float64(expected)) > 1e-6 {
+		t.Errorf("TopK score = %f; want the Similarity score %f", matches[0].Score, expected)
+	}
+	if matches := c.TopK("sleeps", 1); len(matches) != 1 || matches[0].ID != "cats" {
+		t.Errorf("TopK(sleeps, 1) = %v; want cats on the id tie-break", matches)
+	}
+	if matches := c.TopK("the", 3); matches != nil {
+		t.Errorf("TopK(the) = %v; want nil for a query of stop words", matches)
+	}
+	if matches := c.TopK("mat", 0); matches != nil {
+		t.Errorf("TopK(mat, 0) = %v; want nil", matches)
+	}
+
+	c.Add("cats", "The python sleeps.")
+	if matches := c.TopK("mat", 3); matches != nil {
+		t.Errorf("TopK(mat) after replacing cats = %v; want nil", matches)
+	}
+	if !c.Remove("python") || c.Remove("python") || c.Len() != 3 {
+		t.Errorf("Remove(python) did not remove exactly one document")
+	}
+	if matches := c.TopK("python", 3); len(matches) != 1 || matches[0].ID != "cats" {
+		t.Errorf("TopK(python) after Remove = %v; want cats", matches)
+	}
+}
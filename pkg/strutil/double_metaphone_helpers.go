@@ -0,0 +1,569 @@
+package strutil
+
+import "strings"
+
+// doubleMetaphoneMaxLength is the length of the primary and alternate Double Metaphone codes.
+const doubleMetaphoneMaxLength = 4
+
+// doubleMetaphoneState holds the upper-cased input and the codes being built while encoding a string
+// with Double Metaphone.
+type doubleMetaphoneState struct {
+	value         []rune
+	primary       strings.Builder
+	alternate     strings.Builder
+	slavoGermanic bool
+}
+
+// doubleMetaphoneHandler encodes the letter at index i and returns the index of the next letter to encode.
+type doubleMetaphoneHandler func(d *doubleMetaphoneState, i int) int
+
+// doubleMetaphoneHandlers holds the Double Metaphone rules keyed by letter; other runes are skipped.
+var doubleMetaphoneHandlers = map[rune]doubleMetaphoneHandler{
+	'A': (*doubleMetaphoneState).vowel, 'E': (*doubleMetaphoneState).vowel, 'I': (*doubleMetaphoneState).vowel,
+	'O': (*doubleMetaphoneState).vowel, 'U': (*doubleMetaphoneState).vowel, 'Y': (*doubleMetaphoneState).vowel,
+	'B': doubleMetaphoneLetter("P", 'B'),
+	'Ç': doubleMetaphoneLetter("S", 0),
+	'C': (*doubleMetaphoneState).c,
+	'D': (*doubleMetaphoneState).d,
+	'F': doubleMetaphoneLetter("F", 'F'),
+	'G': (*doubleMetaphoneState).g,
+	'H': (*doubleMetaphoneState).h,
+	'J': (*doubleMetaphoneState).j,
+	'K': doubleMetaphoneLetter("K", 'K'),
+	'L': (*doubleMetaphoneState).l,
+	'M': (*doubleMetaphoneState).m,
+	'N': doubleMetaphoneLetter("N", 'N'),
+	'Ñ': doubleMetaphoneLetter("N", 0),
+	'P': (*doubleMetaphoneState).p,
+	'Q': doubleMetaphoneLetter("K", 'Q'),
+	'R': (*doubleMetaphoneState).r,
+	'S': (*doubleMetaphoneState).s,
+	'T': (*doubleMetaphoneState).t,
+	'V': doubleMetaphoneLetter("F", 'V'),
+	'W': (*doubleMetaphoneState).w,
+	'X': (*doubleMetaphoneState).x,
+	'Z': (*doubleMetaphoneState).z,
+}
+
+// doubleMetaphone encodes s with Lawrence Philips' Double Metaphone and returns the primary and alternate codes.
+func doubleMetaphone(s string) (string, string) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if value == "" {
+		return "", ""
+	}
+	d := &doubleMetaphoneState{value: []rune(value)}
+	d.slavoGermanic = strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") ||
+		strings.Contains(value, "WITZ")
+	i := 0
+	if d.contains(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		i = 1
+	}
+	for i < len(d.value) &&
+		(d.primary.Len() < doubleMetaphoneMaxLength || d.alternate.Len() < doubleMetaphoneMaxLength) {
+		if handler, ok := doubleMetaphoneHandlers[d.value[i]]; ok {
+			i = handler(d, i)
+		} else {
+			i++
+		}
+	}
+	primary, alternate := d.primary.String(), d.alternate.String()
+	return primary[:min(len(primary), doubleMetaphoneMaxLength)],
+		alternate[:min(len(alternate), doubleMetaphoneMaxLength)]
+}
+
+// doubleMetaphoneLetter returns a handler that appends code and skips a following double letter.
+func doubleMetaphoneLetter(code string, double rune) doubleMetaphoneHandler {
+	return func(d *doubleMetaphoneState, i int) int {
+		d.add(code)
+		if double != 0 && d.at(i+1) == double {
+			return i + 2
+		}
+		return i + 1
+	}
+}
+
+// at returns the rune at index i, or 0 if i is out of range.
+func (d *doubleMetaphoneState) at(i int) rune {
+	if i < 0 || i >= len(d.value) {
+		return 0
+	}
+	return d.value[i]
+}
+
+// contains reports whether the length runes starting at start equal any of the criteria.
+func (d *doubleMetaphoneState) contains(start, length int, criteria ...string) bool {
+	if start < 0 || start+length > len(d.value) {
+		return false
+	}
+	target := string(d.value[start : start+length])
+	for _, c := range criteria {
+		if target == c {
+			return true
+		}
+	}
+	return false
+}
+
+// isVowel reports whether the rune at index i is a Double Metaphone vowel (A, E, I, O, U or Y).
+func (d *doubleMetaphoneState) isVowel(i int) bool {
+	return strings.ContainsRune("AEIOUY", d.at(i))
+}
+
+// add appends code to both the primary and the alternate code.
+func (d *doubleMetaphoneState) add(code string) {
+	d.primary.WriteString(code)
+	d.alternate.WriteString(code)
+}
+
+// addPair appends primary to the primary code and alternate to the alternate code.
+func (d *doubleMetaphoneState) addPair(primary, alternate string) {
+	d.primary.WriteString(primary)
+	d.alternate.WriteString(alternate)
+}
+
+// vowel encodes an initial vowel as A; other vowels are skipped.
+func (d *doubleMetaphoneState) vowel(i int) int {
+	if i == 0 {
+		d.add("A")
+	}
+	return i + 1
+}
+
+// c encodes C, including the Germanic "ach", "caesar", "ch", "cz", "cia", "cc" and soft C before a front vowel.
+func (d *doubleMetaphoneState) c(i int) int {
+	switch {
+	case d.germanicC(i):
+		d.add("K")
+		return i + 2
+	case i == 0 && d.contains(i, 6, "CAESAR"):
+		d.add("S")
+		return i + 2
+	case d.contains(i, 2, "CH"):
+		return d.ch(i)
+	case d.contains(i, 2, "CZ") && !d.contains(i-2, 4, "WICZ"):
+		d.addPair("S", "X")
+		return i + 2
+	case d.contains(i+1, 3, "CIA"):
+		d.add("X")
+		return i + 3
+	case d.contains(i, 2, "CC") && !(i == 1 && d.at(0) == 'M'):
+		return d.cc(i)
+	case d.contains(i, 2, "CK", "CG", "CQ"):
+		d.add("K")
+		return i + 2
+	case d.contains(i, 2, "CI", "CE", "CY"):
+		if d.contains(i, 3, "CIO", "CIE", "CIA") {
+			d.addPair("S", "X")
+		} else {
+			d.add("S")
+		}
+		return i + 2
+	}
+	return d.hardC(i)
+}
+
+// hardC encodes C as K, skipping a following C, K or Q and the space in "Mac Caffrey" and "Mac Gregor".
+func (d *doubleMetaphoneState) hardC(i int) int {
+	d.add("K")
+	switch {
+	case d.contains(i+1, 2, " C", " Q", " G"):
+		return i + 3
+	case d.contains(i+1, 1, "C", "K", "Q") && !d.contains(i+1, 2, "CE", "CI"):
+		return i + 2
+	}
+	return i + 1
+}
+
+// germanicC reports whether the C at index i is a hard Germanic "ach", as in "Bacher" but not "Macher".
+func (d *doubleMetaphoneState) germanicC(i int) bool {
+	if d.contains(i, 4, "CHIA") {
+		return true
+	}
+	if i <= 1 || d.isVowel(i-2) || !d.contains(i-1, 3, "ACH") {
+		return false
+	}
+	next := d.at(i + 2)
+	return (next != 'I' && next != 'E') || d.contains(i-2, 6, "BACHER", "MACHER")
+}
+
+// cc encodes a double C as KS in "accident" and "succeed", X in "bacci" and K otherwise.
+func (d *doubleMetaphoneState) cc(i int) int {
+	if d.contains(i+2, 1, "I", "E", "H") && !d.contains(i+2, 2, "HU") {
+		if (i == 1 && d.at(i-1) == 'A') || d.contains(i-1, 5, "UCCEE", "UCCES") {
+			d.add("KS")
+		} else {
+			d.add("X")
+		}
+		return i + 3
+	}
+	d.add("K")
+	return i + 2
+}
+
+// ch encodes "ch" as K in Greek and Germanic words such as "character" and "orchestra", and X otherwise.
+func (d *doubleMetaphoneState) ch(i int) int {
+	switch {
+	case i > 0 && d.contains(i, 4, "CHAE"):
+		d.addPair("K", "X")
+	case d.greekCH(i), d.germanicCH(i):
+		d.add("K")
+	case i > 0 && d.contains(0, 2, "MC"):
+		d.add("K")
+	case i > 0:
+		d.addPair("X", "K")
+	default:
+		d.add("X")
+	}
+	return i + 2
+}
+
+// greekCH reports whether an initial "ch" starts a Greek root such as "character" or "chorus".
+func (d *doubleMetaphoneState) greekCH(i int) bool {
+	if i != 0 || d.contains(0, 5, "CHORE") {
+		return false
+	}
+	return d.contains(i+1, 5, "HARAC", "HARIS") || d.contains(i+1, 3, "HOR", "HYM", "HIA", "HEM")
+}
+
+// germanicCH reports whether the "ch" at index i is pronounced K, as in "Van Ch", "Schmidt", "orchestra"
+// or before a consonant.
+func (d *doubleMetaphoneState) germanicCH(i int) bool {
+	return d.contains(0, 4, "VAN ", "VON ") || d.contains(0, 3, "SCH") ||
+		d.contains(i-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		d.contains(i+2, 1, "T", "S") ||
+		((d.contains(i-1, 1, "A", "O", "U", "E") || i == 0) &&
+			(d.contains(i+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || i+1 == len(d.value)-1))
+}
+
+// d encodes "dge", "dgi" and "dgy" as J, "dg" as TK and D as T, skipping a following D or T.
+func (d *doubleMetaphoneState) d(i int) int {
+	switch {
+	case d.contains(i, 2, "DG") && d.contains(i+2, 1, "I", "E", "Y"):
+		d.add("J")
+		return i + 3
+	case d.contains(i, 2, "DG"):
+		d.add("TK")
+		return i + 2
+	case d.contains(i, 2, "DT", "DD"):
+		d.add("T")
+		return i + 2
+	}
+	d.add("T")
+	return i + 1
+}
+
+// g encodes G, delegating "gh" to gh and soft G before a front vowel to softG.
+func (d *doubleMetaphoneState) g(i int) int {
+	next := d.at(i + 1)
+	switch {
+	case next == 'H':
+		return d.gh(i)
+	case next == 'N':
+		d.gn(i)
+		return i + 2
+	case d.contains(i+1, 2, "LI") && !d.slavoGermanic:
+		d.addPair("KL", "L")
+		return i + 2
+	case d.ambiguousG(i):
+		d.addPair("K", "J")
+		return i + 2
+	case d.contains(i+1, 1, "E", "I", "Y") || d.contains(i-1, 4, "AGGI", "OGGI"):
+		d.softG(i)
+		return i + 2
+	case next == 'G':
+		d.add("K")
+		return i + 2
+	}
+	d.add("K")
+	return i + 1
+}
+
+// ambiguousG reports whether the G at index i may be hard or soft, as in "Gerald" or "Gypsy",
+// giving a K primary and a J alternate.
+func (d *doubleMetaphoneState) ambiguousG(i int) bool {
+	next := d.at(i + 1)
+	if i == 0 && (next == 'Y' ||
+		d.contains(i+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		return true
+	}
+	return (d.contains(i+1, 2, "ER") || next == 'Y') && !d.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!d.contains(i-1, 1, "E", "I") && !d.contains(i-1, 3, "RGY", "OGY")
+}
+
+// gn encodes "gn" as KN/N after an initial vowel, N/KN when not followed by "ey", and KN otherwise.
+func (d *doubleMetaphoneState) gn(i int) {
+	switch {
+	case i == 1 && d.isVowel(0) && !d.slavoGermanic:
+		d.addPair("KN", "N")
+	case !d.contains(i+2, 2, "EY") && d.at(i+1) != 'Y' && !d.slavoGermanic:
+		d.addPair("N", "KN")
+	default:
+		d.add("KN")
+	}
+}
+
+// softG encodes G before a front vowel as K in Germanic words, J before "ier" and J/K otherwise.
+func (d *doubleMetaphoneState) softG(i int) {
+	switch {
+	case d.contains(0, 4, "VAN ", "VON ") || d.contains(0, 3, "SCH") || d.contains(i+1, 2, "ET"):
+		d.add("K")
+	case d.contains(i+1, 3, "IER"):
+		d.add("J")
+	default:
+		d.addPair("J", "K")
+	}
+}
+
+// gh encodes "gh" as K after a consonant, silent in "bought" and "tough"-like positions, and F in "laugh".
+func (d *doubleMetaphoneState) gh(i int) int {
+	switch {
+	case i > 0 && !d.isVowel(i-1):
+		d.add("K")
+	case i == 0:
+		if d.at(i+2) == 'I' {
+			d.add("J")
+		} else {
+			d.add("K")
+		}
+	case (i > 1 && d.contains(i-2, 1, "B", "H", "D")) || (i > 2 && d.contains(i-3, 1, "B", "H", "D")) ||
+		(i > 3 && d.contains(i-4, 1, "B", "H")):
+	case i > 2 && d.at(i-1) == 'U' && d.contains(i-3, 1, "C", "G", "L", "R", "T"):
+		d.add("F")
+	case d.at(i-1) != 'I':
+		d.add("K")
+	}
+	return i + 2
+}
+
+// h encodes H only when it starts the word or follows a vowel and precedes a vowel.
+func (d *doubleMetaphoneState) h(i int) int {
+	if (i == 0 || d.isVowel(i-1)) && d.isVowel(i+1) {
+		d.add("H")
+		return i + 2
+	}
+	return i + 1
+}
+
+// j encodes J as H in Spanish names such as "Jose" and "San Juan", and as J/A or J/H otherwise.
+func (d *doubleMetaphoneState) j(i int) int {
+	if d.contains(i, 4, "JOSE") || d.contains(0, 4, "SAN ") {
+		return d.spanishJ(i)
+	}
+	switch {
+	case i == 0:
+		d.addPair("J", "A")
+	case d.isVowel(i-1) && !d.slavoGermanic && (d.at(i+1) == 'A' || d.at(i+1) == 'O'):
+		d.addPair("J", "H")
+	case i == len(d.value)-1:
+		d.primary.WriteString("J")
+	case !d.contains(i+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !d.contains(i-1, 1, "S", "K", "L"):
+		d.add("J")
+	}
+	if d.at(i+1) == 'J' {
+		return i + 2
+	}
+	return i + 1
+}
+
+// spanishJ encodes the J of "Jose" and "San ..." names as H, or J/H when "jose" is part of a longer word.
+func (d *doubleMetaphoneState) spanishJ(i int) int {
+	if (i == 0 && d.at(i+4) == ' ') || len(d.value) == 4 || d.contains(0, 4, "SAN ") {
+		d.add("H")
+	} else {
+		d.addPair("J", "H")
+	}
+	return i + 1
+}
+
+// l encodes L, dropping the alternate for a Spanish "ll" as in "Cabrillo".
+func (d *doubleMetaphoneState) l(i int) int {
+	if d.at(i+1) != 'L' {
+		d.add("L")
+		return i + 1
+	}
+	last := len(d.value) - 1
+	if (i == last-2 && d.contains(i-1, 4, "ILLO", "ILLA", "ALLE")) ||
+		((d.contains(last-1, 2, "AS", "OS") || d.contains(last, 1, "A", "O")) && d.contains(i-1, 4, "ALLE")) {
+		d.primary.WriteString("L")
+	} else {
+		d.add("L")
+	}
+	return i + 2
+}
+
+// m encodes M, skipping a following M and the silent B in "dumb" and "thumbed".
+func (d *doubleMetaphoneState) m(i int) int {
+	d.add("M")
+	if d.at(i+1) == 'M' ||
+		(d.contains(i-1, 3, "UMB") && (i+1 == len(d.value)-1 || d.contains(i+2, 2, "ER"))) {
+		return i + 2
+	}
+	return i + 1
+}
+
+// p encodes "ph" as F and P otherwise, skipping a following P or B.
+func (d *doubleMetaphoneState) p(i int) int {
+	if d.at(i+1) == 'H' {
+		d.add("F")
+		return i + 2
+	}
+	d.add("P")
+	if d.contains(i+1, 1, "P", "B") {
+		return i + 2
+	}
+	return i + 1
+}
+
+// r encodes R, dropping it from the primary code for a French final "ier" as in "Rogier".
+func (d *doubleMetaphoneState) r(i int) int {
+	if i == len(d.value)-1 && !d.slavoGermanic && d.contains(i-2, 2, "IE") && !d.contains(i-4, 2, "ME", "MA") {
+		d.alternate.WriteString("R")
+	} else {
+		d.add("R")
+	}
+	if d.at(i+1) == 'R' {
+		return i + 2
+	}
+	return i + 1
+}
+
+// s encodes S, including the silent S in "island", "sugar", "sh", "sio", "sia" and "sc".
+func (d *doubleMetaphoneState) s(i int) int {
+	switch {
+	case d.contains(i-1, 3, "ISL", "YSL"):
+		return i + 1
+	case i == 0 && d.contains(i, 5, "SUGAR"):
+		d.addPair("X", "S")
+		return i + 1
+	case d.contains(i, 2, "SH"):
+		if d.contains(i+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			d.add("S")
+		} else {
+			d.add("X")
+		}
+		return i + 2
+	case d.contains(i, 3, "SIO", "SIA") || d.contains(i, 4, "SIAN"):
+		if d.slavoGermanic {
+			d.add("S")
+		} else {
+			d.addPair("S", "X")
+		}
+		return i + 3
+	case (i == 0 && d.contains(i+1, 1, "M", "N", "L", "W")) || d.contains(i+1, 1, "Z"):
+		d.addPair("S", "X")
+		if d.contains(i+1, 1, "Z") {
+			return i + 2
+		}
+		return i + 1
+	case d.contains(i, 2, "SC"):
+		return d.sc(i)
+	}
+	return d.plainS(i)
+}
+
+// plainS encodes S, dropping it from the primary code for a French final "ais" or "ois",
+// and skips a following S or Z.
+func (d *doubleMetaphoneState) plainS(i int) int {
+	if i == len(d.value)-1 && d.contains(i-2, 2, "AI", "OI") {
+		d.alternate.WriteString("S")
+	} else {
+		d.add("S")
+	}
+	if d.contains(i+1, 1, "S", "Z") {
+		return i + 2
+	}
+	return i + 1
+}
+
+// sc encodes "sch" as SK or X depending on the following letters, "sci", "sce" and "scy" as S, and SK otherwise.
+func (d *doubleMetaphoneState) sc(i int) int {
+	switch {
+	case d.at(i+2) == 'H' && d.contains(i+3, 2, "ER", "EN"):
+		d.addPair("X", "SK")
+	case d.at(i+2) == 'H' && d.contains(i+3, 2, "OO", "UY", "ED", "EM"):
+		d.add("SK")
+	case d.at(i+2) == 'H' && i == 0 && !d.isVowel(3) && d.at(3) != 'W':
+		d.addPair("X", "S")
+	case d.at(i+2) == 'H':
+		d.add("X")
+	case d.contains(i+2, 1, "I", "E", "Y"):
+		d.add("S")
+	default:
+		d.add("SK")
+	}
+	return i + 3
+}
+
+// t encodes "tion", "tia" and "tch" as X, "th" as 0/T and T otherwise, skipping a following T or D.
+func (d *doubleMetaphoneState) t(i int) int {
+	switch {
+	case d.contains(i, 4, "TION"), d.contains(i, 3, "TIA", "TCH"):
+		d.add("X")
+		return i + 3
+	case d.contains(i, 2, "TH") || d.contains(i, 3, "TTH"):
+		if d.contains(i+2, 2, "OM", "AM") || d.contains(0, 4, "VAN ", "VON ") || d.contains(0, 3, "SCH") {
+			d.add("T")
+		} else {
+			d.addPair("0", "T")
+		}
+		return i + 2
+	}
+	d.add("T")
+	if d.contains(i+1, 1, "T", "D") {
+		return i + 2
+	}
+	return i + 1
+}
+
+// w encodes "wr" as R, an initial W as A/F, a Polish or Germanic W as F in the alternate code,
+// "wicz" and "witz" as TS/FX, and skips W otherwise.
+func (d *doubleMetaphoneState) w(i int) int {
+	switch {
+	case d.contains(i, 2, "WR"):
+		d.add("R")
+		return i + 2
+	case i == 0 && d.isVowel(i+1):
+		d.addPair("A", "F")
+	case i == 0 && d.contains(i, 2, "WH"):
+		d.add("A")
+	case (i == len(d.value)-1 && d.isVowel(i-1)) ||
+		d.contains(i-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || d.contains(0, 3, "SCH"):
+		d.alternate.WriteString("F")
+	case d.contains(i, 4, "WICZ", "WITZ"):
+		d.addPair("TS", "FX")
+		return i + 4
+	}
+	return i + 1
+}
+
+// x encodes an initial X as S, a French final X as silent and KS otherwise, skipping a following C or X.
+func (d *doubleMetaphoneState) x(i int) int {
+	if i == 0 {
+		d.add("S")
+		return i + 1
+	}
+	if !(i == len(d.value)-1 && (d.contains(i-3, 3, "IAU", "EAU") || d.contains(i-2, 2, "AU", "OU"))) {
+		d.add("KS")
+	}
+	if d.contains(i+1, 1, "C", "X") {
+		return i + 2
+	}
+	return i + 1
+}
+
+// z encodes "zh" as J, Z as S/TS in Slavic and Italian words and S otherwise, skipping a following Z.
+func (d *doubleMetaphoneState) z(i int) int {
+	if d.at(i+1) == 'H' {
+		d.add("J")
+		return i + 2
+	}
+	if d.contains(i+1, 2, "ZO", "ZI", "ZA") || (d.slavoGermanic && i > 0 && d.at(i-1) != 'T') {
+		d.addPair("S", "TS")
+	} else {
+		d.add("S")
+	}
+	if d.at(i+1) == 'Z' {
+		return i + 2
+	}
+	return i + 1
+}
@@ -0,0 +1,98 @@
+package strutil
+
+// EditStrategy selects how an edit distance is computed.
+type EditStrategy int
+
+// String returns the string representation of the EditStrategy using EditStrategyMap.
+func (e EditStrategy) String() string {
+	return EditStrategyMap[e]
+}
+
+// EditStrategyAuto picks the fastest strategy supported by the strings, costs and cutoff.
+// EditStrategyFull computes every cell of the dynamic programming matrix, keeping only the rows it needs.
+// EditStrategyBanded only computes cells within MaxDistance of the diagonal; without a cutoff the band is
+// doubled until the distance fits inside it.
+// EditStrategyBitParallel uses Myers' bit-parallel algorithm; it requires unit costs and the shorter string to
+// have at most 64 runes.
+const (
+	EditStrategyAuto EditStrategy = iota
+	EditStrategyFull
+	EditStrategyBanded
+	EditStrategyBitParallel
+)
+
+// EditStrategyMap maps EditStrategy constants to their corresponding string representations.
+var EditStrategyMap = map[EditStrategy]string{
+	EditStrategyAuto:        "Auto",
+	EditStrategyFull:        "Full",
+	EditStrategyBanded:      "Banded",
+	EditStrategyBitParallel: "Bit-Parallel",
+}
+
+// EditDistanceOptions configures the cutoff, costs and strategy of the native edit distance functions.
+// Costs describe transforming the first string into the second: deleting removes a rune of the first string and
+// inserting adds a rune of the second.
+type EditDistanceOptions struct {
+	// MaxDistance stops the computation once the distance is known to exceed it, in which case the score is
+	// MaxDistance + 1. A negative value disables the cutoff.
+	MaxDistance int
+	// InsertCost is the cost of inserting a rune; must be at least 1.
+	InsertCost int
+	// DeleteCost is the cost of deleting a rune; must be at least 1.
+	DeleteCost int
+	// SubstituteCost is the cost of replacing a rune with a different one; must not be negative.
+	SubstituteCost int
+	// TransposeCost is the cost of swapping two adjacent runes in the Damerau variants; must not be negative.
+	TransposeCost int
+	// SubstituteFunc, if set, returns the cost of replacing a with b and overrides SubstituteCost.
+	// It is only called for different runes and negative costs are treated as 0.
+	SubstituteFunc func(a, b rune) int
+	// Strategy selects the algorithm used to compute the distance.
+	Strategy EditStrategy
+}
+
+// NewEditDistanceOptions returns EditDistanceOptions with unit costs, no cutoff and EditStrategyAuto,
+// which yields the same distances as LevenshteinDistance and OSADamerauLevenshteinDistance.
+func NewEditDistanceOptions() EditDistanceOptions {
+	return EditDistanceOptions{
+		MaxDistance:    -1,
+		InsertCost:     1,
+		DeleteCost:     1,
+		SubstituteCost: 1,
+		TransposeCost:  1,
+		Strategy:       EditStrategyAuto,
+	}
+}
+
+// LevenshteinDistanceWith calculates the Levenshtein distance between s1 and s2 using the given options and
+// returns a ComparisonResultInt. Returns an error result if the costs or strategy are invalid.
+func LevenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	return levenshteinDistanceWith(s1, s2, opts)
+}
+
+// OSADamerauLevenshteinDistanceWith calculates the optimal string alignment distance between s1 and s2 using the
+// given options, counting swaps of adjacent runes as a single edit as long as neither is edited again.
+// Returns an error result if the costs or strategy are invalid.
+func OSADamerauLevenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	return osaDamerauLevenshteinDistanceWith(s1, s2, opts)
+}
+
+// DamerauLevenshteinDistanceWith calculates the unrestricted Damerau-Levenshtein distance between s1 and s2 using
+// the given options. It always computes the full matrix, so only EditStrategyAuto and EditStrategyFull are
+// supported, and the result is only optimal when twice the transpose cost is at least the insert plus delete cost.
+func DamerauLevenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	return damerauLevenshteinDistanceWith(s1, s2, opts)
+}
+
+// EditOperations returns the operations of a minimal edit script turning s1 into s2, with positions, as an
+// EditScriptResult. Each inserted, deleted or substituted rune and each swap of adjacent runes counts as one edit,
+// so the distance matches OSADamerauLevenshteinDistance. The result renders as an inline, ANSI or unified diff.
+func EditOperations(s1, s2 string) *EditScriptResult {
+	return editOperations(s1, s2)
+}
+
+// QWERTYSubstitution returns a SubstituteFunc that charges adjacentCost for replacing a key with one next to it
+// on a QWERTY keyboard and cost for any other replacement. Letters are compared case-insensitively.
+func QWERTYSubstitution(adjacentCost, cost int) func(a, b rune) int {
+	return qwertySubstitution(adjacentCost, cost)
+}
@@ -0,0 +1,363 @@
+package strutil
+
+import (
+	"math"
+	"unicode"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// editInfinity marks cells that cannot be reached; it leaves enough headroom that adding costs cannot overflow.
+const editInfinity = math.MaxInt / 4
+
+// bitParallelWordSize is the longest pattern, in runes, the bit-parallel algorithm can encode in a single word.
+const bitParallelWordSize = 64
+
+// qwertyRows lists the keys of a QWERTY keyboard from top to bottom; each row is offset half a key to the right
+// of the row above it.
+var qwertyRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// qwertyAdjacent holds every ordered pair of neighboring QWERTY keys.
+var qwertyAdjacent = buildQWERTYAdjacency()
+
+// editCosts holds validated edit costs.
+type editCosts struct {
+	ins     int
+	del     int
+	sub     int
+	trans   int
+	subFunc func(a, b rune) int
+}
+
+// newEditCosts validates the costs in opts and returns them as editCosts.
+func newEditCosts(opts EditDistanceOptions) (editCosts, error) {
+	if opts.InsertCost < 1 || opts.DeleteCost < 1 || opts.SubstituteCost < 0 || opts.TransposeCost < 0 {
+		return editCosts{}, errors.ErrInvalidEditCosts
+	}
+	return editCosts{
+		ins:     opts.InsertCost,
+		del:     opts.DeleteCost,
+		sub:     opts.SubstituteCost,
+		trans:   opts.TransposeCost,
+		subFunc: opts.SubstituteFunc,
+	}, nil
+}
+
+// substitute returns the cost of replacing a with b, which is 0 for equal runes.
+func (c editCosts) substitute(a, b rune) int {
+	if a == b {
+		return 0
+	}
+	if c.subFunc == nil {
+		return c.sub
+	}
+	return max(c.subFunc(a, b), 0)
+}
+
+// unit reports whether every edit costs 1, which the bit-parallel algorithm requires.
+func (c editCosts) unit() bool {
+	return c.ins == 1 && c.del == 1 && c.sub == 1 && c.trans == 1 && c.subFunc == nil
+}
+
+// minIndel returns the cheaper of the insert and delete costs.
+func (c editCosts) minIndel() int {
+	return min(c.ins, c.del)
+}
+
+// lowerBound returns the cost of the insertions or deletions needed to equalize lengths n and m,
+// which no edit script can avoid.
+func (c editCosts) lowerBound(n, m int) int {
+	if n > m {
+		return (n - m) * c.del
+	}
+	return (m - n) * c.ins
+}
+
+// clampEditDistance reports a distance above a non-negative limit as limit + 1.
+func clampEditDistance(d, limit int) int {
+	if limit >= 0 && d > limit {
+		return limit + 1
+	}
+	return d
+}
+
+// levenshteinDistanceWith computes the Levenshtein distance between s1 and s2 using opts
+// and returns a ComparisonResultInt.
+func levenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	d, err := editDistance(s1, s2, opts, false)
+	if err != nil {
+		return NewComparisonResultInt(CustomLevDist, s1, s2, nil, nil, err)
+	}
+	return NewComparisonResultInt(CustomLevDist, s1, s2, nil, &d, nil)
+}
+
+// osaDamerauLevenshteinDistanceWith computes the optimal string alignment distance between s1 and s2 using opts
+// and returns a ComparisonResultInt.
+func osaDamerauLevenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	d, err := editDistance(s1, s2, opts, true)
+	if err != nil {
+		return NewComparisonResultInt(CustomOSADamLevDist, s1, s2, nil, nil, err)
+	}
+	return NewComparisonResultInt(CustomOSADamLevDist, s1, s2, nil, &d, nil)
+}
+
+// damerauLevenshteinDistanceWith computes the unrestricted Damerau-Levenshtein distance between s1 and s2
+// using opts and returns a ComparisonResultInt.
+func damerauLevenshteinDistanceWith(s1, s2 string, opts EditDistanceOptions) *ComparisonResultInt {
+	d, err := damerauDistance(s1, s2, opts)
+	if err != nil {
+		return NewComparisonResultInt(CustomDamLevDist, s1, s2, nil, nil, err)
+	}
+	return NewComparisonResultInt(CustomDamLevDist, s1, s2, nil, &d, nil)
+}
+
+// editDistance validates opts and computes the Levenshtein distance between s1 and s2, or the optimal string
+// alignment distance if transpose is set, using the requested strategy.
+func editDistance(s1, s2 string, opts EditDistanceOptions, transpose bool) (int, error) {
+	c, err := newEditCosts(opts)
+	if err != nil {
+		return 0, err
+	}
+	a, b := []rune(s1), []rune(s2)
+	if _, ok := EditStrategyMap[opts.Strategy]; !ok ||
+		(opts.Strategy == EditStrategyBitParallel && !bitParallelSupported(a, b, c)) {
+		return 0, errors.ErrUnsupportedEditStrategy
+	}
+	limit := opts.MaxDistance
+	if limit >= 0 && c.lowerBound(len(a), len(b)) > limit {
+		return limit + 1, nil
+	}
+	switch opts.Strategy {
+	case EditStrategyFull:
+		return clampEditDistance(editDistanceBanded(a, b, c, len(a)+len(b), limit, transpose), limit), nil
+	case EditStrategyBanded:
+		return editDistanceBandedSearch(a, b, c, limit, transpose), nil
+	case EditStrategyBitParallel:
+		return bitParallelEditDistance(a, b, limit, transpose), nil
+	default:
+		return editDistanceAuto(a, b, c, limit, transpose), nil
+	}
+}
+
+// editDistanceAuto uses the bit-parallel algorithm when the strings and costs allow it, a band around the
+// diagonal when a cutoff is set, and the full matrix otherwise.
+func editDistanceAuto(a, b []rune, c editCosts, limit int, transpose bool) int {
+	if bitParallelSupported(a, b, c) {
+		return bitParallelEditDistance(a, b, limit, transpose)
+	}
+	if limit >= 0 {
+		return editDistanceBandedSearch(a, b, c, limit, transpose)
+	}
+	return editDistanceBanded(a, b, c, len(a)+len(b), limit, transpose)
+}
+
+// editDistanceBandedSearch computes the distance within a band wide enough for limit. Without a limit it starts
+// from the length difference and doubles the budget until the distance fits inside the band (Ukkonen).
+func editDistanceBandedSearch(a, b []rune, c editCosts, limit int, transpose bool) int {
+	if limit >= 0 {
+		return clampEditDistance(editDistanceBanded(a, b, c, limit/c.minIndel(), limit, transpose), limit)
+	}
+	budget := max(c.lowerBound(len(a), len(b)), c.minIndel())
+	for {
+		if d := editDistanceBanded(a, b, c, budget/c.minIndel(), budget, transpose); d <= budget {
+			return d
+		}
+		budget *= 2
+	}
+}
+
+// editDistanceBanded computes the distance using only the cells within width of the diagonal, keeping three rows.
+// Any edit script leaving the band needs more than width insertions or deletions, so the result is exact whenever
+// it is at most width times the cheaper of those costs. Stops early once two consecutive rows exceed a
+// non-negative limit, as every later cell is derived from them.
+func editDistanceBanded(a, b []rune, c editCosts, width, limit int, transpose bool) int {
+	n, m := len(a), len(b)
+	prev2, prev, curr := newEditRow(m), newEditRow(m), newEditRow(m)
+	for j := 0; j <= min(m, width); j++ {
+		prev[j] = j * c.ins
+	}
+	prevMin := 0
+	for i := 1; i <= n; i++ {
+		lo, hi := max(0, i-width), min(m, i+width)
+		if lo > hi {
+			return editInfinity
+		}
+		rowMin := editBandRow(a, b, c, i, lo, hi, [3][]int{prev2, prev, curr}, transpose)
+		if limit >= 0 && rowMin > limit && (!transpose || prevMin > limit) {
+			return limit + 1
+		}
+		prevMin = rowMin
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[m]
+}
+
+// newEditRow returns a matrix row of m + 1 unreachable cells.
+func newEditRow(m int) []int {
+	row := make([]int, m+1)
+	for j := range row {
+		row[j] = editInfinity
+	}
+	return row
+}
+
+// editBandRow fills columns lo through hi of row i from the two rows above it and returns the row's minimum.
+// Cells right of the band were never written and stay unreachable; the cell left of it is reset explicitly.
+func editBandRow(a, b []rune, c editCosts, i, lo, hi int, rows [3][]int, transpose bool) int {
+	prev2, prev, curr := rows[0], rows[1], rows[2]
+	rowMin := editInfinity
+	if lo > 0 {
+		curr[lo-1] = editInfinity
+	} else {
+		curr[0] = i * c.del
+		rowMin = curr[0]
+		lo = 1
+	}
+	for j := lo; j <= hi; j++ {
+		v := min(prev[j]+c.del, curr[j-1]+c.ins, prev[j-1]+c.substitute(a[i-1], b[j-1]))
+		if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+			v = min(v, prev2[j-2]+c.trans)
+		}
+		curr[j] = v
+		rowMin = min(rowMin, v)
+	}
+	return rowMin
+}
+
+// bitParallelSupported reports whether the bit-parallel algorithm can compute the distance between a and b.
+func bitParallelSupported(a, b []rune, c editCosts) bool {
+	return c.unit() && min(len(a), len(b)) <= bitParallelWordSize
+}
+
+// bitParallelEditDistance computes the unit-cost distance with Myers' bit-parallel algorithm as formulated by
+// Hyyrö, encoding the shorter string in a single word. With transpose set it also counts adjacent swaps (OSA).
+// Stops early once the distance cannot drop back to a non-negative limit in the remaining columns.
+func bitParallelEditDistance(a, b []rune, limit int, transpose bool) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return clampEditDistance(len(b), limit)
+	}
+	peq := make(map[rune]uint64, len(a))
+	for i, r := range a {
+		peq[r] |= 1 << i
+	}
+	last := uint64(1) << (len(a) - 1)
+	vp, vn, d0, pmPrev := ^uint64(0), uint64(0), uint64(0), uint64(0)
+	score := len(a)
+	for j, r := range b {
+		pm := peq[r]
+		tr := ((^d0 & pm) << 1) & pmPrev
+		d0 = (((pm & vp) + vp) ^ vp) | pm | vn
+		if transpose {
+			d0 |= tr
+		}
+		hp := vn | ^(d0 | vp)
+		hn := d0 & vp
+		if hp&last != 0 {
+			score++
+		} else if hn&last != 0 {
+			score--
+		}
+		hp = hp<<1 | 1
+		hn <<= 1
+		vp = hn | ^(d0 | hp)
+		vn = hp & d0
+		pmPrev = pm
+		if limit >= 0 && score-(len(b)-1-j) > limit {
+			return limit + 1
+		}
+	}
+	return clampEditDistance(score, limit)
+}
+
+// unitEditDistance returns the unit-cost Levenshtein distance between s1 and s2, or the optimal string alignment
+// distance if transpose is set, without validating options or building a result.
+func unitEditDistance(s1, s2 string, transpose bool) int {
+	unit := editCosts{ins: 1, del: 1, sub: 1, trans: 1}
+	return editDistanceAuto([]rune(s1), []rune(s2), unit, -1, transpose)
+}
+
+// damerauDistance validates opts and computes the unrestricted Damerau-Levenshtein distance between s1 and s2.
+func damerauDistance(s1, s2 string, opts EditDistanceOptions) (int, error) {
+	c, err := newEditCosts(opts)
+	if err != nil {
+		return 0, err
+	}
+	if opts.Strategy != EditStrategyAuto && opts.Strategy != EditStrategyFull {
+		return 0, errors.ErrUnsupportedEditStrategy
+	}
+	a, b := []rune(s1), []rune(s2)
+	if opts.MaxDistance >= 0 && c.lowerBound(len(a), len(b)) > opts.MaxDistance {
+		return opts.MaxDistance + 1, nil
+	}
+	return clampEditDistance(lowranceWagner(a, b, c), opts.MaxDistance), nil
+}
+
+// lowranceWagner computes the unrestricted Damerau-Levenshtein distance with the Lowrance-Wagner algorithm.
+// The matrix has an extra unreachable row and column so transpositions without a previous occurrence are ignored.
+func lowranceWagner(a, b []rune, c editCosts) int {
+	n, m := len(a), len(b)
+	d := make([][]int, n+2)
+	for i := range d {
+		d[i] = newEditRow(m + 1)
+	}
+	for i := 0; i <= n; i++ {
+		d[i+1][1] = i * c.del
+	}
+	for j := 0; j <= m; j++ {
+		d[1][j+1] = j * c.ins
+	}
+	lastRow := make(map[rune]int)
+	for i := 1; i <= n; i++ {
+		lastCol := 0
+		for j := 1; j <= m; j++ {
+			k, l := lastRow[b[j-1]], lastCol
+			if a[i-1] == b[j-1] {
+				lastCol = j
+			}
+			d[i+1][j+1] = min(d[i][j]+c.substitute(a[i-1], b[j-1]), d[i+1][j]+c.ins, d[i][j+1]+c.del,
+				d[k][l]+(i-k-1)*c.del+c.trans+(j-l-1)*c.ins)
+		}
+		lastRow[a[i-1]] = i
+	}
+	return d[n+1][m+1]
+}
+
+// buildQWERTYAdjacency links every key to its horizontal neighbors and to the keys touching it in the row below.
+func buildQWERTYAdjacency() map[[2]rune]bool {
+	adjacent := make(map[[2]rune]bool)
+	link := func(x, y rune) {
+		adjacent[[2]rune{x, y}] = true
+		adjacent[[2]rune{y, x}] = true
+	}
+	for r, row := range qwertyRows {
+		keys := []rune(row)
+		for col, key := range keys {
+			if col+1 < len(keys) {
+				link(key, keys[col+1])
+			}
+			if r+1 == len(qwertyRows) {
+				continue
+			}
+			below := []rune(qwertyRows[r+1])
+			for _, bc := range []int{col - 1, col} {
+				if bc >= 0 && bc < len(below) {
+					link(key, below[bc])
+				}
+			}
+		}
+	}
+	return adjacent
+}
+
+// qwertySubstitution returns a substitution cost function charging adjacentCost for neighboring QWERTY keys.
+func qwertySubstitution(adjacentCost, cost int) func(a, b rune) int {
+	return func(a, b rune) int {
+		if qwertyAdjacent[[2]rune{unicode.ToLower(a), unicode.ToLower(b)}] {
+			return adjacentCost
+		}
+		return cost
+	}
+}
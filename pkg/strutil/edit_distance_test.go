@@ -0,0 +1,212 @@
+package strutil
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+
+	"github.com/hbollon/go-edlib"
+)
+
+// randomEditString returns a string of up to maxLen runes drawn from a small alphabet so edits overlap.
+func randomEditString(r *rand.Rand, maxLen int) string {
+	alphabet := []rune("abcdé")
+	n := r.Intn(maxLen + 1)
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(out)
+}
+
+func TestEditDistanceStrategiesMatchEdlib(t *testing.T) {
+	r := rand.New(rand.NewSource(33))
+	strategies := []EditStrategy{EditStrategyAuto, EditStrategyFull, EditStrategyBanded, EditStrategyBitParallel}
+	for i := 0; i < 500; i++ {
+		s1, s2 := randomEditString(r, 12), randomEditString(r, 12)
+		if i%50 == 0 {
+			s1, s2 = strings.Repeat(s1+"x", 8), strings.Repeat(s2+"x", 8)
+		}
+		wantLev := edlib.LevenshteinDistance(s1, s2)
+		wantOSA := edlib.OSADamerauLevenshteinDistance(s1, s2)
+		for _, strategy := range strategies {
+			opts := NewEditDistanceOptions()
+			opts.Strategy = strategy
+			lev, err := LevenshteinDistanceWith(s1, s2, opts).GetScoreInt()
+			if strategy == EditStrategyBitParallel && min(len([]rune(s1)), len([]rune(s2))) > 64 {
+				if !errors.Is(err, errors2.ErrUnsupportedEditStrategy) {
+					t.Errorf("LevenshteinDistanceWith(%q, %q, %s) error = %v; want unsupported", s1, s2, strategy, err)
+				}
+				continue
+			}
+			if err != nil || lev != wantLev {
+				t.Errorf("LevenshteinDistanceWith(%q, %q, %s) = %d (%v); want %d", s1, s2, strategy, lev, err, wantLev)
+			}
+			osa, err := OSADamerauLevenshteinDistanceWith(s1, s2, opts).GetScoreInt()
+			if err != nil || osa != wantOSA {
+				t.Errorf("OSADamerauLevenshteinDistanceWith(%q, %q, %s) = %d (%v); want %d",
+					s1, s2, strategy, osa, err, wantOSA)
+			}
+		}
+		dl, err := DamerauLevenshteinDistanceWith(s1, s2, NewEditDistanceOptions()).GetScoreInt()
+		if want := edlib.DamerauLevenshteinDistance(s1, s2); err != nil || dl != want {
+			t.Errorf("DamerauLevenshteinDistanceWith(%q, %q) = %d (%v); want %d", s1, s2, dl, err, want)
+		}
+	}
+}
+
+func TestEditDistanceCutoff(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	for i := 0; i < 300; i++ {
+		s1, s2 := randomEditString(r, 10), randomEditString(r, 10)
+		maxDistance := r.Intn(6)
+		exact := edlib.LevenshteinDistance(s1, s2)
+		want := min(exact, maxDistance+1)
+		for strategy := range EditStrategyMap {
+			opts := NewEditDistanceOptions()
+			opts.MaxDistance = maxDistance
+			opts.Strategy = strategy
+			got, err := LevenshteinDistanceWith(s1, s2, opts).GetScoreInt()
+			if err != nil || got != want {
+				t.Errorf("LevenshteinDistanceWith(%q, %q, max %d, %s) = %d (%v); want %d",
+					s1, s2, maxDistance, strategy, got, err, want)
+			}
+		}
+	}
+}
+
+func TestEditDistanceCustomCosts(t *testing.T) {
+	qwerty := NewEditDistanceOptions()
+	qwerty.InsertCost, qwerty.DeleteCost, qwerty.SubstituteCost = 2, 2, 2
+	qwerty.SubstituteFunc = QWERTYSubstitution(1, 2)
+	cheapDelete := NewEditDistanceOptions()
+	cheapDelete.InsertCost, cheapDelete.DeleteCost, cheapDelete.SubstituteCost = 3, 1, 5
+	swap := NewEditDistanceOptions()
+	swap.TransposeCost = 5
+	capped := qwerty
+	capped.MaxDistance = 2
+	tests := []struct {
+		name     string
+		fn       func(string, string, EditDistanceOptions) *ComparisonResultInt
+		s1       string
+		s2       string
+		opts     EditDistanceOptions
+		expected int
+	}{
+		{"LevenshteinQWERTYAdjacent", LevenshteinDistanceWith, "hello", "jello", qwerty, 1},
+		{"LevenshteinQWERTYCase", LevenshteinDistanceWith, "Hello", "jello", qwerty, 1},
+		{"LevenshteinQWERTYDistant", LevenshteinDistanceWith, "hello", "mello", qwerty, 2},
+		{"LevenshteinQWERTYCapped", LevenshteinDistanceWith, "hello", "jrllp", capped, 3},
+		{"LevenshteinCheapDelete", LevenshteinDistanceWith, "abc", "xbc", cheapDelete, 4},
+		{"LevenshteinCheapDeleteReverse", LevenshteinDistanceWith, "abcd", "ab", cheapDelete, 2},
+		{"LevenshteinInsertOnly", LevenshteinDistanceWith, "ab", "abcd", cheapDelete, 6},
+		{"OSAExpensiveSwap", OSADamerauLevenshteinDistanceWith, "ab", "ba", swap, 2},
+		{"OSAQWERTYSwap", OSADamerauLevenshteinDistanceWith, "form", "from", qwerty, 1},
+		{"DamerauQWERTYSwap", DamerauLevenshteinDistanceWith, "ca", "abc", qwerty, 3},
+		{"DamerauCheapDelete", DamerauLevenshteinDistanceWith, "abcd", "ab", cheapDelete, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(tt.s1, tt.s2, tt.opts).GetScoreInt()
+			if err != nil || result != tt.expected {
+				t.Errorf("%s(%q, %q) = %d (%v); want %d", tt.name, tt.s1, tt.s2, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEditDistanceCustomCostStrategies(t *testing.T) {
+	r := rand.New(rand.NewSource(35))
+	opts := NewEditDistanceOptions()
+	opts.InsertCost, opts.DeleteCost, opts.SubstituteCost, opts.TransposeCost = 2, 3, 4, 1
+	for i := 0; i < 300; i++ {
+		s1, s2 := randomEditString(r, 10), randomEditString(r, 10)
+		for _, transpose := range []bool{false, true} {
+			full := opts
+			full.Strategy = EditStrategyFull
+			want, _ := editDistance(s1, s2, full, transpose)
+			banded := opts
+			banded.Strategy = EditStrategyBanded
+			if got, _ := editDistance(s1, s2, banded, transpose); got != want {
+				t.Errorf("banded editDistance(%q, %q, %t) = %d; want %d", s1, s2, transpose, got, want)
+			}
+			banded.MaxDistance = r.Intn(10)
+			if got, _ := editDistance(s1, s2, banded, transpose); got != min(want, banded.MaxDistance+1) {
+				t.Errorf("banded editDistance(%q, %q, %t, max %d) = %d; want %d",
+					s1, s2, transpose, banded.MaxDistance, got, min(want, banded.MaxDistance+1))
+			}
+		}
+	}
+}
+
+func TestEditDistanceErrors(t *testing.T) {
+	badCosts := NewEditDistanceOptions()
+	badCosts.InsertCost = 0
+	bitParallelCosts := NewEditDistanceOptions()
+	bitParallelCosts.SubstituteCost = 2
+	bitParallelCosts.Strategy = EditStrategyBitParallel
+	unknown := NewEditDistanceOptions()
+	unknown.Strategy = EditStrategy(42)
+	banded := NewEditDistanceOptions()
+	banded.Strategy = EditStrategyBanded
+	tests := []struct {
+		name     string
+		fn       func(string, string, EditDistanceOptions) *ComparisonResultInt
+		opts     EditDistanceOptions
+		expected error
+	}{
+		{"LevenshteinInvalidCosts", LevenshteinDistanceWith, badCosts, errors2.ErrInvalidEditCosts},
+		{"LevenshteinBitParallelCosts", LevenshteinDistanceWith, bitParallelCosts, errors2.ErrUnsupportedEditStrategy},
+		{"OSAUnknownStrategy", OSADamerauLevenshteinDistanceWith, unknown, errors2.ErrUnsupportedEditStrategy},
+		{"DamerauInvalidCosts", DamerauLevenshteinDistanceWith, badCosts, errors2.ErrInvalidEditCosts},
+		{"DamerauBanded", DamerauLevenshteinDistanceWith, banded, errors2.ErrUnsupportedEditStrategy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.fn("kitten", "sitting", tt.opts)
+			if _, err := result.GetScoreInt(); !errors.Is(err, tt.expected) {
+				t.Errorf("%s error = %v; want %v", tt.name, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStringBuilderEditDistanceWith(t *testing.T) {
+	opts := NewEditDistanceOptions()
+	opts.MaxDistance = 2
+	sb := New("kitten").
+		LevenshteinDistanceWith("sitting", opts).
+		DamerauLevenshteinDistanceWith("kitetn", NewEditDistanceOptions()).
+		OSADamerauLevenshteinDistanceWith("iktten", NewEditDistanceOptions())
+	tests := []struct {
+		name       string
+		resultType ComparisonResultType
+		other      string
+		expected   int
+	}{
+		{"BuilderLevenshteinCapped", CustomLevDist, "sitting", 3},
+		{"BuilderDamerau", CustomDamLevDist, "kitetn", 1},
+		{"BuilderOSA", CustomOSADamLevDist, "iktten", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := sb.GetComparisonManager().GetComparisonResult(tt.resultType, tt.other)
+			result, ok := CastComparisonResult(&stored).(*ComparisonResultInt)
+			if !ok {
+				t.Fatalf("%s - result not stored in ComparisonManager", tt.name)
+			}
+			if score, err := result.GetScoreInt(); err != nil || score != tt.expected {
+				t.Errorf("%s = %d (%v); want %d", tt.name, score, err, tt.expected)
+			}
+		})
+	}
+	badCosts := NewEditDistanceOptions()
+	badCosts.DeleteCost = -1
+	if err := New("kitten").LevenshteinDistanceWith("sitting", badCosts).Error(); !errors.Is(err,
+		errors2.ErrInvalidEditCosts) {
+		t.Errorf("StringBuilder.LevenshteinDistanceWith error = %v; want %v", err, errors2.ErrInvalidEditCosts)
+	}
+}
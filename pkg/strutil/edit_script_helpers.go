@@ -0,0 +1,176 @@
+package strutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ansiReset, ansiRed and ansiGreen are the ANSI escape codes used to color terminal output.
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+)
+
+// unifiedSegmentEscaper escapes line breaks so every edit segment fits on a single diff line.
+var unifiedSegmentEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`)
+
+// editOperations computes the unit-cost optimal string alignment between s1 and s2 and returns the
+// operations of one optimal edit script as an EditScriptResult.
+func editOperations(s1, s2 string) *EditScriptResult {
+	a, b := []rune(s1), []rune(s2)
+	d := editScriptMatrix(a, b)
+	return NewEditScriptResult(s1, s2, d[len(a)][len(b)], editBacktrack(a, b, d))
+}
+
+// editScriptMatrix fills the complete unit-cost optimal string alignment matrix between a and b,
+// which editBacktrack needs to recover the operations.
+func editScriptMatrix(a, b []rune) [][]int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if editTransposable(a, b, i, j) {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d
+}
+
+// editTransposable reports whether the two runes of a ending at i are the two runes of b ending at j swapped.
+func editTransposable(a, b []rune, i, j int) bool {
+	return i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && a[i-1] != a[i-2]
+}
+
+// editBacktrack walks the matrix from the bottom-right corner back to the origin, collecting one operation per
+// step, and returns the operations in order with consecutive steps of the same type merged.
+func editBacktrack(a, b []rune, d [][]int) []EditOperation {
+	var steps []EditOperation
+	for i, j := len(a), len(b); i > 0 || j > 0; {
+		op := editStep(a, b, d, i, j)
+		steps = append(steps, op)
+		i, j = op.Index1, op.Index2
+	}
+	slices.Reverse(steps)
+	var ops []EditOperation
+	for _, op := range steps {
+		if n := len(ops); n > 0 && ops[n-1].Type == op.Type && op.Type != EditTranspose {
+			ops[n-1].From += op.From
+			ops[n-1].To += op.To
+			continue
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// editStep returns the operation that reaches cell (i, j) of an optimal path, preferring matches, then
+// substitutions, transpositions, deletions and finally insertions.
+func editStep(a, b []rune, d [][]int, i, j int) EditOperation {
+	switch {
+	case i > 0 && j > 0 && a[i-1] == b[j-1] && d[i][j] == d[i-1][j-1]:
+		return editSpan(EditEqual, a, b, i-1, i, j-1, j)
+	case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+		return editSpan(EditSubstitute, a, b, i-1, i, j-1, j)
+	case editTransposable(a, b, i, j) && d[i][j] == d[i-2][j-2]+1:
+		return editSpan(EditTranspose, a, b, i-2, i, j-2, j)
+	case i > 0 && d[i][j] == d[i-1][j]+1:
+		return editSpan(EditDelete, a, b, i-1, i, j, j)
+	default:
+		return editSpan(EditInsert, a, b, i, i, j-1, j)
+	}
+}
+
+// editSpan returns an operation of type t covering a[i1:i2] and b[j1:j2].
+func editSpan(t EditOpType, a, b []rune, i1, i2, j1, j2 int) EditOperation {
+	return EditOperation{Type: t, Index1: i1, Index2: j1, From: string(a[i1:i2]), To: string(b[j1:j2])}
+}
+
+// renderEditScript writes unchanged runes as they are and wraps removed and added runes with del and ins.
+func renderEditScript(ops []EditOperation, del, ins func(string) string) string {
+	var sb strings.Builder
+	for _, op := range ops {
+		switch op.Type {
+		case EditEqual:
+			sb.WriteString(op.From)
+		case EditDelete:
+			sb.WriteString(del(op.From))
+		case EditInsert:
+			sb.WriteString(ins(op.To))
+		default:
+			sb.WriteString(del(op.From))
+			sb.WriteString(ins(op.To))
+		}
+	}
+	return sb.String()
+}
+
+// inlineDeletion marks s as deleted text.
+func inlineDeletion(s string) string {
+	return "[-" + s + "-]"
+}
+
+// inlineInsertion marks s as inserted text.
+func inlineInsertion(s string) string {
+	return "{+" + s + "+}"
+}
+
+// ansiDeletion colors s red.
+func ansiDeletion(s string) string {
+	return ansiRed + s + ansiReset
+}
+
+// ansiInsertion colors s green.
+func ansiInsertion(s string) string {
+	return ansiGreen + s + ansiReset
+}
+
+// renderUnifiedEditScript renders ops as a single unified diff hunk with one line per segment.
+func renderUnifiedEditScript(ops []EditOperation, label1, label2 string) string {
+	var body strings.Builder
+	count1, count2, changed := 0, 0, false
+	for _, op := range ops {
+		from, to := unifiedSegmentEscaper.Replace(op.From), unifiedSegmentEscaper.Replace(op.To)
+		if op.Type == EditEqual {
+			body.WriteString(" " + from + "\n")
+			count1++
+			count2++
+			continue
+		}
+		changed = true
+		if op.From != "" {
+			body.WriteString("-" + from + "\n")
+			count1++
+		}
+		if op.To != "" {
+			body.WriteString("+" + to + "\n")
+			count2++
+		}
+	}
+	if !changed {
+		return ""
+	}
+	return fmt.Sprintf("--- %s\n+++ %s\n@@ -%s +%s @@\n%s",
+		label1, label2, unifiedRange(count1), unifiedRange(count2), body.String())
+}
+
+// unifiedRange formats the start and length of a hunk side, which starts at 0 when the side is empty.
+func unifiedRange(count int) string {
+	if count == 0 {
+		return "0,0"
+	}
+	return fmt.Sprintf("1,%d", count)
+}
@@ -0,0 +1,150 @@
+package strutil
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EditOpType identifies the kind of change an EditOperation makes.
+type EditOpType int
+
+// String returns the string representation of the EditOpType using EditOpTypeMap.
+func (e EditOpType) String() string {
+	return EditOpTypeMap[e]
+}
+
+// EditEqual marks runes that are unchanged between the two strings.
+// EditInsert marks runes that only appear in the second string.
+// EditDelete marks runes that only appear in the first string.
+// EditSubstitute marks runes of the first string replaced one for one by runes of the second.
+// EditTranspose marks two adjacent runes of the first string that are swapped in the second.
+const (
+	EditEqual EditOpType = iota
+	EditInsert
+	EditDelete
+	EditSubstitute
+	EditTranspose
+)
+
+// EditOpTypeMap maps EditOpType constants to their corresponding string representations.
+var EditOpTypeMap = map[EditOpType]string{
+	EditEqual:      "Equal",
+	EditInsert:     "Insert",
+	EditDelete:     "Delete",
+	EditSubstitute: "Substitute",
+	EditTranspose:  "Transpose",
+}
+
+// EditOperation is one step of an edit script. Consecutive steps of the same type are merged,
+// except transpositions, which always cover exactly two runes.
+type EditOperation struct {
+	// Type is the kind of change.
+	Type EditOpType
+	// Index1 is the rune offset in the first string where the operation starts.
+	Index1 int
+	// Index2 is the rune offset in the second string where the operation starts.
+	Index2 int
+	// From holds the runes taken from the first string; empty for insertions.
+	From string
+	// To holds the runes taken from the second string; empty for deletions.
+	To string
+}
+
+// EditScriptResult holds the edit operations that turn one string into another, along with their distance.
+type EditScriptResult struct {
+	string1    string
+	string2    string
+	distance   int
+	operations []EditOperation
+}
+
+// NewEditScriptResult initializes and returns a new EditScriptResult with the provided parameters.
+func NewEditScriptResult(string1 string,
+	string2 string,
+	distance int,
+	operations []EditOperation) *EditScriptResult {
+	return &EditScriptResult{
+		string1:    string1,
+		string2:    string2,
+		distance:   distance,
+		operations: operations,
+	}
+}
+
+// GetString1 returns the string the edit script starts from.
+func (es *EditScriptResult) GetString1() string {
+	return es.string1
+}
+
+// GetString2 returns the string the edit script produces.
+func (es *EditScriptResult) GetString2() string {
+	return es.string2
+}
+
+// GetStrings returns both strings of the edit script.
+func (es *EditScriptResult) GetStrings() (string, string) {
+	return es.string1, es.string2
+}
+
+// GetDistance returns the optimal string alignment distance between the two strings, where every inserted,
+// deleted or substituted rune and every transposition counts as one edit.
+func (es *EditScriptResult) GetDistance() int {
+	return es.distance
+}
+
+// GetOperations returns a copy of the edit operations in order.
+func (es *EditScriptResult) GetOperations() []EditOperation {
+	return slices.Clone(es.operations)
+}
+
+// Inline renders the edit script as the first string with changes marked inline: deleted runes as [-del-] and
+// inserted runes as {+ins+}. Substitutions and transpositions render as a deletion followed by an insertion.
+func (es *EditScriptResult) Inline() string {
+	return renderEditScript(es.operations, inlineDeletion, inlineInsertion)
+}
+
+// ANSI renders the edit script like Inline, but colors deleted runes red and inserted runes green
+// using ANSI escape codes instead of brackets.
+func (es *EditScriptResult) ANSI() string {
+	return renderEditScript(es.operations, ansiDeletion, ansiInsertion)
+}
+
+// Unified renders the edit script as a single-hunk unified diff with the given labels, using one line per
+// operation segment instead of per line of text. Newlines and carriage returns inside segments are escaped
+// as \n and \r. Returns an empty string if the strings are equal.
+func (es *EditScriptResult) Unified(label1, label2 string) string {
+	return renderUnifiedEditScript(es.operations, label1, label2)
+}
+
+// IsMatch compares two EditScriptResult objects for equality of strings, distance and operations.
+func (es *EditScriptResult) IsMatch(other *EditScriptResult) bool {
+	if es == nil || other == nil {
+		return false
+	}
+	return es.string1 == other.string1 &&
+		es.string2 == other.string2 &&
+		es.distance == other.distance &&
+		slices.Equal(es.operations, other.operations)
+}
+
+// Print outputs the EditScriptResult details to the console based on the provided verbosity flag.
+func (es *EditScriptResult) Print(v bool) {
+	fmt.Print(formatEditScriptResultOutput(es, v))
+}
+
+// formatEditScriptResultOutput formats an EditScriptResult for display.
+// Verbose output lists every operation; concise output shows the distance and the inline rendering.
+func formatEditScriptResultOutput(es *EditScriptResult, v bool) string {
+	if es == nil {
+		return ""
+	}
+	if !v {
+		return fmt.Sprintf("Edit Script (%s/%s): %d: %s\n", es.string1, es.string2, es.distance, es.Inline())
+	}
+	output := fmt.Sprintf("Edit Script:\nFirst: %s\nSecond: %s\nDistance: %d\nOperations:\n",
+		es.string1, es.string2, es.distance)
+	for _, op := range es.operations {
+		output += fmt.Sprintf("%s %d/%d %q -> %q\n", op.Type, op.Index1, op.Index2, op.From, op.To)
+	}
+	return output
+}
@@ -0,0 +1,64 @@
+package strutil
+
+import "fmt"
+
+// EditScriptResultsMap is keyed by the comparison string and holds the most recent edit script for each.
+type EditScriptResultsMap map[string]*EditScriptResult
+
+// NewEditScriptResultsMap initializes and returns a new, empty EditScriptResultsMap.
+func NewEditScriptResultsMap() EditScriptResultsMap {
+	return make(map[string]*EditScriptResult)
+}
+
+// Add inserts or replaces the edit script stored for the result's second string.
+func (esm EditScriptResultsMap) Add(result EditScriptResult) {
+	esm[result.GetString2()] = &result
+}
+
+// Get retrieves the edit script for the given comparison string, or nil if none exists.
+func (esm EditScriptResultsMap) Get(compStr string) *EditScriptResult {
+	return esm[compStr]
+}
+
+// GetCopy creates and returns a copy of the EditScriptResultsMap with cloned operation slices.
+func (esm EditScriptResultsMap) GetCopy() EditScriptResultsMap {
+	cloned := NewEditScriptResultsMap()
+	for compStr, es := range esm {
+		if es != nil {
+			cloned[compStr] = NewEditScriptResult(es.string1, es.string2, es.distance, es.GetOperations())
+		}
+	}
+	return cloned
+}
+
+// EntryCount returns the number of non-nil edit scripts in the EditScriptResultsMap.
+func (esm EditScriptResultsMap) EntryCount() int {
+	count := 0
+	for _, es := range esm {
+		if es != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// IsMatch compares two EditScriptResultsMap objects for equality of every stored edit script.
+func (esm EditScriptResultsMap) IsMatch(other EditScriptResultsMap) bool {
+	if esm.EntryCount() != other.EntryCount() {
+		return false
+	}
+	for compStr, es := range esm {
+		if es != nil && !es.IsMatch(other[compStr]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Print outputs every stored edit script, optionally in verbose mode.
+func (esm EditScriptResultsMap) Print(v bool) EditScriptResultsMap {
+	for _, es := range esm {
+		fmt.Print(formatEditScriptResultOutput(es, v) + "\n")
+	}
+	return esm
+}
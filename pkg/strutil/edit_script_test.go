@@ -0,0 +1,131 @@
+package strutil
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hbollon/go-edlib"
+)
+
+func TestEditOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		s1       string
+		s2       string
+		distance int
+		ops      []EditOperation
+		inline   string
+	}{
+		{"EditOperationsKitten", "kitten", "sitting", 3, []EditOperation{
+			{EditSubstitute, 0, 0, "k", "s"},
+			{EditEqual, 1, 1, "itt", "itt"},
+			{EditSubstitute, 4, 4, "e", "i"},
+			{EditEqual, 5, 5, "n", "n"},
+			{EditInsert, 6, 6, "", "g"},
+		}, "[-k-]{+s+}itt[-e-]{+i+}n{+g+}"},
+		{"EditOperationsTranspose", "form", "from", 1, []EditOperation{
+			{EditEqual, 0, 0, "f", "f"},
+			{EditTranspose, 1, 1, "or", "ro"},
+			{EditEqual, 3, 3, "m", "m"},
+		}, "f[-or-]{+ro+}m"},
+		{"EditOperationsDelete", "naïve café", "naïve", 5, []EditOperation{
+			{EditEqual, 0, 0, "naïve", "naïve"},
+			{EditDelete, 5, 5, " café", ""},
+		}, "naïve[- café-]"},
+		{"EditOperationsInsert", "", "abc", 3, []EditOperation{
+			{EditInsert, 0, 0, "", "abc"},
+		}, "{+abc+}"},
+		{"EditOperationsEqual", "same", "same", 0, []EditOperation{
+			{EditEqual, 0, 0, "same", "same"},
+		}, "same"},
+		{"EditOperationsEmpty", "", "", 0, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := editOperations(tt.s1, tt.s2)
+			result := EditOperations(tt.s1, tt.s2)
+			if !helperResult.IsMatch(result) {
+				t.Fatalf("EditOperations(%q, %q) - helper and functional results differ", tt.s1, tt.s2)
+			}
+			if result.GetDistance() != tt.distance || !reflect.DeepEqual(result.GetOperations(), tt.ops) {
+				t.Errorf("EditOperations(%q, %q) = %d %v; want %d %v",
+					tt.s1, tt.s2, result.GetDistance(), result.GetOperations(), tt.distance, tt.ops)
+			}
+			if result.Inline() != tt.inline {
+				t.Errorf("EditOperations(%q, %q).Inline() = %q; want %q", tt.s1, tt.s2, result.Inline(), tt.inline)
+			}
+		})
+	}
+}
+
+func TestEditOperationsReconstruct(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	for i := 0; i < 300; i++ {
+		s1, s2 := randomEditString(r, 10), randomEditString(r, 10)
+		result := EditOperations(s1, s2)
+		if want := edlib.OSADamerauLevenshteinDistance(s1, s2); result.GetDistance() != want {
+			t.Errorf("EditOperations(%q, %q).GetDistance() = %d; want %d", s1, s2, result.GetDistance(), want)
+		}
+		var from, to []rune
+		for _, op := range result.GetOperations() {
+			if op.Index1 != len(from) || op.Index2 != len(to) {
+				t.Fatalf("EditOperations(%q, %q) - %v starts at the wrong position", s1, s2, op)
+			}
+			from, to = append(from, []rune(op.From)...), append(to, []rune(op.To)...)
+		}
+		if string(from) != s1 || string(to) != s2 {
+			t.Errorf("EditOperations(%q, %q) reconstructs %q -> %q", s1, s2, string(from), string(to))
+		}
+	}
+}
+
+func TestEditScriptRenderers(t *testing.T) {
+	kitten := EditOperations("kitten", "sitting")
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"UnifiedKitten", kitten.Unified("a", "b"),
+			"--- a\n+++ b\n@@ -1,4 +1,5 @@\n-k\n+s\n itt\n-e\n+i\n n\n+g\n"},
+		{"UnifiedInsertOnly", EditOperations("", "abc").Unified("old", "new"),
+			"--- old\n+++ new\n@@ -0,0 +1,1 @@\n+abc\n"},
+		{"UnifiedNewline", EditOperations("a\nb", "a\nc").Unified("a", "b"),
+			"--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\\n\n-b\n+c\n"},
+		{"UnifiedEqual", EditOperations("same", "same").Unified("a", "b"), ""},
+		{"ANSISubstitute", EditOperations("ab", "ac").ANSI(), "a\x1b[31mb\x1b[0m\x1b[32mc\x1b[0m"},
+		{"ANSIInsert", EditOperations("a", "ab").ANSI(), "a\x1b[32mb\x1b[0m"},
+		{"FormatConcise", formatEditScriptResultOutput(kitten, false),
+			"Edit Script (kitten/sitting): 3: [-k-]{+s+}itt[-e-]{+i+}n{+g+}\n"},
+		{"FormatVerbose", formatEditScriptResultOutput(EditOperations("ab", "b"), true),
+			"Edit Script:\nFirst: ab\nSecond: b\nDistance: 1\nOperations:\nDelete 0/0 \"a\" -> \"\"\n" +
+				"Equal 1/0 \"b\" -> \"b\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("%s = %q; want %q", tt.name, tt.result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStringBuilderEditOperations(t *testing.T) {
+	cm := New("kitten").EditOperations("sitting").EditOperations("mitten").GetComparisonManager()
+	if !cm.GetEditScriptResult("sitting").IsMatch(EditOperations("kitten", "sitting")) ||
+		!cm.GetEditScriptResult("mitten").IsMatch(EditOperations("kitten", "mitten")) {
+		t.Errorf("StringBuilder.EditOperations - edit scripts not stored in ComparisonManager")
+	}
+	if cm.GetEditScriptResult("missing") != nil {
+		t.Errorf("GetEditScriptResult(%q) = %v; want nil", "missing", cm.GetEditScriptResult("missing"))
+	}
+	copied := cm.CopyEditScriptResultsMap()
+	if copied.EntryCount() != 2 || !copied.IsMatch(cm.GetEditScriptResultsMap()) {
+		t.Errorf("CopyEditScriptResultsMap() = %v; want a matching copy", copied)
+	}
+	copied.Add(*EditOperations("kitten", "knitting"))
+	if copied.IsMatch(cm.GetEditScriptResultsMap()) || cm.GetEditScriptResultsMap().EntryCount() != 2 {
+		t.Errorf("CopyEditScriptResultsMap() - copy is not independent of the original")
+	}
+}
@@ -0,0 +1,179 @@
+package strutil
+
+import "github.com/bmj2728/utils/pkg/internal/errors"
+
+// The sentinel errors returned by this package. Match them with errors.Is; a ValidationError unwraps to the
+// sentinel of its rule.
+var (
+	// ErrInvalidEmail indicates that the input string is not in a valid email address format.
+	ErrInvalidEmail = errors.ErrInvalidEmail
+
+	// ErrInvalidURL indicates that the provided string is not a valid URL.
+	ErrInvalidURL = errors.ErrInvalidURL
+
+	// ErrInvalidUUID represents an error message indicating that the provided value is not a valid UUID format.
+	ErrInvalidUUID = errors.ErrInvalidUUID
+
+	// ErrInvalidLengthRange signifies that the length range provided is invalid,
+	// such as when min is greater than max or negative.
+	ErrInvalidLengthRange = errors.ErrInvalidLengthRange
+
+	// ErrInvalidLength indicates that the length of the string is outside the allowed range.
+	ErrInvalidLength = errors.ErrInvalidLength
+
+	// ErrInvalidEmpty indicates that the provided string is empty and does not meet the required validation criteria.
+	ErrInvalidEmpty = errors.ErrInvalidEmpty
+
+	// ErrInvalidEmptyAfterNormalization represents an error for strings that become empty after whitespace normalization.
+	ErrInvalidEmptyAfterNormalization = errors.ErrInvalidEmptyAfterNormalization
+
+	// ErrInvalidNotAlphaNumeric indicates that a string contains non-alphanumeric characters.
+	ErrInvalidNotAlphaNumeric = errors.ErrInvalidNotAlphaNumeric
+
+	// ErrInvalidNotNumeric indicates that the string contains characters that are not numeric.
+	ErrInvalidNotNumeric = errors.ErrInvalidNotNumeric
+
+	// ErrInvalidNotAlpha indicates that the string contains non-alphabetic characters.
+	ErrInvalidNotAlpha = errors.ErrInvalidNotAlpha
+
+	// ErrInvalidDomain indicates that the provided string is not a valid domain.
+	ErrInvalidDomain = errors.ErrInvalidDomain
+
+	// ErrLCSBacktrackFailure indicates a failure in the
+	// backtracking process to determine the longest common substring.
+	ErrLCSBacktrackFailure = errors.ErrLCSBacktrackFailure
+
+	// ErrLCSBacktrackAllFailure indicates an error occurred while attempting to
+	// backtrack for a slice of common substrings.
+	ErrLCSBacktrackAllFailure = errors.ErrLCSBacktrackAllFailure
+
+	// ErrLCSDiffFailure represents an error occurring during the generation of Longest Common Subsequence (LCS) diff.
+	ErrLCSDiffFailure = errors.ErrLCSDiffFailure
+
+	// ErrHammingDistanceFailure is returned when there is an error while
+	// calculating the Hamming distance between two strings.
+	ErrHammingDistanceFailure = errors.ErrHammingDistanceFailure
+
+	// ErrShingleLengthOutOfRange indicates that the shingle length provided is outside the acceptable range.
+	ErrShingleLengthOutOfRange = errors.ErrShingleLengthOutOfRange
+
+	// ErrUnknownError represents an unknown error, typically used when no specific error or score applies.
+	ErrUnknownError = errors.ErrUnknownError
+
+	// ErrNoSplitLengthSet indicates that no split length was provided or set for the operation.
+	ErrNoSplitLengthSet = errors.ErrNoSplitLengthSet
+
+	// ErrNotNormalizedUnicode indicates that a string is not in a normalized Unicode format.
+	ErrNotNormalizedUnicode = errors.ErrNotNormalizedUnicode
+
+	// ErrNilScore is returned when the expected score is nil but no specific error is associated.
+	ErrNilScore = errors.ErrNilScore
+
+	// ErrInvalidHistoryIndex indicates that the provided index is out of bounds for the history collection.
+	ErrInvalidHistoryIndex = errors.ErrInvalidHistoryIndex
+
+	// ErrHistoryNotInitialized indicates that the history has not been initialized prior to use.
+	ErrHistoryNotInitialized = errors.ErrHistoryNotInitialized
+
+	// ErrInvalidNgramMap indicates that the provided ngram map is invalid.
+	ErrInvalidNgramMap = errors.ErrInvalidNgramMap
+
+	// ErrPatternNotFound indicates that the specified pattern could not be found.
+	ErrPatternNotFound = errors.ErrPatternNotFound
+
+	// ErrDoesNotContainSubstring indicates that a string does not contain the expected substring.
+	ErrDoesNotContainSubstring = errors.ErrDoesNotContainSubstring
+
+	// ErrNoSubstrings indicates that a containment check was given no substrings to look for.
+	ErrNoSubstrings = errors.ErrNoSubstrings
+
+	// ErrMissingPrefix indicates that a required prefix is missing from a given string.
+	ErrMissingPrefix = errors.ErrMissingPrefix
+
+	// ErrMissingSuffix indicates that the required suffix is missing from a string.
+	ErrMissingSuffix = errors.ErrMissingSuffix
+
+	// ErrInvalidAlgorithm indicates that the provided algorithm is not a supported similarity algorithm.
+	ErrInvalidAlgorithm = errors.ErrInvalidAlgorithm
+
+	// ErrMatrixIndexOutOfRange indicates that a row or column index is outside the bounds of a similarity matrix.
+	ErrMatrixIndexOutOfRange = errors.ErrMatrixIndexOutOfRange
+
+	// ErrNilSimilarityMatrix indicates that a method was called on a nil SimilarityMatrixResult.
+	ErrNilSimilarityMatrix = errors.ErrNilSimilarityMatrix
+
+	// ErrNilFuzzyIndex indicates that a nil FuzzyIndex was provided for a lookup.
+	ErrNilFuzzyIndex = errors.ErrNilFuzzyIndex
+
+	// ErrInvalidFuzzyWeights indicates that algorithm weights for fuzzy matching or consensus scoring are empty,
+	// negative or sum to zero.
+	ErrInvalidFuzzyWeights = errors.ErrInvalidFuzzyWeights
+
+	// ErrNotSimilar indicates that a string is not similar enough to the string it was compared with.
+	ErrNotSimilar = errors.ErrNotSimilar
+
+	// ErrInvalidPhoneticAlgorithm indicates that the provided algorithm is not a supported phonetic algorithm.
+	ErrInvalidPhoneticAlgorithm = errors.ErrInvalidPhoneticAlgorithm
+
+	// ErrInvalidEditCosts indicates that edit distance costs are invalid; insert and delete costs must be at least 1
+	// and substitute and transpose costs must not be negative.
+	ErrInvalidEditCosts = errors.ErrInvalidEditCosts
+
+	// ErrUnsupportedEditStrategy indicates that an edit distance strategy is unknown or cannot be used with the
+	// given strings and costs.
+	ErrUnsupportedEditStrategy = errors.ErrUnsupportedEditStrategy
+
+	// ErrInvalidPatch indicates that a unified diff is malformed or its hunk line counts do not match their headers.
+	ErrInvalidPatch = errors.ErrInvalidPatch
+
+	// ErrPatchConflict indicates that a patch hunk's context and deleted lines could not be found in the text.
+	ErrPatchConflict = errors.ErrPatchConflict
+
+	// ErrInvalidHashCount indicates that the number of hash functions for a MinHash signature is less than 1.
+	ErrInvalidHashCount = errors.ErrInvalidHashCount
+
+	// ErrSignatureMismatch indicates that two signatures were built with different parameters and cannot be compared.
+	ErrSignatureMismatch = errors.ErrSignatureMismatch
+
+	// ErrInvalidSignature indicates that serialized signature data is malformed.
+	ErrInvalidSignature = errors.ErrInvalidSignature
+
+	// ErrInvalidLSHBands indicates that the band or row count of an LSH index is less than 1.
+	ErrInvalidLSHBands = errors.ErrInvalidLSHBands
+
+	// ErrInvalidCorpusOptions indicates that corpus options select unknown terms or an n-gram length less than 1.
+	ErrInvalidCorpusOptions = errors.ErrInvalidCorpusOptions
+
+	// ErrNilCorpus indicates that a nil Corpus was provided for a comparison.
+	ErrNilCorpus = errors.ErrNilCorpus
+
+	// ErrInvalidResultsData indicates that exported comparison manager results are malformed or name an unknown
+	// algorithm or comparison type.
+	ErrInvalidResultsData = errors.ErrInvalidResultsData
+
+	// ErrUnsafePath indicates that a user supplied path is empty, absolute, contains a NUL byte or escapes the
+	// directory it is joined to.
+	ErrUnsafePath = errors.ErrUnsafePath
+
+	// ErrMixedScript indicates that a string mixes characters of scripts that are not used together.
+	ErrMixedScript = errors.ErrMixedScript
+
+	// ErrRestrictionLevel indicates that a string mixes scripts more freely than the required restriction level
+	// allows.
+	ErrRestrictionLevel = errors.ErrRestrictionLevel
+
+	// ErrConfusable indicates that a string is visually confusable with a protected string.
+	ErrConfusable = errors.ErrConfusable
+
+	// ErrBidiControl indicates that a string contains bidirectional formatting characters.
+	ErrBidiControl = errors.ErrBidiControl
+
+	// ErrInvalidPattern indicates that serialized pattern data is malformed or contains a pattern that does not compile.
+	ErrInvalidPattern = errors.ErrInvalidPattern
+
+	// ErrSecretFound indicates that a string contains a credential such as an access token or private key.
+	ErrSecretFound = errors.ErrSecretFound
+
+	// ErrInvalidLoremSchema indicates that a lorem schema is nil or contains an unsupported field definition.
+	ErrInvalidLoremSchema = errors.ErrInvalidLoremSchema
+)
@@ -0,0 +1,215 @@
+package strutil
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultFuzzyIndexNgram is the n-gram length used by the inverted index of a FuzzyIndex.
+const DefaultFuzzyIndexNgram = 2
+
+// fuzzyIndexMinCandidates is the minimum number of n-gram candidates scored by TopK.
+const fuzzyIndexMinCandidates = 64
+
+// FuzzyIndex is a searchable index over a corpus of strings.
+// Levenshtein range queries are answered with a BK-tree; ranked queries for any Algorithm use an
+// inverted index of character n-grams (built with Shingle) to prune the candidates that are scored.
+// A FuzzyIndex is safe for concurrent use.
+type FuzzyIndex struct {
+	mu       sync.RWMutex
+	ngram    int
+	items    []string
+	ids      map[string]int
+	postings map[string][]int
+	short    []int
+	tree     *bkNode
+}
+
+// bkNode is a node of a BK-tree keyed by Levenshtein distance to its parent.
+type bkNode struct {
+	id       int
+	children map[int]*bkNode
+}
+
+// NewFuzzyIndex builds a FuzzyIndex over the provided items. Duplicate items are indexed once.
+func NewFuzzyIndex(items []string) *FuzzyIndex {
+	fi := &FuzzyIndex{
+		ngram:    DefaultFuzzyIndexNgram,
+		ids:      make(map[string]int, len(items)),
+		postings: make(map[string][]int),
+	}
+	for _, item := range items {
+		fi.add(item)
+	}
+	return fi
+}
+
+// Add inserts an item into the index. Adding an item that is already indexed has no effect.
+func (fi *FuzzyIndex) Add(item string) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.add(item)
+}
+
+// Len returns the number of unique items in the index.
+func (fi *FuzzyIndex) Len() int {
+	fi.mu.RLock()
+	defer fi.mu.RUnlock()
+	return len(fi.items)
+}
+
+// Contains reports whether the exact item is present in the index.
+func (fi *FuzzyIndex) Contains(item string) bool {
+	fi.mu.RLock()
+	defer fi.mu.RUnlock()
+	_, ok := fi.ids[item]
+	return ok
+}
+
+// Search returns every indexed item within maxDistance Levenshtein edits of the query as
+// Levenshtein SimilarityResults, ordered by ascending distance and then alphabetically.
+// Returns nil if maxDistance is negative or nothing matches.
+func (fi *FuzzyIndex) Search(query string, maxDistance int) []SimilarityResult {
+	if maxDistance < 0 {
+		return nil
+	}
+	fi.mu.RLock()
+	defer fi.mu.RUnlock()
+	if fi.tree == nil {
+		return nil
+	}
+	type match struct {
+		item     string
+		distance int
+	}
+	var matches []match
+	stack := []*bkNode{fi.tree}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		d := unitEditDistance(query, fi.items[node.id], false)
+		if d <= maxDistance {
+			matches = append(matches, match{fi.items[node.id], d})
+		}
+		// triangle inequality: only subtrees within [d-maxDistance, d+maxDistance] can match
+		for edge, child := range node.children {
+			if edge >= d-maxDistance && edge <= d+maxDistance {
+				stack = append(stack, child)
+			}
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].distance != matches[b].distance {
+			return matches[a].distance < matches[b].distance
+		}
+		return matches[a].item < matches[b].item
+	})
+	var results []SimilarityResult
+	for _, m := range matches {
+		results = append(results, *similarity(query, m.item, Levenshtein))
+	}
+	return results
+}
+
+// TopK returns up to k indexed items most similar to the query under the given algorithm,
+// ordered by descending score. Candidates are pruned to the items sharing the most n-grams
+// with the query, so results are approximate for algorithms that do not correlate with n-gram overlap.
+// Queries shorter than the n-gram length are scored against every item.
+// Items whose comparison fails (e.g. Hamming on unequal lengths) are skipped.
+// Returns nil if k is less than 1, the algorithm is invalid or nothing could be scored.
+func (fi *FuzzyIndex) TopK(query string, k int, algorithm Algorithm) []SimilarityResult {
+	if _, ok := AlgorithmTypeMap[algorithm]; !ok || k < 1 {
+		return nil
+	}
+	fi.mu.RLock()
+	defer fi.mu.RUnlock()
+	var results []SimilarityResult
+	for _, id := range fi.candidates(query, max(k*4, fuzzyIndexMinCandidates)) {
+		r := similarity(query, fi.items[id], algorithm)
+		if r.GetError() == nil {
+			results = append(results, *r)
+		}
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		return *results[a].score > *results[b].score
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// add inserts an item into the BK-tree and the n-gram inverted index. Callers must hold the write lock.
+func (fi *FuzzyIndex) add(item string) {
+	if _, ok := fi.ids[item]; ok {
+		return
+	}
+	id := len(fi.items)
+	fi.items = append(fi.items, item)
+	fi.ids[item] = id
+	grams := shingle(item, fi.ngram).GetShinglesMap()
+	if len(grams) == 0 {
+		fi.short = append(fi.short, id)
+	}
+	for gram := range grams {
+		fi.postings[gram] = append(fi.postings[gram], id)
+	}
+	if fi.tree == nil {
+		fi.tree = &bkNode{id: id}
+		return
+	}
+	node := fi.tree
+	for {
+		d := unitEditDistance(item, fi.items[node.id], false)
+		child, ok := node.children[d]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[int]*bkNode)
+			}
+			node.children[d] = &bkNode{id: id}
+			return
+		}
+		node = child
+	}
+}
+
+// candidates returns the ids of at most limit items sharing the most n-grams with the query,
+// in index order. Items too short to have n-grams are always considered and queries without
+// n-grams return every item. Callers must hold the read lock.
+func (fi *FuzzyIndex) candidates(query string, limit int) []int {
+	grams := shingle(query, fi.ngram).GetShinglesMap()
+	if len(grams) == 0 {
+		ids := make([]int, len(fi.items))
+		for i := range ids {
+			ids[i] = i
+		}
+		return ids
+	}
+	shared := make(map[int]int)
+	for _, id := range fi.short {
+		shared[id] = 0
+	}
+	for gram := range grams {
+		for _, id := range fi.postings[gram] {
+			shared[id]++
+		}
+	}
+	ids := make([]int, 0, len(shared))
+	for id := range shared {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		if shared[ids[a]] != shared[ids[b]] {
+			return shared[ids[a]] > shared[ids[b]]
+		}
+		return ids[a] < ids[b]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	sort.Ints(ids)
+	return ids
+}
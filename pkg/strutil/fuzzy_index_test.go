@@ -0,0 +1,145 @@
+package strutil
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+var fuzzyItems = []string{"kitten", "sitting", "mitten", "kitchen", "smitten", "bitten", "a", "apple", "kitten"}
+
+func TestFuzzyIndexSearch(t *testing.T) {
+	fi := NewFuzzyIndex(fuzzyItems)
+	tests := []struct {
+		name        string
+		query       string
+		maxDistance int
+		expected    []string
+	}{
+		{"SearchExact", "kitten", 0, []string{"kitten"}},
+		{"SearchOne", "kitten", 1, []string{"kitten", "bitten", "mitten"}},
+		{"SearchTwo", "kitten", 2, []string{"kitten", "bitten", "mitten", "kitchen", "smitten"}},
+		{"SearchShort", "b", 1, []string{"a"}},
+		{"SearchNone", "zzzzzzzz", 2, nil},
+		{"SearchNegative", "kitten", -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := fi.Search(tt.query, tt.maxDistance)
+			if len(results) != len(tt.expected) {
+				t.Fatalf("Search(%q, %d) - expected %v, got %v", tt.query, tt.maxDistance, tt.expected, results)
+			}
+			for i, r := range results {
+				if r.GetString2() != tt.expected[i] || r.GetAlgorithm() != Levenshtein {
+					t.Errorf("Search(%q, %d) - expected %q at %d, got %q", tt.query, tt.maxDistance,
+						tt.expected[i], i, r.GetString2())
+				}
+			}
+		})
+	}
+	if NewFuzzyIndex(nil).Search("kitten", 3) != nil {
+		t.Errorf("Search - expected nil results for empty index")
+	}
+}
+
+func TestFuzzyIndexTopK(t *testing.T) {
+	fi := NewFuzzyIndex(fuzzyItems)
+	tests := []struct {
+		name      string
+		query     string
+		k         int
+		algorithm Algorithm
+		expected  int
+		best      string
+	}{
+		{"TopKLevenshtein", "kiten", 2, Levenshtein, 2, "kitten"},
+		{"TopKJaroWinkler", "smiten", 1, JaroWinkler, 1, "smitten"},
+		{"TopKHammingSkipsUnequal", "kittem", 10, Hamming, 3, "kitten"},
+		{"TopKShortQuery", "a", 20, Levenshtein, 8, "a"},
+		{"TopKZero", "kitten", 0, Levenshtein, 0, ""},
+		{"TopKInvalidAlgorithm", "kitten", 2, Algorithm(99), 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := fi.TopK(tt.query, tt.k, tt.algorithm)
+			if len(results) != tt.expected {
+				t.Fatalf("TopK(%q, %d) - expected %d results, got %v", tt.query, tt.k, tt.expected, results)
+			}
+			if tt.expected == 0 {
+				return
+			}
+			if results[0].GetString2() != tt.best {
+				t.Errorf("TopK(%q, %d) - expected best %q, got %q", tt.query, tt.k, tt.best, results[0].GetString2())
+			}
+			var last float32 = 2
+			for _, r := range results {
+				score, err := r.GetScore()
+				if err != nil || score > last {
+					t.Errorf("TopK(%q, %d) - results not ordered: %v", tt.query, tt.k, results)
+				}
+				last = score
+			}
+		})
+	}
+}
+
+func TestFuzzyIndexAdd(t *testing.T) {
+	fi := NewFuzzyIndex(fuzzyItems)
+	if fi.Len() != len(fuzzyItems)-1 {
+		t.Errorf("NewFuzzyIndex - expected duplicates to be indexed once, got %d items", fi.Len())
+	}
+	if fi.Contains("mittens") {
+		t.Errorf("Contains - unexpected item")
+	}
+	fi.Add("mittens")
+	fi.Add("mittens")
+	if !fi.Contains("mittens") || fi.Len() != len(fuzzyItems) {
+		t.Errorf("Add - expected mittens to be indexed once, got %d items", fi.Len())
+	}
+	if r := fi.Search("mittens", 0); len(r) != 1 || r[0].GetString2() != "mittens" {
+		t.Errorf("Add - added item not searchable: %v", r)
+	}
+}
+
+func TestFuzzyIndexConcurrent(t *testing.T) {
+	fi := NewFuzzyIndex(fuzzyItems)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			fi.Search("kitten", 2)
+			fi.TopK("kitten", 3, Jaro)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			fi.Add("kitten" + string(rune('a'+i)))
+		}(i)
+	}
+	wg.Wait()
+	if fi.Len() != len(fuzzyItems)+7 {
+		t.Errorf("FuzzyIndex - concurrent adds lost items, got %d", fi.Len())
+	}
+}
+
+func TestFuzzyIndexBuilder(t *testing.T) {
+	fi := NewFuzzyIndex(fuzzyItems)
+	sb := New("kitten").FuzzySearch(fi, 1).FuzzyTopK(fi, 2, JaroWinkler)
+	if sb.Error() != nil {
+		t.Fatalf("StringBuilder.FuzzySearch - unexpected error %v", sb.Error())
+	}
+	cm := sb.GetComparisonManager()
+	if len(cm.GetSimilarityResultsByType(Levenshtein)) != 3 || cm.GetSimilarityResult(Levenshtein, "mitten") == nil {
+		t.Errorf("StringBuilder.FuzzySearch - unexpected results %v", cm.GetSimilarityResultsByType(Levenshtein))
+	}
+	if len(cm.GetSimilarityResultsByType(JaroWinkler)) != 2 {
+		t.Errorf("StringBuilder.FuzzyTopK - unexpected results %v", cm.GetSimilarityResultsByType(JaroWinkler))
+	}
+	for _, sb := range []*StringBuilder{New("kitten").FuzzySearch(nil, 1), New("kitten").FuzzyTopK(nil, 1, Jaro)} {
+		if !errors.Is(sb.Error(), errors2.ErrNilFuzzyIndex) || sb.String() != "kitten" {
+			t.Errorf("StringBuilder - expected non-fatal nil index error, got %v", sb.Error())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package strutil
+
+// DefaultFuzzyThreshold is the combined score at or above which FuzzyEqual considers two strings equal
+// when using NewFuzzyOptions.
+const DefaultFuzzyThreshold float32 = 0.87
+
+// FuzzyOptions controls how two strings are normalized and scored by FuzzyEqual, FuzzyScore and BestMatch.
+// The combined score is the weighted mean of the similarity returned by each algorithm in Weights.
+// Algorithms that fail for a pair (e.g. Hamming on unequal lengths) contribute a score of 0.
+type FuzzyOptions struct {
+	CaseFold            bool                  // compare using Unicode case folding
+	NormalizeDiacritics bool                  // strip diacritical marks before comparing
+	CollapseWhitespace  bool                  // trim and collapse runs of whitespace to a single space
+	Weights             map[Algorithm]float32 // relative weight of each algorithm in the combined score
+	Threshold           float32               // minimum combined score for two strings to be considered equal
+}
+
+// NewFuzzyOptions returns FuzzyOptions populated with sensible defaults for matching names:
+// case folding, diacritic and whitespace normalization enabled, Jaro-Winkler scoring and
+// a threshold of DefaultFuzzyThreshold.
+func NewFuzzyOptions() FuzzyOptions {
+	return FuzzyOptions{
+		CaseFold:            true,
+		NormalizeDiacritics: true,
+		CollapseWhitespace:  true,
+		Weights:             map[Algorithm]float32{JaroWinkler: 1},
+		Threshold:           DefaultFuzzyThreshold,
+	}
+}
+
+// FuzzyScore normalizes both strings as configured by opts and returns their weighted combined similarity.
+// Returns an error if Weights is empty, contains a negative weight, sums to zero or contains an invalid algorithm.
+func FuzzyScore(a, b string, opts FuzzyOptions) (float32, error) {
+	return fuzzyScore(a, b, opts)
+}
+
+// FuzzyEqual reports whether the combined similarity of a and b is at least opts.Threshold.
+// Returns false if the options are invalid.
+func FuzzyEqual(a, b string, opts FuzzyOptions) bool {
+	return fuzzyEqual(a, b, opts)
+}
+
+// BestMatch returns the candidate most similar to the query using NewFuzzyOptions, along with its score.
+// ok is true only if the best score meets DefaultFuzzyThreshold. Ties keep the earliest candidate.
+func BestMatch(query string, candidates []string) (match string, score float32, ok bool) {
+	return bestMatch(query, candidates, NewFuzzyOptions())
+}
+
+// BestMatchWith returns the candidate most similar to the query using the provided options, along with its score.
+// ok is true only if the best score meets opts.Threshold. Ties keep the earliest candidate.
+func BestMatchWith(query string, candidates []string, opts FuzzyOptions) (match string, score float32, ok bool) {
+	return bestMatch(query, candidates, opts)
+}
@@ -0,0 +1,93 @@
+package strutil
+
+import (
+	"golang.org/x/text/cases"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// fuzzyNormalize applies the normalization steps enabled in opts to s.
+func fuzzyNormalize(s string, opts FuzzyOptions) string {
+	if opts.NormalizeDiacritics {
+		s = normalizeDiacritics(s)
+	}
+	if opts.CaseFold {
+		s = cases.Fold().String(s)
+	}
+	if opts.CollapseWhitespace {
+		s = collapseWhitespace(normalizeWhitespace(s, ' '))
+	}
+	return s
+}
+
+// fuzzyWeights validates algorithm weights and returns their sum.
+func fuzzyWeights(weights map[Algorithm]float32) (float32, error) {
+	var total float32
+	for algo, weight := range weights {
+		if _, ok := AlgorithmTypeMap[algo]; !ok {
+			return 0, errors.ErrInvalidAlgorithm
+		}
+		if weight < 0 {
+			return 0, errors.ErrInvalidFuzzyWeights
+		}
+		total += weight
+	}
+	if total <= 0 {
+		return 0, errors.ErrInvalidFuzzyWeights
+	}
+	return total, nil
+}
+
+// fuzzyScore normalizes a and b and returns the weighted mean of their similarity under each algorithm in opts.
+func fuzzyScore(a, b string, opts FuzzyOptions) (float32, error) {
+	total, err := fuzzyWeights(opts.Weights)
+	if err != nil {
+		return 0, err
+	}
+	return fuzzyScoreNormalized(fuzzyNormalize(a, opts), fuzzyNormalize(b, opts), opts, total), nil
+}
+
+// fuzzyScoreNormalized computes the weighted mean similarity of already normalized strings.
+// total must be the validated sum of opts.Weights.
+func fuzzyScoreNormalized(a, b string, opts FuzzyOptions, total float32) float32 {
+	var sum float32
+	for algo, weight := range opts.Weights {
+		if weight == 0 {
+			continue
+		}
+		if score, err := similarity(a, b, algo).GetScore(); err == nil {
+			sum += score * weight
+		}
+	}
+	return sum / total
+}
+
+// fuzzyEqual reports whether the combined score of a and b meets the threshold in opts.
+func fuzzyEqual(a, b string, opts FuzzyOptions) bool {
+	score, err := fuzzyScore(a, b, opts)
+	return err == nil && score >= opts.Threshold
+}
+
+// bestMatch scores every candidate against the query and returns the highest scoring one.
+// The query is normalized once; ties keep the earliest candidate.
+func bestMatch(query string, candidates []string, opts FuzzyOptions) (string, float32, bool) {
+	total, err := fuzzyWeights(opts.Weights)
+	if err != nil || len(candidates) == 0 {
+		return "", 0, false
+	}
+	query = fuzzyNormalize(query, opts)
+	best, bestScore := "", float32(-1)
+	for _, candidate := range candidates {
+		score := fuzzyScoreNormalized(query, fuzzyNormalize(candidate, opts), opts, total)
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, bestScore, bestScore >= opts.Threshold
+}
+
+// isSimilarTo reports whether the similarity of a and b under the given algorithm is at least threshold.
+func isSimilarTo(a, b string, algorithm Algorithm, threshold float32) bool {
+	score, err := similarity(a, b, algorithm).GetScore()
+	return err == nil && score >= threshold
+}
@@ -0,0 +1,129 @@
+package strutil
+
+import (
+	"errors"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+func TestFuzzyEqual(t *testing.T) {
+	weighted := NewFuzzyOptions()
+	weighted.Weights = map[Algorithm]float32{JaroWinkler: 2, Levenshtein: 1}
+	weighted.Threshold = 0.8
+	raw := FuzzyOptions{Weights: map[Algorithm]float32{Levenshtein: 1}, Threshold: 1}
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		opts     FuzzyOptions
+		expected bool
+		err      error
+	}{
+		{"FuzzyEqualIdentical", "Acme Corp", "Acme Corp", NewFuzzyOptions(), true, nil},
+		{"FuzzyEqualCaseFold", "ACME CORP", "acme corp", NewFuzzyOptions(), true, nil},
+		{"FuzzyEqualDiacritics", "José Müller", "Jose Muller", NewFuzzyOptions(), true, nil},
+		{"FuzzyEqualWhitespace", "  Jane \t Doe ", "Jane Doe", NewFuzzyOptions(), true, nil},
+		{"FuzzyEqualTypo", "Jonathan Smith", "Jonathon Smith", NewFuzzyOptions(), true, nil},
+		{"FuzzyEqualDifferent", "Jonathan Smith", "Maria Garcia", NewFuzzyOptions(), false, nil},
+		{"FuzzyEqualWeighted", "Katherine", "Catherine", weighted, true, nil},
+		{"FuzzyEqualNoNormalization", "ACME", "acme", raw, false, nil},
+		{"FuzzyEqualNoWeights", "a", "a", FuzzyOptions{}, false, errors2.ErrInvalidFuzzyWeights},
+		{"FuzzyEqualNegativeWeight", "a", "a",
+			FuzzyOptions{Weights: map[Algorithm]float32{Jaro: -1}}, false, errors2.ErrInvalidFuzzyWeights},
+		{"FuzzyEqualInvalidAlgorithm", "a", "a",
+			FuzzyOptions{Weights: map[Algorithm]float32{Algorithm(99): 1}}, false, errors2.ErrInvalidAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := fuzzyEqual(tt.a, tt.b, tt.opts)
+			result := FuzzyEqual(tt.a, tt.b, tt.opts)
+			builderResult := New(tt.a).IsFuzzyEqual(tt.b, tt.opts)
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("FuzzyEqual(%q, %q) = %v/%v/%v; want %v", tt.a, tt.b,
+					helperResult, result, builderResult, tt.expected)
+			}
+			score, err := FuzzyScore(tt.a, tt.b, tt.opts)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("FuzzyScore(%q, %q) - expected error %v, got %v", tt.a, tt.b, tt.err, err)
+			}
+			if err == nil && (score < 0 || score > 1) {
+				t.Errorf("FuzzyScore(%q, %q) - score out of range: %f", tt.a, tt.b, score)
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreHammingFailure(t *testing.T) {
+	opts := FuzzyOptions{Weights: map[Algorithm]float32{Hamming: 1, Levenshtein: 1}}
+	score, err := FuzzyScore("abc", "abcd", opts)
+	lev, _ := similarity("abc", "abcd", Levenshtein).GetScore()
+	if err != nil || score != lev/2 {
+		t.Errorf("FuzzyScore - expected failing algorithm to contribute 0, got %f (%v)", score, err)
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	candidates := []string{"Jonathan Smith", "Maria García", "Jon Smyth", "Acme Corporation"}
+	tests := []struct {
+		name       string
+		query      string
+		candidates []string
+		expected   string
+		ok         bool
+	}{
+		{"BestMatchTypo", "jonathon smith", candidates, "Jonathan Smith", true},
+		{"BestMatchDiacritics", "MARIA GARCIA", candidates, "Maria García", true},
+		{"BestMatchBelowThreshold", "Zebra", candidates, "", false},
+		{"BestMatchEmpty", "Jonathan", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperMatch, helperScore, helperOK := bestMatch(tt.query, tt.candidates, NewFuzzyOptions())
+			match, score, ok := BestMatch(tt.query, tt.candidates)
+			if helperMatch != match || helperScore != score || helperOK != ok {
+				t.Errorf("BestMatch(%q) - helper and functional results differ", tt.query)
+			}
+			if ok != tt.ok || (tt.expected != "" && match != tt.expected) {
+				t.Errorf("BestMatch(%q) = %q, %f, %v; want %q, %v", tt.query, match, score, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+	opts := NewFuzzyOptions()
+	opts.Threshold = 0
+	if match, _, ok := BestMatchWith("Zebra", candidates, opts); !ok || match == "" {
+		t.Errorf("BestMatchWith - expected a match with zero threshold")
+	}
+	if _, _, ok := BestMatchWith("Jonathan", candidates, FuzzyOptions{}); ok {
+		t.Errorf("BestMatchWith - expected no match with invalid options")
+	}
+}
+
+func TestRequireSimilarTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		other     string
+		algorithm Algorithm
+		threshold float32
+		err       error
+	}{
+		{"SimilarToJaroWinkler", "martha", "marhta", JaroWinkler, 0.9, nil},
+		{"SimilarToLevenshtein", "kitten", "sitting", Levenshtein, 0.9, errors2.ErrNotSimilar},
+		{"SimilarToHammingUnequal", "abc", "abcd", Hamming, 0, errors2.ErrNotSimilar},
+		{"SimilarToInvalidAlgorithm", "abc", "abc", Algorithm(99), 0, errors2.ErrInvalidAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := tt.err == nil
+			if isSimilarTo(tt.input, tt.other, tt.algorithm, tt.threshold) != expected ||
+				New(tt.input).IsSimilarTo(tt.other, tt.algorithm, tt.threshold) != expected {
+				t.Errorf("IsSimilarTo(%q, %q) - expected %v", tt.input, tt.other, expected)
+			}
+			sb := New(tt.input).RequireSimilarTo(tt.other, tt.algorithm, tt.threshold)
+			if !errors.Is(sb.Error(), tt.err) {
+				t.Errorf("RequireSimilarTo(%q, %q) - expected error %v, got %v", tt.input, tt.other, tt.err, sb.Error())
+			}
+		})
+	}
+}
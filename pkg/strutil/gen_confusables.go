@@ -0,0 +1,133 @@
+//go:build ignore
+
+// gen_confusables generates confusables_table.go from confusables.txt, the UTS #39 confusable mapping data
+// published by the Unicode Consortium.
+//
+// Usage:
+//
+//	go run gen_confusables.go [-version 15.1.0] [-file confusables.txt]
+//
+// Without -file the data for -version is downloaded from unicode.org.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "15.1.0", "Unicode version of the security data")
+	file    = flag.String("file", "", "read confusables.txt from this file instead of unicode.org")
+	output  = flag.String("output", "confusables_table.go", "file to write the table to")
+)
+
+func main() {
+	flag.Parse()
+	data, err := open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer data.Close()
+
+	table, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// open returns the confusables.txt named by -file, or downloads the one for -version.
+func open() (io.ReadCloser, error) {
+	if *file != "" {
+		return os.Open(*file)
+	}
+	url := "https://www.unicode.org/Public/security/" + *version + "/confusables.txt"
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// parse reads the mappings of confusables.txt. Each data line has the form
+// "source ; prototype ; type # comment" with code points written as space separated hex numbers.
+func parse(r io.Reader) (map[rune]string, error) {
+	table := make(map[rune]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(strings.TrimPrefix(text, "\uFEFF"))
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, ";")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected source and prototype fields", line)
+		}
+		source, err := codePoints(fields[0])
+		if err != nil || len(source) != 1 {
+			return nil, fmt.Errorf("line %d: invalid source %q", line, fields[0])
+		}
+		prototype, err := codePoints(fields[1])
+		if err != nil || len(prototype) == 0 {
+			return nil, fmt.Errorf("line %d: invalid prototype %q", line, fields[1])
+		}
+		table[source[0]] = string(prototype)
+	}
+	return table, scanner.Err()
+}
+
+// codePoints parses a list of space separated hex code points.
+func codePoints(s string) ([]rune, error) {
+	var runes []rune
+	for _, field := range strings.Fields(s) {
+		cp, err := strconv.ParseUint(field, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		runes = append(runes, rune(cp))
+	}
+	return runes, nil
+}
+
+// generate renders table as gofmt-ed Go source.
+func generate(table map[rune]string) ([]byte, error) {
+	keys := make([]rune, 0, len(table))
+	for r := range table {
+		keys = append(keys, r)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by \"go run gen_confusables.go\"; DO NOT EDIT.\n\n")
+	b.WriteString("package strutil\n\n")
+	b.WriteString("// ConfusablesVersion is the Unicode version of the UTS #39 data behind Skeleton and AreConfusable.\n")
+	fmt.Fprintf(&b, "const ConfusablesVersion = %q\n\n", *version)
+	b.WriteString("// confusables maps each code point of confusables.txt to its prototype.\n")
+	b.WriteString("var confusables = map[rune]string{\n")
+	for _, r := range keys {
+		fmt.Fprintf(&b, "0x%04X: %+q,\n", r, table[r])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
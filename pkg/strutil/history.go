@@ -53,6 +53,20 @@ func (sh *StringHistory) GetByIndex(index int) (string, error) {
 	return (*sh).transforms[index], nil
 }
 
+// Diff compares the history entries at indexes i and j using the given options and returns a TextDiffResult.
+// Returns an error if either index is out of bounds.
+func (sh *StringHistory) Diff(i, j int, opts DiffOptions) (*TextDiffResult, error) {
+	s1, err := sh.GetByIndex(i)
+	if err != nil {
+		return nil, err
+	}
+	s2, err := sh.GetByIndex(j)
+	if err != nil {
+		return nil, err
+	}
+	return textDiff(s1, s2, opts), nil
+}
+
 // formatHistoryOutput formats the StringHistory into a string representation,
 // with verbosity controlled by the verbose flag.
 func formatHistoryOutput(history StringHistory, verbose bool) string {
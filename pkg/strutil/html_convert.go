@@ -0,0 +1,58 @@
+package strutil
+
+// LinkStyle selects how HTMLToText renders the URL of a link.
+type LinkStyle int
+
+// String returns the string representation of the LinkStyle using LinkStyleMap.
+func (l LinkStyle) String() string {
+	return LinkStyleMap[l]
+}
+
+// LinksInline renders a link as its text followed by the URL in parentheses, e.g. "docs (https://x.io/docs)".
+// LinksFootnotes renders a link as its text followed by a footnote number, e.g. "docs[1]", and lists the
+// numbered URLs at the end of the text.
+// LinksTextOnly renders only the text of a link.
+const (
+	LinksInline LinkStyle = iota
+	LinksFootnotes
+	LinksTextOnly
+)
+
+// LinkStyleMap maps LinkStyle constants to their corresponding string representations.
+var LinkStyleMap = map[LinkStyle]string{
+	LinksInline:    "Inline",
+	LinksFootnotes: "Footnotes",
+	LinksTextOnly:  "Text Only",
+}
+
+// HTMLToTextOptions configures how HTMLToText renders HTML as plain text.
+type HTMLToTextOptions struct {
+	// Links selects how the URLs of links are rendered. Links whose text is their URL, and links without a
+	// usable URL such as fragment or javascript: links, always render as their text.
+	Links LinkStyle
+	// ListBullet prefixes the items of unordered lists; ordered list items are numbered.
+	ListBullet string
+}
+
+// NewHTMLToTextOptions returns HTMLToTextOptions rendering links inline and list items with "- ".
+func NewHTMLToTextOptions() HTMLToTextOptions {
+	return HTMLToTextOptions{
+		Links:      LinksInline,
+		ListBullet: "- ",
+	}
+}
+
+// HTMLToText renders HTML as readable plain text. Entities are decoded, whitespace is collapsed outside pre
+// elements, block elements start new lines or paragraphs, list items are bulleted or numbered and indented when
+// nested, blockquotes are prefixed with "> ", tables are rendered as aligned columns, and script, style and head
+// content is dropped.
+func HTMLToText(s string, opts HTMLToTextOptions) string {
+	return htmlToText(s, opts)
+}
+
+// HTMLToMarkdown renders HTML as CommonMark with GitHub-style tables and strikethrough. Headings, emphasis,
+// links, images, lists, blockquotes, code and horizontal rules are converted, text is escaped so it is not read as
+// Markdown, and script, style and head content is dropped.
+func HTMLToMarkdown(s string) string {
+	return htmlToMarkdown(s)
+}
@@ -0,0 +1,21 @@
+package strutil
+
+// HTMLToText renders the StringBuilder's value as readable plain text using opts.
+// See HTMLToText for how elements are rendered.
+func (sb *StringBuilder) HTMLToText(opts HTMLToTextOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(htmlToText(sb.value, opts))
+	return sb
+}
+
+// HTMLToMarkdown renders the StringBuilder's value as Markdown.
+// See HTMLToMarkdown for how elements are rendered.
+func (sb *StringBuilder) HTMLToMarkdown() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(htmlToMarkdown(sb.value))
+	return sb
+}
@@ -0,0 +1,596 @@
+package strutil
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// htmlSkippedElements are the elements whose content is never rendered.
+var htmlSkippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true, "iframe": true,
+	"object": true, "svg": true, "math": true, "canvas": true,
+}
+
+// htmlBlockBreaks maps block elements to the number of line breaks separating them from the surrounding
+// content: two for paragraph-like elements and one for line-like elements.
+var htmlBlockBreaks = map[string]int{
+	"p": 2, "h1": 2, "h2": 2, "h3": 2, "h4": 2, "h5": 2, "h6": 2, "dl": 2, "figure": 2, "address": 2,
+	"div": 1, "section": 1, "article": 1, "header": 1, "footer": 1, "nav": 1, "aside": 1, "main": 1,
+	"form": 1, "fieldset": 1, "details": 1, "summary": 1, "dt": 1, "dd": 1, "figcaption": 1, "li": 1,
+	"tr": 1, "caption": 1,
+}
+
+// markdownInlineMarkers maps inline elements to the Markdown markers wrapping their content.
+var markdownInlineMarkers = map[string]string{
+	"strong": "**", "b": "**", "em": "_", "i": "_", "del": "~~", "s": "~~", "strike": "~~",
+}
+
+// unsafeURLSchemes are the URL schemes that are never rendered as links.
+var unsafeURLSchemes = []string{"javascript:", "vbscript:", "data:"}
+
+// markdownEscaper escapes the characters Markdown reads as inline markup.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`,
+)
+
+// markdownBlockMarker matches words Markdown reads as a heading, list, quote or rule marker at the start of a
+// line.
+var markdownBlockMarker = regexp.MustCompile(`^(#{1,6}|[-+=]+|>.*|\d{1,9}[.)])$`)
+
+// htmlRenderer renders a parsed HTML tree as plain text or Markdown. Whitespace is collapsed as a browser
+// would, and the breaks requested by block elements are only written once more content follows, so nested
+// blocks never produce runs of blank lines. Lists, quotes, tables and inline markup are rendered by child
+// renderers whose output is then indented, prefixed or wrapped.
+type htmlRenderer struct {
+	markdown     bool
+	opts         HTMLToTextOptions
+	footnotes    *[]string // link URLs numbered by LinksFootnotes, shared with child renderers
+	out          strings.Builder
+	leadingSpace bool // whitespace preceded the first output
+	space        bool // whitespace is pending before the next output
+	breaks       int  // line breaks pending before the next output
+	pre          int  // depth of pre elements; whitespace is kept inside them
+	code         int  // depth of code elements; Markdown is not escaped inside them
+	listDepth    int
+}
+
+// htmlToText renders s as plain text using opts.
+func htmlToText(s string, opts HTMLToTextOptions) string {
+	return renderHTML(s, false, opts)
+}
+
+// htmlToMarkdown renders s as Markdown.
+func htmlToMarkdown(s string) string {
+	return renderHTML(s, true, NewHTMLToTextOptions())
+}
+
+// renderHTML parses s and renders it as plain text or Markdown, appending any link footnotes.
+// Falls back to removing the tags if s cannot be parsed.
+func renderHTML(s string, markdown bool, opts HTMLToTextOptions) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return removeHTML(s, true)
+	}
+	r := &htmlRenderer{markdown: markdown, opts: opts, footnotes: &[]string{}}
+	r.children(doc)
+	text := tidyRenderedLines(r.out.String())
+	if len(*r.footnotes) == 0 {
+		return text
+	}
+	notes := make([]string, 0, len(*r.footnotes))
+	for i, url := range *r.footnotes {
+		notes = append(notes, "["+strconv.Itoa(i+1)+"] "+url)
+	}
+	return text + "\n\n" + strings.Join(notes, "\n")
+}
+
+// tidyRenderedLines removes trailing spaces from every line and leading and trailing line breaks from s.
+func tidyRenderedLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// child returns a renderer for nested content sharing the mode, options, footnotes and nesting of r.
+func (r *htmlRenderer) child() *htmlRenderer {
+	return &htmlRenderer{
+		markdown:  r.markdown,
+		opts:      r.opts,
+		footnotes: r.footnotes,
+		pre:       r.pre,
+		code:      r.code,
+		listDepth: r.listDepth,
+	}
+}
+
+// node renders n and its descendants.
+func (r *htmlRenderer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+	case html.ElementNode:
+		r.element(n)
+	case html.DocumentNode:
+		r.children(n)
+	default:
+	}
+}
+
+// children renders the children of n.
+func (r *htmlRenderer) children(n *html.Node) {
+	for c := range n.ChildNodes() {
+		r.node(c)
+	}
+}
+
+// element renders an element, dispatching the elements that need more than line breaks to their renderers.
+func (r *htmlRenderer) element(n *html.Node) {
+	if htmlSkippedElements[n.Data] || r.blockElement(n) || r.inlineElement(n) {
+		return
+	}
+	breaks := htmlBlockBreaks[n.Data]
+	r.block(breaks)
+	r.children(n)
+	r.block(breaks)
+}
+
+// blockElement renders lists, quotes, preformatted text, tables, rules and Markdown headings, reporting whether
+// n was one of them.
+func (r *htmlRenderer) blockElement(n *html.Node) bool {
+	switch n.Data {
+	case "ul", "ol":
+		r.list(n)
+	case "blockquote":
+		r.blockquote(n)
+	case "pre":
+		r.preformatted(n)
+	case "table":
+		r.table(n)
+	case "hr":
+		r.block(2)
+		r.write("---")
+		r.block(2)
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		if !r.markdown {
+			return false
+		}
+		marker := strings.Repeat("#", int(n.Data[1]-'0')) + " "
+		r.block(2)
+		r.writeInline(r.child(), n, func(text string) string { return marker + text })
+		r.block(2)
+	default:
+		return false
+	}
+	return true
+}
+
+// inlineElement renders line breaks, links, images and Markdown inline markup, reporting whether n was one of
+// them.
+func (r *htmlRenderer) inlineElement(n *html.Node) bool {
+	switch n.Data {
+	case "br":
+		if r.markdown && r.pre == 0 {
+			r.write("\\\n")
+		} else {
+			r.write("\n")
+		}
+	case "a":
+		href := strings.TrimSpace(htmlAttr(n, "href"))
+		r.writeInline(r.child(), n, func(text string) string { return r.formatLink(text, href) })
+	case "img":
+		r.image(n)
+	case "code":
+		if !r.markdown || r.pre > 0 || r.code > 0 {
+			return false
+		}
+		c := r.child()
+		c.code++
+		r.writeInline(c, n, markdownCode)
+	default:
+		marker, ok := markdownInlineMarkers[n.Data]
+		if !ok || !r.markdown || r.pre > 0 || r.code > 0 {
+			return false
+		}
+		r.writeInline(r.child(), n, func(text string) string { return marker + text + marker })
+	}
+	return true
+}
+
+// text renders a text node, collapsing its whitespace outside pre elements.
+func (r *htmlRenderer) text(s string) {
+	if r.pre > 0 {
+		r.write(s)
+		return
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	if unicode.IsSpace(first) {
+		r.markSpace()
+	}
+	for i, word := range strings.Fields(s) {
+		if i > 0 {
+			r.space = true
+		}
+		r.write(r.escape(word))
+	}
+	last, _ := utf8.DecodeLastRuneInString(s)
+	if unicode.IsSpace(last) {
+		r.markSpace()
+	}
+}
+
+// escape escapes a word of text so that it is not read as Markdown.
+func (r *htmlRenderer) escape(word string) string {
+	if !r.markdown || r.code > 0 {
+		return word
+	}
+	word = markdownEscaper.Replace(word)
+	if !r.atLineStart() || !markdownBlockMarker.MatchString(word) {
+		return word
+	}
+	if word[0] >= '0' && word[0] <= '9' {
+		return word[:len(word)-1] + `\` + word[len(word)-1:]
+	}
+	return `\` + word
+}
+
+// atLineStart reports whether the next output starts a line.
+func (r *htmlRenderer) atLineStart() bool {
+	return r.breaks > 0 || r.out.Len() == 0 || strings.HasSuffix(r.out.String(), "\n")
+}
+
+// markSpace records whitespace before the next output.
+func (r *htmlRenderer) markSpace() {
+	if r.out.Len() == 0 {
+		r.leadingSpace = true
+	} else {
+		r.space = true
+	}
+}
+
+// block requests at least breaks line breaks before the next output.
+func (r *htmlRenderer) block(breaks int) {
+	r.breaks = max(r.breaks, breaks)
+}
+
+// write outputs s after any pending line breaks or space. Breaks and spaces before the first output are dropped.
+func (r *htmlRenderer) write(s string) {
+	if r.out.Len() > 0 {
+		written := r.out.String()
+		if r.breaks > 0 {
+			trailing := len(written) - len(strings.TrimRight(written, "\n"))
+			r.out.WriteString(strings.Repeat("\n", max(r.breaks-trailing, 0)))
+		} else if r.space && !strings.HasSuffix(written, "\n") {
+			r.out.WriteByte(' ')
+		}
+	}
+	r.breaks, r.space = 0, false
+	r.out.WriteString(s)
+}
+
+// writeInline renders the children of n with c, collapses the result to one line and writes it formatted by
+// format, keeping the whitespace around it.
+func (r *htmlRenderer) writeInline(c *htmlRenderer, n *html.Node, format func(text string) string) {
+	c.children(n)
+	text := strings.Join(strings.Fields(c.out.String()), " ")
+	if c.leadingSpace {
+		r.markSpace()
+	}
+	if text != "" {
+		r.write(format(text))
+	}
+	if c.space {
+		r.markSpace()
+	}
+}
+
+// renderNested renders the children of n with c and returns the result without surrounding line breaks.
+func renderNested(c *htmlRenderer, n *html.Node) string {
+	c.children(n)
+	return strings.Trim(c.out.String(), "\n")
+}
+
+// formatLink renders a link with the given text and URL. Links whose URL is unsafe, or in plain text only
+// refers to the current page, render as their text.
+func (r *htmlRenderer) formatLink(text, href string) string {
+	if !isSafeLinkURL(href) || (!r.markdown && strings.HasPrefix(href, "#")) {
+		return text
+	}
+	if r.markdown {
+		return "[" + text + "](" + markdownURL(href) + ")"
+	}
+	if text == href || "mailto:"+text == href {
+		return text
+	}
+	switch r.opts.Links {
+	case LinksFootnotes:
+		index := slices.Index(*r.footnotes, href)
+		if index < 0 {
+			*r.footnotes = append(*r.footnotes, href)
+			index = len(*r.footnotes) - 1
+		}
+		return text + "[" + strconv.Itoa(index+1) + "]"
+	case LinksTextOnly:
+		return text
+	default:
+		return text + " (" + href + ")"
+	}
+}
+
+// image renders an image as its alt text, or in Markdown as an image if its source is safe.
+func (r *htmlRenderer) image(n *html.Node) {
+	alt := strings.Join(strings.Fields(htmlAttr(n, "alt")), " ")
+	if !r.markdown {
+		if alt != "" {
+			r.write(alt)
+		}
+		return
+	}
+	alt = markdownEscaper.Replace(alt)
+	if src := strings.TrimSpace(htmlAttr(n, "src")); src != "" && isSafeLinkURL(src) {
+		r.write("![" + alt + "](" + markdownURL(src) + ")")
+	} else if alt != "" {
+		r.write(alt)
+	}
+}
+
+// list renders the items of an ordered or unordered list, indenting their continuation lines under the bullet
+// or number.
+func (r *htmlRenderer) list(n *html.Node) {
+	breaks := 2
+	if r.listDepth > 0 {
+		breaks = 1
+	}
+	number := 1
+	if start, err := strconv.Atoi(htmlAttr(n, "start")); err == nil {
+		number = start
+	}
+	r.block(breaks)
+	for item := range n.ChildNodes() {
+		if item.Type != html.ElementNode || item.Data != "li" {
+			continue
+		}
+		prefix := r.opts.ListBullet
+		if r.markdown {
+			prefix = "- "
+		}
+		if n.Data == "ol" {
+			prefix = strconv.Itoa(number) + ". "
+			number++
+		}
+		c := r.child()
+		c.listDepth++
+		r.block(1)
+		r.write(indentLines(renderNested(c, item), prefix))
+	}
+	r.block(breaks)
+}
+
+// indentLines prefixes the first line of s with prefix and indents the other non-empty lines to match.
+func indentLines(s, prefix string) string {
+	indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = prefix + line
+		} else if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// blockquote renders a quote with every line prefixed by "> ".
+func (r *htmlRenderer) blockquote(n *html.Node) {
+	text := renderNested(r.child(), n)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	r.block(2)
+	r.write(strings.Join(lines, "\n"))
+	r.block(2)
+}
+
+// preformatted renders a pre element with its whitespace kept, fenced as a code block in Markdown.
+func (r *htmlRenderer) preformatted(n *html.Node) {
+	c := r.child()
+	c.pre++
+	text := renderNested(c, n)
+	if r.markdown {
+		text = "```" + codeLanguage(n) + "\n" + text + "\n```"
+	}
+	if text == "" {
+		return
+	}
+	r.block(2)
+	r.write(text)
+	r.block(2)
+}
+
+// codeLanguage returns the language named by a "language-" class of a code element inside a pre element.
+func codeLanguage(pre *html.Node) string {
+	for c := range pre.ChildNodes() {
+		if c.Type != html.ElementNode || c.Data != "code" {
+			continue
+		}
+		for _, class := range strings.Fields(htmlAttr(c, "class")) {
+			if lang, ok := strings.CutPrefix(class, "language-"); ok {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// table renders a table as aligned columns, or in Markdown as a table with the first row as its header. A
+// caption is rendered above, as a paragraph of its own in Markdown.
+func (r *htmlRenderer) table(n *html.Node) {
+	var rows [][]string
+	header := false
+	for i, row := range tableRows(n) {
+		cells, allHeaders := r.tableCells(row)
+		if i == 0 {
+			header = allHeaders
+		}
+		rows = append(rows, cells)
+	}
+	r.block(2)
+	for c := range n.ChildNodes() {
+		if c.Type == html.ElementNode && c.Data == "caption" {
+			r.writeInline(r.child(), c, func(text string) string { return text })
+			r.block(1)
+			if r.markdown {
+				r.block(2)
+			}
+		}
+	}
+	if len(rows) > 0 {
+		if r.markdown {
+			r.write(markdownTable(rows))
+		} else {
+			r.write(textTable(rows, header))
+		}
+	}
+	r.block(2)
+}
+
+// tableRows returns the rows of a table, including those of its head, bodies and foot but not those of nested
+// tables.
+func tableRows(n *html.Node) []*html.Node {
+	var rows []*html.Node
+	for c := range n.ChildNodes() {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.Data {
+		case "thead", "tbody", "tfoot":
+			rows = append(rows, tableRows(c)...)
+		case "tr":
+			rows = append(rows, c)
+		default:
+		}
+	}
+	return rows
+}
+
+// tableCells renders the cells of a table row on one line each and reports whether they are all header cells.
+func (r *htmlRenderer) tableCells(row *html.Node) ([]string, bool) {
+	var cells []string
+	allHeaders := true
+	for c := range row.ChildNodes() {
+		if c.Type != html.ElementNode || (c.Data != "td" && c.Data != "th") {
+			continue
+		}
+		allHeaders = allHeaders && c.Data == "th"
+		cells = append(cells, strings.Join(strings.Fields(renderNested(r.child(), c)), " "))
+	}
+	return cells, allHeaders && len(cells) > 0
+}
+
+// textTable aligns the cells of rows in columns separated by two spaces, underlining the first row if it is a
+// header.
+func textTable(rows [][]string, header bool) string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		padded := make([]string, len(row))
+		for j, cell := range row {
+			padded[j] = cell + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(padded, "  "), " "))
+		if i == 0 && header {
+			rules := make([]string, len(widths))
+			for j, width := range widths {
+				rules[j] = strings.Repeat("-", width)
+			}
+			lines = append(lines, strings.Join(rules, "  "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownTable renders rows as a Markdown table with the first row as its header, padding short rows.
+func markdownTable(rows [][]string) string {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		cells := make([]string, columns)
+		for j, cell := range row {
+			cells[j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		lines = append(lines, strings.TrimRight("| "+strings.Join(cells, " | ")+" |", " "))
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownCode renders text as a Markdown code span, using a double backtick delimiter if it contains one.
+func markdownCode(text string) string {
+	if strings.Contains(text, "`") {
+		return "`` " + text + " ``"
+	}
+	return "`" + text + "`"
+}
+
+// markdownURL returns href as a Markdown link destination, enclosed in angle brackets if it contains spaces
+// or parentheses.
+func markdownURL(href string) string {
+	if strings.ContainsAny(href, " ()") {
+		return "<" + href + ">"
+	}
+	return href
+}
+
+// isSafeLinkURL reports whether href is non-empty and does not use a scheme that runs or embeds content.
+// Control characters and spaces are ignored when checking the scheme, as browsers ignore them.
+func isSafeLinkURL(href string) bool {
+	scheme := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, href)
+	if scheme == "" {
+		return false
+	}
+	for _, unsafe := range unsafeURLSchemes {
+		if strings.HasPrefix(scheme, unsafe) {
+			return false
+		}
+	}
+	return true
+}
+
+// htmlAttr returns the value of the named attribute of n, or an empty string if it is not set.
+func htmlAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
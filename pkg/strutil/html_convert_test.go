@@ -0,0 +1,105 @@
+package strutil
+
+import (
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	footnotes := NewHTMLToTextOptions()
+	footnotes.Links = LinksFootnotes
+	textOnly := NewHTMLToTextOptions()
+	textOnly.Links = LinksTextOnly
+	stars := NewHTMLToTextOptions()
+	stars.ListBullet = "* "
+	tests := []struct {
+		name     string
+		input    string
+		opts     HTMLToTextOptions
+		expected string
+	}{
+		{"Empty", "", NewHTMLToTextOptions(), ""},
+		{"PlainText", "just   text", NewHTMLToTextOptions(), "just text"},
+		{"Entities", "<p>Fish &amp; chips &lt;3 &eacute;&#233;</p>", NewHTMLToTextOptions(), "Fish & chips <3 éé"},
+		{"Paragraphs", "<p>One\n  two</p><p>Three</p>", NewHTMLToTextOptions(), "One two\n\nThree"},
+		{"DivsAndBreaks", "<div>a</div><div>b<br>c<br><br>d</div>", NewHTMLToTextOptions(), "a\nb\nc\n\nd"},
+		{"InlineSpacing", "<p>a <b>bold</b><i> move</i> end</p>", NewHTMLToTextOptions(), "a bold move end"},
+		{"SkipsScripts", "<head><title>T</title></head><p>a</p><script>x()</script><style>p{}</style>",
+			NewHTMLToTextOptions(), "a"},
+		{"Headings", "<h1>Title</h1><p>Body</p>", NewHTMLToTextOptions(), "Title\n\nBody"},
+		{"LinksInline", `<p>See <a href="https://x.io/docs">the docs</a>.</p>`, NewHTMLToTextOptions(),
+			"See the docs (https://x.io/docs)."},
+		{"LinksSameText", `<a href="https://x.io">https://x.io</a> <a href="mailto:a@x.io">a@x.io</a>`,
+			NewHTMLToTextOptions(), "https://x.io a@x.io"},
+		{"LinksUnsafe", `<a href="javascript:alert(1)">a</a> <a href="#top">b</a> <a>c</a>`,
+			NewHTMLToTextOptions(), "a b c"},
+		{"LinksFootnotes", `<a href="https://a.io">a</a>, <a href="https://b.io">b</a>, <a href="https://a.io">c</a>`,
+			footnotes, "a[1], b[2], c[1]\n\n[1] https://a.io\n[2] https://b.io"},
+		{"LinksTextOnly", `<a href="https://a.io">a</a>`, textOnly, "a"},
+		{"Images", `<p>A <img src="a.png" alt="cat"> here<img src="b.png"></p>`, NewHTMLToTextOptions(),
+			"A cat here"},
+		{"Lists", "<p>Items:</p><ul><li>One</li><li>Two<ul><li>Nested</li></ul></li></ul><p>After</p>",
+			NewHTMLToTextOptions(), "Items:\n\n- One\n- Two\n  - Nested\n\nAfter"},
+		{"ListBullet", "<ul><li>a</li><li>b</li></ul>", stars, "* a\n* b"},
+		{"OrderedLists", `<ol start="9"><li>Nine</li><li>Ten<p>more</p></li></ol>`, NewHTMLToTextOptions(),
+			"9. Nine\n10. Ten\n\n    more"},
+		{"Blockquote", "<blockquote><p>a</p><p>b</p></blockquote>", NewHTMLToTextOptions(), "> a\n>\n> b"},
+		{"Preformatted", "<p>x</p><pre>  a\n    b</pre>", NewHTMLToTextOptions(), "x\n\n  a\n    b"},
+		{"Table", "<table><tr><th>Name</th><th>Qty</th></tr><tr><td>Apple</td><td>3</td></tr>" +
+			"<tr><td>Kiwi fruit</td><td>12</td></tr></table>", NewHTMLToTextOptions(),
+			"Name        Qty\n----------  ---\nApple       3\nKiwi fruit  12"},
+		{"TableCaption", "<table><caption>Totals</caption><tbody><tr><td>a</td><td>1</td></tr></tbody></table>",
+			NewHTMLToTextOptions(), "Totals\na  1"},
+		{"Rule", "<p>a</p><hr><p>b</p>", NewHTMLToTextOptions(), "a\n\n---\n\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := htmlToText(tt.input, tt.opts)
+			result := HTMLToText(tt.input, tt.opts)
+			builderResult := New(tt.input).HTMLToText(tt.opts).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("HTMLToText - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Headings", "<h1>Title</h1><h3>Sub <em>part</em></h3><p>Body</p>", "# Title\n\n### Sub _part_\n\nBody"},
+		{"Emphasis", "<p><strong>b</strong> <em>i</em> <del>d</del> <code>x*y</code></p>", "**b** _i_ ~~d~~ `x*y`"},
+		{"CodeWithBacktick", "<code>a`b</code>", "`` a`b ``"},
+		{"EscapesText", "<p>*not* [a] 2_3 \\ &lt;b&gt;</p>", `\*not\* \[a\] 2\_3 \\ \<b>`},
+		{"EscapesBlockMarkers", "<p># one</p><p>2. two</p><p>- three</p><p>a # b</p>",
+			"\\# one\n\n2\\. two\n\n\\- three\n\na # b"},
+		{"Links", `<a href="https://x.io/a b">x</a> <a href="#top">top</a> <a href="javascript:x()">js</a>`,
+			"[x](<https://x.io/a b>) [top](#top) js"},
+		{"Images", `<img src="a.png" alt="a *cat*"> <img src="data:image/png;base64,AA" alt="inline">`,
+			`![a \*cat\*](a.png) inline`},
+		{"Lists", "<ul><li>One</li><li>Two<ol><li>A</li><li>B</li></ol></li></ul>",
+			"- One\n- Two\n  1. A\n  2. B"},
+		{"Blockquote", "<blockquote><p>Quoted <b>text</b></p></blockquote>", "> Quoted **text**"},
+		{"CodeBlock", `<pre><code class="language-go">x := *p` + "\n" + `_ = x</code></pre>`,
+			"```go\nx := *p\n_ = x\n```"},
+		{"LineBreak", "a<br>b", "a\\\nb"},
+		{"Table", "<table><thead><tr><th>A</th><th>B</th></tr></thead><tbody><tr><td>1|2</td></tr></tbody></table>",
+			"| A | B |\n| --- | --- |\n| 1\\|2 |  |"},
+		{"Rule", "<p>a</p><hr><p>b</p>", "a\n\n---\n\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := htmlToMarkdown(tt.input)
+			result := HTMLToMarkdown(tt.input)
+			builderResult := New(tt.input).HTMLToMarkdown().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("HTMLToMarkdown - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
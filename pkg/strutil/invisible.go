@@ -0,0 +1,90 @@
+package strutil
+
+// InvisibleCategory classifies the invisible and bidirectional control characters found by DetectInvisible.
+type InvisibleCategory int
+
+// String returns the string representation of the InvisibleCategory using InvisibleCategoryMap.
+func (ic InvisibleCategory) String() string {
+	return InvisibleCategoryMap[ic]
+}
+
+// BidiControl is a bidirectional formatting character that can reorder how text is displayed: the embeddings
+// and overrides U+202A to U+202E, the isolates U+2066 to U+2069 and the marks U+200E, U+200F and U+061C.
+// ZeroWidth is a zero width space, joiner or non-joiner, word joiner, Mongolian vowel separator or byte order
+// mark.
+// VariationSelector is a variation selector, including the Mongolian free variation selectors.
+// TagCharacter is one of the tag characters U+E0000 to U+E007F, which can hide ASCII text.
+// OtherInvisible is any other default ignorable character, such as a soft hyphen or a Hangul filler.
+const (
+	BidiControl InvisibleCategory = iota
+	ZeroWidth
+	VariationSelector
+	TagCharacter
+	OtherInvisible
+)
+
+// InvisibleCategoryMap maps InvisibleCategory constants to their corresponding string representations.
+var InvisibleCategoryMap = map[InvisibleCategory]string{
+	BidiControl:       "Bidi Control",
+	ZeroWidth:         "Zero Width",
+	VariationSelector: "Variation Selector",
+	TagCharacter:      "Tag Character",
+	OtherInvisible:    "Other Invisible",
+}
+
+// InvisibleRune is an invisible or bidirectional control character found by DetectInvisible.
+type InvisibleRune struct {
+	Rune     rune
+	Category InvisibleCategory
+	// Offset is the byte offset of the rune in the string.
+	Offset int
+	// Line and Column are the 1-based line and rune column of the rune.
+	Line   int
+	Column int
+	// InEmoji reports whether the rune is part of an emoji sequence, like the zero width joiners of a family
+	// emoji or the variation selector of a keycap.
+	InEmoji bool
+}
+
+// InvisibleOptions configures RemoveInvisible.
+type InvisibleOptions struct {
+	// Categories are the categories of characters that are removed.
+	Categories []InvisibleCategory
+	// Allow lists characters that are always kept, for example U+200C and U+200D for scripts that use them to
+	// select letter forms.
+	Allow []rune
+	// KeepEmoji keeps zero width joiners, variation selectors and tag characters that are part of emoji
+	// sequences.
+	KeepEmoji bool
+}
+
+// NewInvisibleOptions returns InvisibleOptions that remove every category of invisible character except those
+// that are part of emoji sequences.
+func NewInvisibleOptions() InvisibleOptions {
+	return InvisibleOptions{
+		Categories: []InvisibleCategory{BidiControl, ZeroWidth, VariationSelector, TagCharacter, OtherInvisible},
+		KeepEmoji:  true,
+	}
+}
+
+// DetectInvisible reports every invisible or bidirectional control character in s with its position and
+// category, in the order they appear. Invalid UTF-8 is not reported.
+func DetectInvisible(s string) []InvisibleRune {
+	return detectInvisible(s)
+}
+
+// RemoveInvisible removes the invisible and bidirectional control characters selected by opts from s.
+func RemoveInvisible(s string, opts InvisibleOptions) string {
+	return removeInvisible(s, opts)
+}
+
+// RemoveBidiControls removes every bidirectional formatting character from s, defending against "Trojan Source"
+// text whose displayed order differs from its logical order.
+func RemoveBidiControls(s string) string {
+	return removeBidiControls(s)
+}
+
+// HasBidiControls reports whether s contains any bidirectional formatting character.
+func HasBidiControls(s string) bool {
+	return hasBidiControls(s)
+}
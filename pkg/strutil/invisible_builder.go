@@ -0,0 +1,51 @@
+package strutil
+
+import (
+	"strings"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// RemoveInvisible removes the invisible and bidirectional control characters selected by opts from the string.
+func (sb *StringBuilder) RemoveInvisible(opts InvisibleOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(removeInvisible(sb.value, opts))
+	return sb
+}
+
+// RemoveBidiControls removes every bidirectional formatting character from the string.
+func (sb *StringBuilder) RemoveBidiControls() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(removeBidiControls(sb.value))
+	return sb
+}
+
+// HasBidiControls reports whether the string contains any bidirectional formatting character.
+func (sb *StringBuilder) HasBidiControls() bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return hasBidiControls(sb.value)
+}
+
+// RequireNoBidiControls ensures the string contains no bidirectional formatting characters, setting
+// ErrBidiControl otherwise.
+func (sb *StringBuilder) RequireNoBidiControls() *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	offset := strings.IndexFunc(value, func(r rune) bool {
+		category, ok := invisibleCategory(r)
+		return ok && category == BidiControl
+	})
+	if offset >= 0 {
+		return sb.failValidation(newValidationError(value, "no_bidi_controls", ReasonInvalidCharacter, offset,
+			errors.ErrBidiControl))
+	}
+	return sb
+}
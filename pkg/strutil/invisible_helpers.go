@@ -0,0 +1,157 @@
+package strutil
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	zeroWidthJoiner   = '\u200D'
+	textPresentation  = '\uFE0E'
+	emojiPresentation = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+	blackFlag         = '\U0001F3F4'
+	tagSpace          = '\U000E0020'
+	cancelTag         = '\U000E007F'
+)
+
+// emojiRunes approximates the Extended_Pictographic property with the blocks and symbols used as emoji.
+var emojiRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00AE, Stride: 5},
+		{Lo: 0x203C, Hi: 0x2049, Stride: 13},
+		{Lo: 0x2122, Hi: 0x2139, Stride: 23},
+		{Lo: 0x2194, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x23FF, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x303D, Stride: 13},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1FAFF, Stride: 1},
+	},
+}
+
+// invisibleCategory returns the InvisibleCategory of r, or false if r is visible.
+func invisibleCategory(r rune) (InvisibleCategory, bool) {
+	switch {
+	case r == '\u061C' || r == '\u200E' || r == '\u200F' || (r >= '\u202A' && r <= '\u202E') ||
+		(r >= '\u2066' && r <= '\u2069'):
+		return BidiControl, true
+	case (r >= '\u200B' && r <= zeroWidthJoiner) || r == '\u2060' || r == '\uFEFF' || r == '\u180E':
+		return ZeroWidth, true
+	case unicode.Is(unicode.Variation_Selector, r):
+		return VariationSelector, true
+	case r >= '\U000E0000' && r <= cancelTag:
+		return TagCharacter, true
+	case isDefaultIgnorable(r):
+		return OtherInvisible, true
+	}
+	return 0, false
+}
+
+// decodeRunes returns the runes of s and their byte offsets, followed by len(s). Invalid bytes decode to
+// utf8.RuneError like in a range loop.
+func decodeRunes(s string) ([]rune, []int) {
+	runes := make([]rune, 0, len(s))
+	offsets := make([]int, 0, len(s)+1)
+	for i, r := range s {
+		runes = append(runes, r)
+		offsets = append(offsets, i)
+	}
+	return runes, append(offsets, len(s))
+}
+
+// emojiSequenceRunes reports for each of runes whether it is an invisible character inside an emoji sequence:
+// a variation selector after an emoji or keycap base, a zero width joiner between emoji, or a tag character of
+// a flag tag sequence.
+func emojiSequenceRunes(runes []rune) []bool {
+	in := make([]bool, len(runes))
+	for i, r := range runes {
+		if i == 0 {
+			continue
+		}
+		prev := runes[i-1]
+		switch {
+		case r == textPresentation || r == emojiPresentation:
+			isKeycap := (prev >= '0' && prev <= '9' || prev == '#' || prev == '*') &&
+				i+1 < len(runes) && runes[i+1] == combiningKeycap
+			in[i] = unicode.Is(emojiRunes, prev) || isKeycap
+		case r == zeroWidthJoiner:
+			in[i] = (unicode.Is(emojiRunes, prev) || in[i-1]) && i+1 < len(runes) && unicode.Is(emojiRunes, runes[i+1])
+		case r >= tagSpace && r <= cancelTag:
+			in[i] = prev == blackFlag || (in[i-1] && prev >= tagSpace && prev < cancelTag)
+		}
+	}
+	return in
+}
+
+// detectInvisible returns the invisible characters of s with their positions.
+func detectInvisible(s string) []InvisibleRune {
+	runes, offsets := decodeRunes(s)
+	var inEmoji []bool
+	var found []InvisibleRune
+	line, column := 1, 0
+	for i, r := range runes {
+		column++
+		if category, ok := invisibleCategory(r); ok {
+			if inEmoji == nil {
+				inEmoji = emojiSequenceRunes(runes)
+			}
+			found = append(found, InvisibleRune{
+				Rune:     r,
+				Category: category,
+				Offset:   offsets[i],
+				Line:     line,
+				Column:   column,
+				InEmoji:  inEmoji[i],
+			})
+		}
+		if r == '\n' {
+			line, column = line+1, 0
+		}
+	}
+	return found
+}
+
+// removeInvisible removes the invisible characters of s that opts selects, keeping the bytes of everything else,
+// including invalid UTF-8, unchanged.
+func removeInvisible(s string, opts InvisibleOptions) string {
+	runes, offsets := decodeRunes(s)
+	var inEmoji []bool
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range runes {
+		category, ok := invisibleCategory(r)
+		if ok && slices.Contains(opts.Categories, category) && !slices.Contains(opts.Allow, r) {
+			if inEmoji == nil {
+				inEmoji = emojiSequenceRunes(runes)
+			}
+			if !opts.KeepEmoji || !inEmoji[i] {
+				continue
+			}
+		}
+		b.WriteString(s[offsets[i]:offsets[i+1]])
+	}
+	return b.String()
+}
+
+// removeBidiControls removes every bidirectional formatting character from s.
+func removeBidiControls(s string) string {
+	return removeInvisible(s, InvisibleOptions{Categories: []InvisibleCategory{BidiControl}})
+}
+
+// hasBidiControls reports whether s contains a bidirectional formatting character.
+func hasBidiControls(s string) bool {
+	for _, r := range s {
+		if category, ok := invisibleCategory(r); ok && category == BidiControl {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package strutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+func TestDetectInvisible(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []InvisibleRune
+	}{
+		{"Empty", "", nil},
+		{"Visible", "plain text é", nil},
+		{"TrojanSource", "if x {\n\t/* \u202E } \u2066if admin\u2069 \u2066 */", []InvisibleRune{
+			{Rune: '\u202E', Category: BidiControl, Offset: 11, Line: 2, Column: 5},
+			{Rune: '\u2066', Category: BidiControl, Offset: 17, Line: 2, Column: 9},
+			{Rune: '\u2069', Category: BidiControl, Offset: 28, Line: 2, Column: 18},
+			{Rune: '\u2066', Category: BidiControl, Offset: 32, Line: 2, Column: 20},
+		}},
+		{"Categories", "a\u200Bb\uFE00c\U000E0041d\u00ADe", []InvisibleRune{
+			{Rune: '\u200B', Category: ZeroWidth, Offset: 1, Line: 1, Column: 2},
+			{Rune: '\uFE00', Category: VariationSelector, Offset: 5, Line: 1, Column: 4},
+			{Rune: '\U000E0041', Category: TagCharacter, Offset: 9, Line: 1, Column: 6},
+			{Rune: '\u00AD', Category: OtherInvisible, Offset: 14, Line: 1, Column: 8},
+		}},
+		{"EmojiSequence", "\U0001F468\u200D\U0001F469 ❤\uFE0F", []InvisibleRune{
+			{Rune: '\u200D', Category: ZeroWidth, Offset: 4, Line: 1, Column: 2, InEmoji: true},
+			{Rune: '\uFE0F', Category: VariationSelector, Offset: 15, Line: 1, Column: 6, InEmoji: true},
+		}},
+		{"InvalidUTF8", "\xff\u200B", []InvisibleRune{
+			{Rune: '\u200B', Category: ZeroWidth, Offset: 1, Line: 1, Column: 2},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := detectInvisible(tt.input)
+			result := DetectInvisible(tt.input)
+			if !reflect.DeepEqual(helperResult, tt.expected) || !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("DetectInvisible - expected %+v - got %+v / %+v", tt.expected, helperResult, result)
+			}
+		})
+	}
+}
+
+func TestRemoveInvisible(t *testing.T) {
+	allowJoiners := NewInvisibleOptions()
+	allowJoiners.Allow = []rune{'\u200C', '\u200D'}
+	noEmoji := NewInvisibleOptions()
+	noEmoji.KeepEmoji = false
+	zeroWidthOnly := InvisibleOptions{Categories: []InvisibleCategory{ZeroWidth}}
+	tests := []struct {
+		name     string
+		input    string
+		opts     InvisibleOptions
+		expected string
+	}{
+		{"Empty", "", NewInvisibleOptions(), ""},
+		{"Visible", "plain text é", NewInvisibleOptions(), "plain text é"},
+		{"All", "\uFEFFa\u200Bb\u202Ec\uFE00d\U000E0041e\u00ADf", NewInvisibleOptions(), "abcdef"},
+		{"ZeroWidthOnly", "a\u200Bb\u202Ec", zeroWidthOnly, "ab\u202Ec"},
+		{"KeepsEmojiZWJ", "\U0001F468\u200D\U0001F469\u200D\U0001F467 x\u200Dy", NewInvisibleOptions(),
+			"\U0001F468\u200D\U0001F469\u200D\U0001F467 xy"},
+		{"KeepsEmojiVariation", "❤\uFE0F 1\uFE0F\u20E3 a\uFE0F", NewInvisibleOptions(), "❤\uFE0F 1\uFE0F\u20E3 a"},
+		{"KeepsFlagTags", "\U0001F3F4\U000E0067\U000E0062\U000E007F \U000E0067", NewInvisibleOptions(),
+			"\U0001F3F4\U000E0067\U000E0062\U000E007F "},
+		{"KeepsZWJAfterVariation", "\U0001F3F3\uFE0F\u200D\U0001F308", NewInvisibleOptions(),
+			"\U0001F3F3\uFE0F\u200D\U0001F308"},
+		{"RemovesEmojiSequences", "\U0001F468\u200D\U0001F469 ❤\uFE0F", noEmoji, "\U0001F468\U0001F469 ❤"},
+		{"AllowList", "क\u094D\u200Dष a\u200Bb", allowJoiners, "क\u094D\u200Dष ab"},
+		{"KeepsInvalidUTF8", "\xff\u200B\xfe", NewInvisibleOptions(), "\xff\xfe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := removeInvisible(tt.input, tt.opts)
+			result := RemoveInvisible(tt.input, tt.opts)
+			builderResult := New(tt.input).RemoveInvisible(tt.opts).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("RemoveInvisible - expected %+q - got %+q / %+q / %+q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestRemoveBidiControls(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		hasBidi  bool
+	}{
+		{"Empty", "", "", false},
+		{"Plain", "access level", "access level", false},
+		{"Overrides", "a\u202Ab\u202Bc\u202Cd\u202De\u202Ef", "abcdef", true},
+		{"Isolates", "\u2066a\u2067b\u2068c\u2069", "abc", true},
+		{"Marks", "a\u200Eb\u200Fc\u061Cd", "abcd", true},
+		{"KeepsOtherInvisible", "a\u200Bb", "a\u200Bb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := removeBidiControls(tt.input)
+			result := RemoveBidiControls(tt.input)
+			builderResult := New(tt.input).RemoveBidiControls().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("RemoveBidiControls - expected %+q - got %+q / %+q / %+q",
+					tt.expected, helperResult, result, builderResult)
+			}
+			if hasBidiControls(tt.input) != tt.hasBidi || HasBidiControls(tt.input) != tt.hasBidi ||
+				New(tt.input).HasBidiControls() != tt.hasBidi {
+				t.Errorf("HasBidiControls(%+q) - expected %v", tt.input, tt.hasBidi)
+			}
+			builderErr := New(tt.input).RequireNoBidiControls().Error()
+			if tt.hasBidi != errors.Is(builderErr, errors2.ErrBidiControl) {
+				t.Errorf("RequireNoBidiControls(%+q) = %v; want error %v", tt.input, builderErr, tt.hasBidi)
+			}
+		})
+	}
+}
@@ -12,26 +12,18 @@ func NewLCSResultsMap() LCSResultsMap {
 
 // Add inserts an LCSResult into the LCSResultsMap organized by result type and the comparison input string.
 func (lrm LCSResultsMap) Add(result LCSResult) {
-	if lrm[result.GetType()] == nil {
-		lrm[result.GetType()] = make(map[string]*LCSResult)
-	}
-	lrm[result.GetType()][result.GetString2()] = &result
+	lrm.index().add(result.GetType(), result.GetString2(), result)
+}
+
+// index returns the map as the resultIndex its methods delegate to.
+func (lrm LCSResultsMap) index() resultIndex[LCSResultType, string, LCSResult] {
+	return resultIndex[LCSResultType, string, LCSResult](lrm)
 }
 
 // GetCopy creates and returns a deep copy of the LCSResultsMap, duplicating all
 // nested maps and their LCSResult values.
 func (lrm LCSResultsMap) GetCopy() LCSResultsMap {
-	cloned := NewLCSResultsMap()
-	for resType, results := range lrm {
-		cloned[resType] = make(map[string]*LCSResult)
-		for compStr, result := range results {
-			if result != nil {
-				resultCopy := *result
-				cloned[resType][compStr] = &resultCopy
-			}
-		}
-	}
-	return cloned
+	return LCSResultsMap(lrm.index().clone())
 }
 
 // Get retrieves the LCSResult for the given LCSResultType and comparison string from the LCSResultsMap.
@@ -48,69 +40,27 @@ func (lrm LCSResultsMap) Get(resType LCSResultType, compStr string) *LCSResult {
 
 // GetByType returns a slice of LCSResult objects from the LCSResultsMap based on their LCSResultType
 func (lrm LCSResultsMap) GetByType(resType LCSResultType) []LCSResult {
-	if lrm[resType] == nil {
-		return nil
-	}
-	var results []LCSResult
-	for _, v := range lrm[resType] {
-		results = append(results, *v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(lrm.index().values(func(t LCSResultType, _ string) bool { return t == resType }),
+		derefResult[LCSResult])
 }
 
 // FilterByType filters the LCSResultsMap by the specified LCSResultType and returns a new map containing
 // only matching results.
 func (lrm LCSResultsMap) FilterByType(resType LCSResultType) LCSResultsMap {
-	if lrm[resType] == nil {
-		return nil
-	}
-	results := NewLCSResultsMap()
-	for _, v := range lrm[resType] {
-		results.Add(*v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return LCSResultsMap(lrm.index().filter(func(t LCSResultType, _ string) bool { return t == resType }))
 }
 
 // GetByComparisonString retrieves all LCSResult objects from the map that match the specified comparison string.
 // Returns nil if no results are found or the map is empty.
 func (lrm LCSResultsMap) GetByComparisonString(compStr string) []LCSResult {
-	if len(lrm) == 0 {
-		return nil
-	}
-	var results []LCSResult
-	for _, v := range lrm {
-		if v[compStr] != nil {
-			results = append(results, *v[compStr])
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(lrm.index().values(func(_ LCSResultType, s string) bool { return s == compStr }),
+		derefResult[LCSResult])
 }
 
 // FilterByComparisonString filters the LCSResultsMap by a given comparison string and
 // returns a new map with matching results.
 func (lrm LCSResultsMap) FilterByComparisonString(compStr string) LCSResultsMap {
-	if len(lrm) == 0 {
-		return nil
-	}
-	results := NewLCSResultsMap()
-	for _, v := range lrm {
-		if v[compStr] != nil {
-			results.Add(*v[compStr])
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return LCSResultsMap(lrm.index().filter(func(_ LCSResultType, s string) bool { return s == compStr }))
 }
 
 // TypeCount returns the number of LCSResultType keys in the LCSResultsMap.
@@ -120,37 +70,13 @@ func (lrm LCSResultsMap) TypeCount() int {
 
 // EntryCount returns the total number of non-nil LCSResult entries in the LCSResultsMap.
 func (lrm LCSResultsMap) EntryCount() int {
-	mapLength := 0
-	for _, v := range lrm {
-		for _, v2 := range v {
-			if v2 != nil {
-				mapLength++
-			}
-		}
-	}
-	return mapLength
+	return lrm.index().entryCount()
 }
 
 // IsMatch compares the current LCSResultsMap with another,
 // checking if they have identical structure and matching entries.
 func (lrm LCSResultsMap) IsMatch(other LCSResultsMap) bool {
-	if lrm.TypeCount() != other.TypeCount() || lrm.EntryCount() != other.EntryCount() {
-		return false
-	}
-	for resType, v := range lrm {
-		if other[resType] == nil {
-			return false
-		}
-		for compStr, v2 := range v {
-			if other[resType][compStr] == nil {
-				return false
-			}
-			if !v2.IsMatch(other[resType][compStr]) {
-				return false
-			}
-		}
-	}
-	return true
+	return lrm.index().isMatch(other.index(), (*LCSResult).IsMatch)
 }
 
 // Print outputs the contents of the LCSResultsMap to the console in a
@@ -63,3 +63,72 @@ func LoremURL() string {
 func LoremEmail() string {
 	return loremEmail()
 }
+
+// LoremMarkdown generates a placeholder Markdown document with a title, sections, lists,
+// code blocks, links and emphasis as described by opts.
+// Returns an empty string if opts.Sections is less than 1.
+func LoremMarkdown(opts LoremDocumentOptions) string {
+	return loremMarkdown(opts)
+}
+
+// LoremHTML generates a placeholder HTML article using nested semantic elements
+// (article, header, section, p, ul/ol, pre/code, a, em, strong) as described by opts.
+// Returns an empty string if opts.Sections is less than 1.
+func LoremHTML(opts LoremDocumentOptions) string {
+	return loremHTML(opts)
+}
+
+// LoremJSON fills the provided LoremSchema with lorem values of the declared types
+// and returns the result as indented JSON.
+// Returns an error if the schema is nil or contains an unsupported value.
+func LoremJSON(schema LoremSchema) (string, error) {
+	return loremJSON(schema)
+}
+
+// LoremWordFrom returns a single random word from the given corpus.
+// Returns an empty string if the corpus is unknown.
+func LoremWordFrom(corpus LoremCorpus) string {
+	return loremWordFrom(corpus)
+}
+
+// LoremWordsFrom returns the specified number of words from the given corpus.
+// Japanese and Chinese words are joined without spaces.
+func LoremWordsFrom(corpus LoremCorpus, count int) string {
+	return loremWordsFrom(corpus, count)
+}
+
+// LoremSentenceFrom returns an 8 word sentence from the given corpus.
+func LoremSentenceFrom(corpus LoremCorpus) string {
+	return loremSentenceFrom(corpus)
+}
+
+// LoremSentenceCustomFrom returns a sentence with the specified word count from the given corpus.
+func LoremSentenceCustomFrom(corpus LoremCorpus, length int) string {
+	return loremSentenceCustomFrom(corpus, length)
+}
+
+// LoremSentencesFrom returns the specified number of 8 word sentences from the given corpus.
+func LoremSentencesFrom(corpus LoremCorpus, count int) string {
+	return loremSentencesFrom(corpus, count)
+}
+
+// LoremSentencesCustomFrom returns count sentences of the given word length from the given corpus.
+func LoremSentencesCustomFrom(corpus LoremCorpus, count int, length int) string {
+	return loremSentencesCustomFrom(corpus, count, length)
+}
+
+// LoremSentencesVariableFrom returns count sentences from the given corpus with
+// word lengths between min and max inclusive.
+func LoremSentencesVariableFrom(corpus LoremCorpus, count, min, max int) string {
+	return loremSentencesVariableFrom(corpus, count, min, max)
+}
+
+// LoremParagraphFrom returns a 45 word paragraph from the given corpus.
+func LoremParagraphFrom(corpus LoremCorpus) string {
+	return loremParagraphFrom(corpus)
+}
+
+// LoremParagraphsFrom returns the specified number of paragraphs from the given corpus separated by blank lines.
+func LoremParagraphsFrom(corpus LoremCorpus, count int) string {
+	return loremParagraphsFrom(corpus, count)
+}
@@ -112,3 +112,117 @@ func NewLoremEmail() *StringBuilder {
 		originalValue: s,
 	}
 }
+
+// NewLoremMarkdown creates a new StringBuilder initialized with a placeholder Markdown document.
+func NewLoremMarkdown(opts LoremDocumentOptions) *StringBuilder {
+	s := loremMarkdown(opts)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremHTML creates a new StringBuilder initialized with a placeholder HTML article.
+func NewLoremHTML(opts LoremDocumentOptions) *StringBuilder {
+	s := loremHTML(opts)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremJSON creates a new StringBuilder initialized with JSON generated from the provided schema.
+// If the schema is invalid the StringBuilder carries the error and an empty value.
+func NewLoremJSON(schema LoremSchema) *StringBuilder {
+	s, err := loremJSON(schema)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+		err:           err,
+	}
+}
+
+// NewLoremWordFrom creates a new StringBuilder initialized with a random word from the given corpus.
+func NewLoremWordFrom(corpus LoremCorpus) *StringBuilder {
+	s := loremWordFrom(corpus)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremWordsFrom creates a new StringBuilder initialized with the specified number of words from the given corpus.
+func NewLoremWordsFrom(corpus LoremCorpus, count int) *StringBuilder {
+	s := loremWordsFrom(corpus, count)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremSentenceFrom creates a new StringBuilder initialized with an 8 word sentence from the given corpus.
+func NewLoremSentenceFrom(corpus LoremCorpus) *StringBuilder {
+	s := loremSentenceFrom(corpus)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremSentenceCustomFrom creates a new StringBuilder initialized with a sentence of the given word length
+// from the given corpus.
+func NewLoremSentenceCustomFrom(corpus LoremCorpus, length int) *StringBuilder {
+	s := loremSentenceCustomFrom(corpus, length)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremSentencesFrom creates a new StringBuilder initialized with count 8 word sentences from the given corpus.
+func NewLoremSentencesFrom(corpus LoremCorpus, count int) *StringBuilder {
+	s := loremSentencesFrom(corpus, count)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremSentencesCustomFrom creates a new StringBuilder initialized with count sentences of the given word length
+// from the given corpus.
+func NewLoremSentencesCustomFrom(corpus LoremCorpus, count int, length int) *StringBuilder {
+	s := loremSentencesCustomFrom(corpus, count, length)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremSentencesVariableFrom creates a new StringBuilder initialized with count sentences between min and max
+// words long from the given corpus.
+func NewLoremSentencesVariableFrom(corpus LoremCorpus, count int, min int, max int) *StringBuilder {
+	s := loremSentencesVariableFrom(corpus, count, min, max)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremParagraphFrom creates a new StringBuilder initialized with a paragraph from the given corpus.
+func NewLoremParagraphFrom(corpus LoremCorpus) *StringBuilder {
+	s := loremParagraphFrom(corpus)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
+
+// NewLoremParagraphsFrom creates a new StringBuilder initialized with the specified number of paragraphs
+// from the given corpus.
+func NewLoremParagraphsFrom(corpus LoremCorpus, count int) *StringBuilder {
+	s := loremParagraphsFrom(corpus, count)
+	return &StringBuilder{
+		value:         s,
+		originalValue: s,
+	}
+}
@@ -0,0 +1,208 @@
+package strutil
+
+// LoremCorpus selects the word list and script conventions used to generate placeholder text.
+type LoremCorpus int
+
+// String returns the string representation of the LoremCorpus using LoremCorpusMap.
+func (c LoremCorpus) String() string {
+	return LoremCorpusMap[c]
+}
+
+// LatinCorpus generates classic lorem ipsum pseudo-Latin text.
+// EnglishCorpus generates text from common English words.
+// GermanCorpus generates German text rich in umlauts and ß.
+// FrenchCorpus generates French text rich in accents, cedillas and ligatures.
+// RussianCorpus generates Russian text in the Cyrillic script.
+// GreekCorpus generates Greek text including tonos, diaeresis and final sigma.
+// ArabicCorpus generates right-to-left Arabic text, including some words with harakat.
+// HebrewCorpus generates right-to-left Hebrew text, including some words with niqqud.
+// JapaneseCorpus generates Japanese text mixing kanji, hiragana, katakana and half-width katakana without spaces.
+// ChineseCorpus generates Chinese text in simplified and traditional characters without spaces.
+// EmojiCorpus generates emoji-heavy English text including skin tones, flags and ZWJ sequences.
+const (
+	LatinCorpus LoremCorpus = iota
+	EnglishCorpus
+	GermanCorpus
+	FrenchCorpus
+	RussianCorpus
+	GreekCorpus
+	ArabicCorpus
+	HebrewCorpus
+	JapaneseCorpus
+	ChineseCorpus
+	EmojiCorpus
+)
+
+// LoremCorpusMap maps LoremCorpus constants to their corresponding string representations.
+var LoremCorpusMap = map[LoremCorpus]string{
+	LatinCorpus:    "Latin",
+	EnglishCorpus:  "English",
+	GermanCorpus:   "German",
+	FrenchCorpus:   "French",
+	RussianCorpus:  "Russian",
+	GreekCorpus:    "Greek",
+	ArabicCorpus:   "Arabic",
+	HebrewCorpus:   "Hebrew",
+	JapaneseCorpus: "Japanese",
+	ChineseCorpus:  "Chinese",
+	EmojiCorpus:    "Emoji",
+}
+
+// loremCorpusData holds the word list and script conventions for a single corpus.
+// words is the pool words are drawn from.
+// separator is placed between words and between sentences.
+// terminator ends every sentence.
+// cased indicates the script has letter case and sentences should be capitalized.
+type loremCorpusData struct {
+	words      []string
+	separator  string
+	terminator string
+	cased      bool
+}
+
+// loremCorpora holds the data for every non-Latin corpus; Latin is generated by lorelai.
+var loremCorpora = map[LoremCorpus]loremCorpusData{
+	EnglishCorpus: {
+		words: []string{
+			"the", "quick", "brown", "fox", "jumps", "over", "lazy", "dog", "and", "with",
+			"people", "time", "year", "way", "day", "thing", "world", "life", "hand", "part",
+			"child", "eye", "place", "work", "week", "case", "point", "number", "group", "problem",
+			"good", "new", "first", "last", "long", "great", "little", "own", "other", "old",
+			"make", "know", "take", "see", "come", "think", "look", "want", "give", "use",
+			"find", "tell", "ask", "seem", "feel", "try", "leave", "call", "river", "garden",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      true,
+	},
+	GermanCorpus: {
+		words: []string{
+			"über", "Straße", "schön", "Mädchen", "Größe", "Bäcker", "fröhlich", "Äpfel", "Übung", "müde",
+			"hören", "Käse", "Tür", "grün", "weiß", "Fuß", "Brücke", "Löwe", "Schlüssel", "Gemüse",
+			"natürlich", "Frühstück", "Häuser", "könnte", "während", "Öl", "gemütlich", "Schnee", "süß", "Märchen",
+			"und", "der", "die", "das", "ist", "mit", "nicht", "auch", "Zeit", "Wasser",
+			"groß", "Fräulein", "Küche", "Glück", "spät", "Vögel", "Mühle", "Maß", "Gefühl", "außerdem",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      true,
+	},
+	FrenchCorpus: {
+		words: []string{
+			"été", "déjà", "où", "garçon", "français", "très", "forêt", "château", "élève", "cœur",
+			"naïve", "Noël", "hôpital", "fenêtre", "à", "le", "la", "et", "les", "des",
+			"pâtisserie", "crème", "brûlée", "façade", "être", "sœur", "île", "goût", "théâtre", "bientôt",
+			"maïs", "leçon", "après", "première", "œuvre", "ça", "voilà", "fête", "écrire", "général",
+			"répondre", "âge", "reçu", "Ève", "ambiguë", "aïeul", "curaçao", "hélas", "rêve", "pêche",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      true,
+	},
+	RussianCorpus: {
+		words: []string{
+			"и", "в", "не", "на", "я",
+			"быть", "он", "с", "что", "а",
+			"по", "это", "она", "этот", "к",
+			"но", "они", "мы", "как", "из",
+			"который", "то", "за", "свой", "весь",
+			"год", "от", "так", "для", "ещё",
+			"жизнь", "время", "рука", "день", "дом",
+			"слово", "место", "лицо", "друг", "глаз",
+			"вопрос", "работа", "ёлка", "съезд", "объём",
+			"щука", "жёлтый", "Москва", "чай", "хорошо",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      true,
+	},
+	GreekCorpus: {
+		words: []string{
+			"και", "το", "να", "είναι", "θάλασσα",
+			"ουρανός", "ήλιος", "φίλος", "σπίτι", "νερό",
+			"καλημέρα", "ευχαριστώ", "γλώσσα", "λόγος", "άνθρωπος",
+			"χρόνος", "πόλη", "δρόμος", "ψυχή", "αγάπη",
+			"μέρα", "νύχτα", "βιβλίο", "σχολείο", "ώρα",
+			"ζωή", "κόσμος", "ιστορία", "προϊόν", "Αθήνα",
+			"ευτυχία", "ξένος", "ελπίδα", "φως", "δέντρο",
+			"θεός", "παιδί", "μουσική", "ρολόι", "αϋπνία",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      true,
+	},
+	ArabicCorpus: {
+		words: []string{
+			"في", "من", "على", "إلى", "كتاب",
+			"مدرسة", "بيت", "شمس", "قمر", "ماء",
+			"سلام", "عالم", "لغة", "كلمة", "طالب",
+			"مدينة", "سماء", "بحر", "جميل", "كبير",
+			"صغير", "جديد", "قديم", "يوم", "ليلة",
+			"وقت", "صديق", "عمل", "حياة", "قلب",
+			"نور", "طريق", "باب", "شجرة", "زهرة",
+			"كِتَابٌ", "عَرَبِيّ", "مَدْرَسَة", "أهلاً", "القاهرة",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      false,
+	},
+	HebrewCorpus: {
+		words: []string{
+			"של", "את", "על", "הוא", "היא",
+			"זה", "לא", "עם", "ספר", "בית",
+			"שמש", "ירח", "מים", "שלום", "עולם",
+			"שפה", "מילה", "תלמיד", "עיר", "שמיים",
+			"ים", "יפה", "גדול", "קטן", "חדש",
+			"ישן", "יום", "לילה", "זמן", "חבר",
+			"עבודה", "חיים", "לב", "אור", "דרך",
+			"דלת", "עץ", "פרח", "שָׁלוֹם", "בְּרֵאשִׁית",
+		},
+		separator:  " ",
+		terminator: ".",
+		cased:      false,
+	},
+	JapaneseCorpus: {
+		words: []string{
+			"私", "猫", "日本", "東京", "さくら",
+			"ありがとう", "コンピューター", "テスト", "言葉", "学校",
+			"先生", "友達", "水", "山", "川",
+			"空", "花", "食べる", "見る", "行く",
+			"新しい", "美しい", "時間", "今日", "明日",
+			"ラーメン", "カタカナ", "ひらがな", "漢字", "は",
+			"が", "を", "に", "で", "と",
+			"の", "も", "ゲーム", "音楽", "世界",
+			"電車", "ｶﾀｶﾅ", "ﾃｽﾄ", "富士山", "おはよう",
+		},
+		separator:  "",
+		terminator: "。",
+		cased:      false,
+	},
+	ChineseCorpus: {
+		words: []string{
+			"我", "你", "他", "的", "是", "在", "有", "人", "中国", "北京",
+			"上海", "学习", "工作", "朋友", "时间", "今天", "明天", "喜欢", "吃饭", "喝茶",
+			"水", "山", "河", "天空", "花", "书", "电脑", "手机", "世界", "语言",
+			"汉字", "美丽", "快乐", "新", "大", "小", "和", "也", "很", "了",
+			"繁體", "龍", "學習", "臺灣",
+		},
+		separator:  "",
+		terminator: "。",
+		cased:      false,
+	},
+	EmojiCorpus: {
+		words: []string{
+			"😀", "🎉", "🚀", "👍🏽", "👨‍👩‍👧‍👦",
+			"🏳️‍🌈", "❤️", "🔥", "🇩🇪", "🇯🇵",
+			"✨", "🍕", "🐱", "🌍", "💡",
+			"🤖", "🧑🏿‍💻", "✅", "📦", "⭐",
+			"🙈", "☕", "👩‍🚀", "1️⃣", "🫶🏻",
+			"🥳", "💯", "🌈", "🎸", "🦄",
+			"hello", "party", "launch", "love", "pizza",
+			"cat", "world", "idea", "robot", "done",
+			"ship", "star", "wow", "nice", "coffee",
+		},
+		separator:  " ",
+		terminator: "!",
+		cased:      false,
+	},
+}
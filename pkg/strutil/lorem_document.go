@@ -0,0 +1,93 @@
+package strutil
+
+// LoremDocumentOptions controls the shape of structured lorem documents
+// produced by LoremMarkdown and LoremHTML.
+type LoremDocumentOptions struct {
+	Sections   int  // number of second-level sections following the title
+	Paragraphs int  // number of paragraphs per section
+	ListItems  int  // number of items in each section's list, 0 disables lists
+	CodeBlocks bool // include a fenced code block (Markdown) or pre/code block (HTML) per section
+	Links      bool // include an inline link in each paragraph
+	Emphasis   bool // include emphasis, strong emphasis and inline code in each paragraph
+}
+
+// NewLoremDocumentOptions returns LoremDocumentOptions populated with sensible defaults:
+// 3 sections of 2 paragraphs, 4 list items, and code blocks, links and emphasis enabled.
+func NewLoremDocumentOptions() LoremDocumentOptions {
+	return LoremDocumentOptions{
+		Sections:   3,
+		Paragraphs: 2,
+		ListItems:  4,
+		CodeBlocks: true,
+		Links:      true,
+		Emphasis:   true,
+	}
+}
+
+// LoremFieldType identifies the kind of lorem value used to fill a field in a LoremSchema.
+type LoremFieldType int
+
+// String returns the string representation of the LoremFieldType using LoremFieldTypeMap.
+func (l LoremFieldType) String() string {
+	return LoremFieldTypeMap[l]
+}
+
+// LoremFieldWord fills a field with a single lorem word.
+// LoremFieldWords fills a field with a short run of lorem words.
+// LoremFieldSentence fills a field with a lorem sentence.
+// LoremFieldParagraph fills a field with a lorem paragraph.
+// LoremFieldInt fills a field with a random non-negative integer.
+// LoremFieldFloat fills a field with a random float between 0 and 1000.
+// LoremFieldBool fills a field with a random boolean.
+// LoremFieldEmail fills a field with a placeholder email address.
+// LoremFieldURL fills a field with a placeholder URL.
+// LoremFieldDomain fills a field with a placeholder domain.
+// LoremFieldUUID fills a field with a random UUID.
+const (
+	LoremFieldWord LoremFieldType = iota
+	LoremFieldWords
+	LoremFieldSentence
+	LoremFieldParagraph
+	LoremFieldInt
+	LoremFieldFloat
+	LoremFieldBool
+	LoremFieldEmail
+	LoremFieldURL
+	LoremFieldDomain
+	LoremFieldUUID
+)
+
+// LoremFieldTypeMap maps LoremFieldType constants to their corresponding string representations.
+var LoremFieldTypeMap = map[LoremFieldType]string{
+	LoremFieldWord:      "Word",
+	LoremFieldWords:     "Words",
+	LoremFieldSentence:  "Sentence",
+	LoremFieldParagraph: "Paragraph",
+	LoremFieldInt:       "Int",
+	LoremFieldFloat:     "Float",
+	LoremFieldBool:      "Bool",
+	LoremFieldEmail:     "Email",
+	LoremFieldURL:       "URL",
+	LoremFieldDomain:    "Domain",
+	LoremFieldUUID:      "UUID",
+}
+
+// LoremSchema describes a JSON object to be filled with lorem values by LoremJSON.
+// Each value must be a LoremFieldType, a nested LoremSchema, or a LoremArray.
+//
+//	LoremSchema{
+//		"id":    LoremFieldUUID,
+//		"title": LoremFieldSentence,
+//		"tags":  LoremArray{Of: LoremFieldWord, Count: 3},
+//		"author": LoremSchema{
+//			"email": LoremFieldEmail,
+//		},
+//	}
+type LoremSchema map[string]any
+
+// LoremArray describes a JSON array of Count elements, each generated from Of.
+// Of may be a LoremFieldType, a LoremSchema, or another LoremArray.
+type LoremArray struct {
+	Of    any
+	Count int
+}
@@ -1,9 +1,17 @@
 package strutil
 
 import (
+	"encoding/json"
+	"fmt"
+	"html"
 	"math/rand"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	lorelai "github.com/UltiRequiem/lorelai/pkg"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
 )
 
 // loremWord generates and returns a single random word as a string.
@@ -116,3 +124,351 @@ func loremURL() string {
 func loremEmail() string {
 	return lorelai.Email()
 }
+
+// loremTitle generates a title-cased run of lorem words of the given length.
+func loremTitle(length int) string {
+	return toTitleCase(trim(loremWords(length)))
+}
+
+// loremMarkdown generates a Markdown document made up of a title, an introduction and
+// the number of sections described by opts.
+// Returns an empty string if opts.Sections is less than 1.
+func loremMarkdown(opts LoremDocumentOptions) string {
+	if opts.Sections < 1 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("# " + loremTitle(4) + "\n\n")
+	b.WriteString(loremDecoratedParagraph(opts, false) + "\n")
+	for i := 0; i < opts.Sections; i++ {
+		b.WriteString("\n## " + loremTitle(3) + "\n\n")
+		for j := 0; j < opts.Paragraphs; j++ {
+			b.WriteString(loremDecoratedParagraph(opts, false) + "\n\n")
+		}
+		for j := 0; j < opts.ListItems; j++ {
+			if i%2 == 0 {
+				b.WriteString("- " + loremSentence() + "\n")
+			} else {
+				b.WriteString(fmt.Sprintf("%d. %s\n", j+1, loremSentence()))
+			}
+		}
+		if opts.ListItems > 0 {
+			b.WriteString("\n")
+		}
+		if opts.CodeBlocks {
+			b.WriteString("```go\n" + loremCode() + "```\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// loremHTML generates an HTML article made up of a header, an introduction and
+// the number of sections described by opts.
+// Returns an empty string if opts.Sections is less than 1.
+func loremHTML(opts LoremDocumentOptions) string {
+	if opts.Sections < 1 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("<article>\n")
+	b.WriteString("<header><h1>" + html.EscapeString(loremTitle(4)) + "</h1></header>\n")
+	b.WriteString("<p>" + loremDecoratedParagraph(opts, true) + "</p>\n")
+	for i := 0; i < opts.Sections; i++ {
+		b.WriteString("<section>\n")
+		b.WriteString("<h2>" + html.EscapeString(loremTitle(3)) + "</h2>\n")
+		for j := 0; j < opts.Paragraphs; j++ {
+			b.WriteString("<p>" + loremDecoratedParagraph(opts, true) + "</p>\n")
+		}
+		if opts.ListItems > 0 {
+			tag := "ul"
+			if i%2 != 0 {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for j := 0; j < opts.ListItems; j++ {
+				b.WriteString("<li>" + html.EscapeString(loremSentence()) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		}
+		if opts.CodeBlocks {
+			b.WriteString("<pre><code>" + html.EscapeString(loremCode()) + "</code></pre>\n")
+		}
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</article>\n")
+	return b.String()
+}
+
+// loremDecoratedParagraph generates a lorem paragraph and, depending on opts, wraps
+// distinct words in emphasis, strong emphasis, inline code and a link.
+// Markup is rendered as HTML when asHTML is true, otherwise as Markdown.
+func loremDecoratedParagraph(opts LoremDocumentOptions, asHTML bool) string {
+	words := strings.Fields(loremParagraph())
+	if asHTML {
+		for i, w := range words {
+			words[i] = html.EscapeString(w)
+		}
+	}
+	var decorations []func(string) string
+	if opts.Emphasis {
+		if asHTML {
+			decorations = append(decorations,
+				func(w string) string { return "<em>" + w + "</em>" },
+				func(w string) string { return "<strong>" + w + "</strong>" },
+				func(w string) string { return "<code>" + w + "</code>" })
+		} else {
+			decorations = append(decorations,
+				func(w string) string { return "*" + w + "*" },
+				func(w string) string { return "**" + w + "**" },
+				func(w string) string { return "`" + w + "`" })
+		}
+	}
+	if opts.Links {
+		url := loremURL()
+		if asHTML {
+			decorations = append(decorations,
+				func(w string) string { return `<a href="` + html.EscapeString(url) + `">` + w + "</a>" })
+		} else {
+			decorations = append(decorations,
+				func(w string) string { return "[" + w + "](" + url + ")" })
+		}
+	}
+	// each decoration is applied to a distinct word, skipping the leading capitalized word
+	if len(decorations) > 0 && len(words) > len(decorations) {
+		for i, idx := range rand.Perm(len(words) - 1)[:len(decorations)] {
+			w := words[idx+1]
+			trimmed := strings.TrimRight(w, ".,;:!?")
+			words[idx+1] = decorations[i](trimmed) + w[len(trimmed):]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// loremCode generates a short Go-like code snippet using lorem words as identifiers.
+func loremCode() string {
+	name := toCamelCase(trim(loremWords(2)))
+	return fmt.Sprintf("func %s() string {\n\treturn %q\n}\n", name, trim(loremWords(3)))
+}
+
+// loremJSON fills the provided schema with lorem values and returns the result as indented JSON.
+// Returns an error if the schema is nil or contains an unsupported value.
+func loremJSON(schema LoremSchema) (string, error) {
+	if schema == nil {
+		return "", errors.ErrInvalidLoremSchema
+	}
+	value, err := loremSchemaValue(schema)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// loremSchemaValue generates the lorem value described by a schema node,
+// recursing into nested schemas and arrays.
+func loremSchemaValue(node any) (any, error) {
+	switch n := node.(type) {
+	case LoremFieldType:
+		return loremFieldValue(n)
+	case LoremSchema:
+		obj := make(map[string]any, len(n))
+		for key, child := range n {
+			v, err := loremSchemaValue(child)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = v
+		}
+		return obj, nil
+	case map[string]any:
+		return loremSchemaValue(LoremSchema(n))
+	case LoremArray:
+		if n.Count < 0 {
+			return nil, errors.ErrInvalidLoremSchema
+		}
+		arr := make([]any, 0, n.Count)
+		for i := 0; i < n.Count; i++ {
+			v, err := loremSchemaValue(n.Of)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	default:
+		return nil, errors.ErrInvalidLoremSchema
+	}
+}
+
+// loremFieldValue generates a single lorem value of the given LoremFieldType.
+func loremFieldValue(field LoremFieldType) (any, error) {
+	switch field {
+	case LoremFieldWord:
+		return loremWord(), nil
+	case LoremFieldWords:
+		return trim(loremWords(rand.Intn(4) + 2)), nil
+	case LoremFieldSentence:
+		return loremSentence(), nil
+	case LoremFieldParagraph:
+		return loremParagraph(), nil
+	case LoremFieldInt:
+		return rand.Intn(10000), nil
+	case LoremFieldFloat:
+		return rand.Float64() * 1000, nil
+	case LoremFieldBool:
+		return rand.Intn(2) == 1, nil
+	case LoremFieldEmail:
+		return loremEmail(), nil
+	case LoremFieldURL:
+		return loremURL(), nil
+	case LoremFieldDomain:
+		return loremDomain(), nil
+	case LoremFieldUUID:
+		return makeUUID(), nil
+	default:
+		return nil, errors.ErrInvalidLoremSchema
+	}
+}
+
+// loremWordFrom returns a single random word from the given corpus.
+// Returns an empty string if the corpus is unknown.
+func loremWordFrom(corpus LoremCorpus) string {
+	if corpus == LatinCorpus {
+		return loremWord()
+	}
+	data, ok := loremCorpora[corpus]
+	if !ok {
+		return ""
+	}
+	return data.words[rand.Intn(len(data.words))]
+}
+
+// loremWordsFrom returns count random words from the given corpus joined by the corpus separator.
+// The Latin corpus matches loremWords exactly. Returns an empty string if count is less than 1
+// or the corpus is unknown.
+func loremWordsFrom(corpus LoremCorpus, count int) string {
+	if count < 1 {
+		return ""
+	}
+	if corpus == LatinCorpus {
+		return loremWords(count)
+	}
+	data, ok := loremCorpora[corpus]
+	if !ok {
+		return ""
+	}
+	words := make([]string, count)
+	for i := range words {
+		words[i] = data.words[rand.Intn(len(data.words))]
+	}
+	return strings.Join(words, data.separator)
+}
+
+// loremSentenceFrom returns an 8 word sentence from the given corpus.
+func loremSentenceFrom(corpus LoremCorpus) string {
+	if corpus == LatinCorpus {
+		return loremSentence()
+	}
+	return loremSentenceCustomFrom(corpus, 8)
+}
+
+// loremSentenceCustomFrom returns a sentence of the given word length from the given corpus.
+// Sentences in cased scripts begin with an uppercase letter and every sentence ends with the
+// corpus terminator. Returns an empty string if length is less than 1 or the corpus is unknown.
+func loremSentenceCustomFrom(corpus LoremCorpus, length int) string {
+	if length < 1 {
+		return ""
+	}
+	if corpus == LatinCorpus {
+		return loremSentenceCustom(length)
+	}
+	data, ok := loremCorpora[corpus]
+	if !ok {
+		return ""
+	}
+	sentence := loremWordsFrom(corpus, length)
+	if data.cased {
+		r, size := utf8.DecodeRuneInString(sentence)
+		sentence = string(unicode.ToUpper(r)) + sentence[size:]
+	}
+	return sentence + data.terminator
+}
+
+// loremSentencesFrom returns count 8 word sentences from the given corpus.
+func loremSentencesFrom(corpus LoremCorpus, count int) string {
+	return joinLoremSentences(corpus, count, func() string {
+		return loremSentenceFrom(corpus)
+	})
+}
+
+// loremSentencesCustomFrom returns count sentences of the given word length from the given corpus.
+func loremSentencesCustomFrom(corpus LoremCorpus, count int, length int) string {
+	if length < 1 {
+		return ""
+	}
+	return joinLoremSentences(corpus, count, func() string {
+		return loremSentenceCustomFrom(corpus, length)
+	})
+}
+
+// loremSentencesVariableFrom returns count sentences from the given corpus,
+// each between min and max words long inclusive.
+func loremSentencesVariableFrom(corpus LoremCorpus, count, min, max int) string {
+	if min < 1 || min > max {
+		return ""
+	}
+	return joinLoremSentences(corpus, count, func() string {
+		return loremSentenceCustomFrom(corpus, rand.Intn(max-min+1)+min)
+	})
+}
+
+// loremParagraphFrom returns a 45 word paragraph from the given corpus.
+// Non-Latin paragraphs are made up of five 9 word sentences.
+func loremParagraphFrom(corpus LoremCorpus) string {
+	if corpus == LatinCorpus {
+		return loremParagraph()
+	}
+	return loremSentencesCustomFrom(corpus, 5, 9)
+}
+
+// loremParagraphsFrom returns count paragraphs from the given corpus separated by blank lines.
+// Returns an empty string if count is less than 1 or the corpus is unknown.
+func loremParagraphsFrom(corpus LoremCorpus, count int) string {
+	if count < 1 || !isLoremCorpus(corpus) {
+		return ""
+	}
+	paragraphs := make([]string, count)
+	for i := range paragraphs {
+		paragraphs[i] = loremParagraphFrom(corpus)
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// joinLoremSentences calls next count times and joins the sentences using the corpus separator.
+// Returns an empty string if count is less than 1 or the corpus is unknown.
+func joinLoremSentences(corpus LoremCorpus, count int, next func() string) string {
+	if count < 1 || !isLoremCorpus(corpus) {
+		return ""
+	}
+	separator := " "
+	if data, ok := loremCorpora[corpus]; ok {
+		separator = data.separator
+	}
+	sentences := make([]string, count)
+	for i := range sentences {
+		sentences[i] = next()
+	}
+	return strings.Join(sentences, separator)
+}
+
+// isLoremCorpus reports whether the corpus is Latin or has registered corpus data.
+func isLoremCorpus(corpus LoremCorpus) bool {
+	if corpus == LatinCorpus {
+		return true
+	}
+	_, ok := loremCorpora[corpus]
+	return ok
+}
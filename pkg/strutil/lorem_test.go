@@ -1,8 +1,15 @@
 package strutil
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
 )
 
 func TestLoremWord(t *testing.T) {
@@ -429,3 +436,370 @@ func TestLoremEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestLoremMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     LoremDocumentOptions
+		sections int
+	}{
+		{"LoremMarkdownDefault", NewLoremDocumentOptions(), 3},
+		{"LoremMarkdownPlain", LoremDocumentOptions{Sections: 2, Paragraphs: 1}, 2},
+		{"LoremMarkdownZero", LoremDocumentOptions{}, 0},
+		{"LoremMarkdownNegative", LoremDocumentOptions{Sections: -1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := loremMarkdown(tt.opts)
+			result := LoremMarkdown(tt.opts)
+			builderResult := NewLoremMarkdown(tt.opts).String()
+			for _, doc := range []string{helperResult, result, builderResult} {
+				if tt.sections == 0 {
+					if doc != "" {
+						t.Errorf("LoremMarkdown - expected empty document, got %q", doc)
+					}
+					continue
+				}
+				if !strings.HasPrefix(doc, "# ") {
+					t.Errorf("LoremMarkdown - missing title: %q", doc)
+				}
+				if strings.Count(doc, "\n## ") != tt.sections {
+					t.Errorf("LoremMarkdown - expected %d sections: %q", tt.sections, doc)
+				}
+				hasList := strings.Contains(doc, "\n- ")
+				hasCode := strings.Contains(doc, "```go\n")
+				hasLink := strings.Contains(doc, "](http")
+				hasEmphasis := strings.Contains(doc, "**") && strings.Contains(doc, "`")
+				if hasList != (tt.opts.ListItems > 0) ||
+					hasCode != tt.opts.CodeBlocks ||
+					hasLink != tt.opts.Links ||
+					hasEmphasis != tt.opts.Emphasis {
+					t.Errorf("LoremMarkdown - list %t code %t link %t emphasis %t do not match options %+v",
+						hasList, hasCode, hasLink, hasEmphasis, tt.opts)
+				}
+			}
+		})
+	}
+}
+
+func TestLoremHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     LoremDocumentOptions
+		sections int
+	}{
+		{"LoremHTMLDefault", NewLoremDocumentOptions(), 3},
+		{"LoremHTMLPlain", LoremDocumentOptions{Sections: 1, Paragraphs: 3}, 1},
+		{"LoremHTMLZero", LoremDocumentOptions{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := loremHTML(tt.opts)
+			result := LoremHTML(tt.opts)
+			builderResult := NewLoremHTML(tt.opts).String()
+			for _, doc := range []string{helperResult, result, builderResult} {
+				if tt.sections == 0 {
+					if doc != "" {
+						t.Errorf("LoremHTML - expected empty document, got %q", doc)
+					}
+					continue
+				}
+				if !strings.HasPrefix(doc, "<article>") || !strings.HasSuffix(doc, "</article>\n") {
+					t.Errorf("LoremHTML - missing article: %q", doc)
+				}
+				if strings.Count(doc, "<section>") != tt.sections || strings.Count(doc, "</section>") != tt.sections {
+					t.Errorf("LoremHTML - expected %d sections: %q", tt.sections, doc)
+				}
+				if strings.Contains(doc, "<a href=") != tt.opts.Links ||
+					strings.Contains(doc, "<strong>") != tt.opts.Emphasis ||
+					strings.Contains(doc, "<pre><code>") != tt.opts.CodeBlocks ||
+					strings.Contains(doc, "<li>") != (tt.opts.ListItems > 0) {
+					t.Errorf("LoremHTML - elements do not match options %+v: %q", tt.opts, doc)
+				}
+				if removeHTML(doc, true) == "" || !strings.Contains(sanitizeHTML(doc), "<p>") {
+					t.Errorf("LoremHTML - unexpected sanitization output: %q", doc)
+				}
+			}
+		})
+	}
+}
+
+func TestLoremJSON(t *testing.T) {
+	schema := LoremSchema{
+		"id":     LoremFieldUUID,
+		"title":  LoremFieldSentence,
+		"body":   LoremFieldParagraph,
+		"count":  LoremFieldInt,
+		"rating": LoremFieldFloat,
+		"active": LoremFieldBool,
+		"tags":   LoremArray{Of: LoremFieldWord, Count: 3},
+		"author": LoremSchema{
+			"email":   LoremFieldEmail,
+			"website": LoremFieldURL,
+			"domain":  LoremFieldDomain,
+			"name":    LoremFieldWords,
+		},
+		"comments": LoremArray{Of: LoremSchema{"text": LoremFieldSentence}, Count: 2},
+	}
+	tests := []struct {
+		name   string
+		schema LoremSchema
+		err    error
+	}{
+		{"LoremJSONValid", schema, nil},
+		{"LoremJSONEmpty", LoremSchema{}, nil},
+		{"LoremJSONNil", nil, errors2.ErrInvalidLoremSchema},
+		{"LoremJSONBadType", LoremSchema{"bad": "string"}, errors2.ErrInvalidLoremSchema},
+		{"LoremJSONBadField", LoremSchema{"bad": LoremFieldType(99)}, errors2.ErrInvalidLoremSchema},
+		{"LoremJSONBadArray", LoremSchema{"bad": LoremArray{Of: LoremFieldWord, Count: -1}}, errors2.ErrInvalidLoremSchema},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult, helperErr := loremJSON(tt.schema)
+			result, err := LoremJSON(tt.schema)
+			builder := NewLoremJSON(tt.schema)
+			if !errors.Is(helperErr, tt.err) || !errors.Is(err, tt.err) || !errors.Is(builder.Error(), tt.err) {
+				t.Fatalf("LoremJSON - expected error %v, got %v / %v / %v", tt.err, helperErr, err, builder.Error())
+			}
+			if tt.err != nil {
+				if helperResult != "" || result != "" || builder.String() != "" {
+					t.Errorf("LoremJSON - expected empty output on error")
+				}
+				return
+			}
+			for _, doc := range []string{helperResult, result, builder.String()} {
+				var decoded map[string]any
+				if err := json.Unmarshal([]byte(doc), &decoded); err != nil {
+					t.Fatalf("LoremJSON - invalid JSON %q: %v", doc, err)
+				}
+				if len(decoded) != len(tt.schema) {
+					t.Errorf("LoremJSON - expected %d keys, got %d", len(tt.schema), len(decoded))
+				}
+				if len(tt.schema) == 0 {
+					continue
+				}
+				if !isUUID(decoded["id"].(string)) {
+					t.Errorf("LoremJSON - invalid uuid %v", decoded["id"])
+				}
+				if _, ok := decoded["count"].(float64); !ok {
+					t.Errorf("LoremJSON - count is not a number: %v", decoded["count"])
+				}
+				if _, ok := decoded["active"].(bool); !ok {
+					t.Errorf("LoremJSON - active is not a bool: %v", decoded["active"])
+				}
+				if tags, ok := decoded["tags"].([]any); !ok || len(tags) != 3 {
+					t.Errorf("LoremJSON - unexpected tags: %v", decoded["tags"])
+				}
+				author, ok := decoded["author"].(map[string]any)
+				if !ok || !isEmail(author["email"].(string)) || !isURL(author["website"].(string)) {
+					t.Errorf("LoremJSON - unexpected author: %v", decoded["author"])
+				}
+				if comments, ok := decoded["comments"].([]any); !ok || len(comments) != 2 {
+					t.Errorf("LoremJSON - unexpected comments: %v", decoded["comments"])
+				}
+			}
+		})
+	}
+}
+
+func TestLoremWordFrom(t *testing.T) {
+	for corpus, name := range LoremCorpusMap {
+		t.Run("LoremWordFrom"+name, func(t *testing.T) {
+			helperResult := loremWordFrom(corpus)
+			result := LoremWordFrom(corpus)
+			builderResult := NewLoremWordFrom(corpus).String()
+			if helperResult == "" || result == "" || builderResult == "" {
+				t.Errorf("LoremWordFrom(%s) - %q, %q, %q", name, result, helperResult, builderResult)
+			}
+			if data, ok := loremCorpora[corpus]; ok {
+				if !slices.Contains(data.words, helperResult) ||
+					!slices.Contains(data.words, result) ||
+					!slices.Contains(data.words, builderResult) {
+					t.Errorf("LoremWordFrom(%s) - word not in corpus: %q, %q, %q",
+						name, result, helperResult, builderResult)
+				}
+			}
+		})
+	}
+	if LoremWordFrom(LoremCorpus(99)) != "" || NewLoremWordFrom(LoremCorpus(99)).String() != "" {
+		t.Errorf("LoremWordFrom - expected empty result for unknown corpus")
+	}
+}
+
+func TestLoremWordsFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		corpus LoremCorpus
+		count  int
+	}{
+		{"LoremWordsFromEnglish", EnglishCorpus, 10},
+		{"LoremWordsFromGerman", GermanCorpus, 25},
+		{"LoremWordsFromRussian", RussianCorpus, 1},
+		{"LoremWordsFromArabic", ArabicCorpus, 7},
+		{"LoremWordsFromEmoji", EmojiCorpus, 12},
+		{"LoremWordsFromZero", FrenchCorpus, 0},
+		{"LoremWordsFromNegative", GreekCorpus, -1},
+		{"LoremWordsFromUnknown", LoremCorpus(99), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := loremWordsFrom(tt.corpus, tt.count)
+			result := LoremWordsFrom(tt.corpus, tt.count)
+			builderResult := NewLoremWordsFrom(tt.corpus, tt.count).String()
+			expected := tt.count
+			if tt.count < 1 || !isLoremCorpus(tt.corpus) {
+				expected = 0
+			}
+			for _, r := range []string{helperResult, result, builderResult} {
+				if len(strings.Fields(r)) != expected {
+					t.Errorf("LoremWordsFrom(%s, %d) - expected %d words, got %q",
+						tt.corpus, tt.count, expected, r)
+				}
+			}
+		})
+	}
+}
+
+func TestLoremSentenceFrom(t *testing.T) {
+	for corpus, name := range LoremCorpusMap {
+		t.Run("LoremSentenceFrom"+name, func(t *testing.T) {
+			helperResult := loremSentenceFrom(corpus)
+			result := LoremSentenceFrom(corpus)
+			builderResult := NewLoremSentenceFrom(corpus).String()
+			data, ok := loremCorpora[corpus]
+			if !ok {
+				data = loremCorpusData{separator: " ", terminator: ".", cased: true}
+			}
+			for _, r := range []string{helperResult, result, builderResult} {
+				if !utf8.ValidString(r) || !strings.HasSuffix(r, data.terminator) {
+					t.Errorf("LoremSentenceFrom(%s) - invalid sentence %q", name, r)
+				}
+				if data.separator == " " && len(strings.Fields(r)) != 8 {
+					t.Errorf("LoremSentenceFrom(%s) - expected 8 words, got %q", name, r)
+				}
+				first, _ := utf8.DecodeRuneInString(r)
+				if data.cased && unicode.IsLetter(first) && !unicode.IsUpper(first) {
+					t.Errorf("LoremSentenceFrom(%s) - not capitalized %q", name, r)
+				}
+			}
+		})
+	}
+}
+
+func TestLoremSentencesCustomFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		corpus   LoremCorpus
+		count    int
+		length   int
+		expected int
+	}{
+		{"LoremSentencesCustomFromLatin", LatinCorpus, 3, 7, 21},
+		{"LoremSentencesCustomFromEnglish", EnglishCorpus, 3, 7, 21},
+		{"LoremSentencesCustomFromGreek", GreekCorpus, 5, 4, 20},
+		{"LoremSentencesCustomFromHebrew", HebrewCorpus, 2, 10, 20},
+		{"LoremSentencesCustomFromZeroCount", FrenchCorpus, 0, 5, 0},
+		{"LoremSentencesCustomFromZeroLength", FrenchCorpus, 3, 0, 0},
+		{"LoremSentencesCustomFromUnknown", LoremCorpus(-1), 3, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := loremSentencesCustomFrom(tt.corpus, tt.count, tt.length)
+			result := LoremSentencesCustomFrom(tt.corpus, tt.count, tt.length)
+			builderResult := NewLoremSentencesCustomFrom(tt.corpus, tt.count, tt.length).String()
+			for _, r := range []string{helperResult, result, builderResult} {
+				if len(strings.Fields(r)) != tt.expected {
+					t.Errorf("LoremSentencesCustomFrom - expected %d words, got %q", tt.expected, r)
+				}
+			}
+			sentences := loremSentencesFrom(tt.corpus, tt.count)
+			if tt.expected > 0 && len(strings.Fields(sentences)) != tt.count*8 {
+				t.Errorf("LoremSentencesFrom - expected %d words, got %q", tt.count*8, sentences)
+			}
+		})
+	}
+}
+
+func TestLoremSentencesVariableFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		corpus LoremCorpus
+		count  int
+		min    int
+		max    int
+	}{
+		{"LoremSentencesVariableFromEnglish", EnglishCorpus, 4, 2, 6},
+		{"LoremSentencesVariableFromEqual", RussianCorpus, 3, 5, 5},
+		{"LoremSentencesVariableFromInvalid", GermanCorpus, 3, 6, 2},
+		{"LoremSentencesVariableFromZeroMin", GermanCorpus, 3, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := loremSentencesVariableFrom(tt.corpus, tt.count, tt.min, tt.max)
+			result := LoremSentencesVariableFrom(tt.corpus, tt.count, tt.min, tt.max)
+			builderResult := NewLoremSentencesVariableFrom(tt.corpus, tt.count, tt.min, tt.max).String()
+			for _, r := range []string{helperResult, result, builderResult} {
+				words := len(strings.Fields(r))
+				if tt.min < 1 || tt.min > tt.max {
+					if r != "" {
+						t.Errorf("LoremSentencesVariableFrom - expected empty result, got %q", r)
+					}
+					continue
+				}
+				if words < tt.count*tt.min || words > tt.count*tt.max {
+					t.Errorf("LoremSentencesVariableFrom - %d words outside %d-%d: %q",
+						words, tt.count*tt.min, tt.count*tt.max, r)
+				}
+			}
+		})
+	}
+}
+
+func TestLoremParagraphsFrom(t *testing.T) {
+	for corpus, name := range LoremCorpusMap {
+		t.Run("LoremParagraphsFrom"+name, func(t *testing.T) {
+			helperResult := loremParagraphsFrom(corpus, 3)
+			result := LoremParagraphsFrom(corpus, 3)
+			builderResult := NewLoremParagraphsFrom(corpus, 3).String()
+			paragraph := LoremParagraphFrom(corpus)
+			builderParagraph := NewLoremParagraphFrom(corpus).String()
+			for _, r := range []string{helperResult, result, builderResult} {
+				if len(strings.Split(r, "\n\n")) != 3 {
+					t.Errorf("LoremParagraphsFrom(%s) - expected 3 paragraphs, got %q", name, r)
+				}
+			}
+			if corpus != JapaneseCorpus && corpus != ChineseCorpus &&
+				(len(strings.Fields(paragraph)) != 45 || len(strings.Fields(builderParagraph)) != 45) {
+				t.Errorf("LoremParagraphFrom(%s) - expected 45 words: %q / %q", name, paragraph, builderParagraph)
+			}
+		})
+	}
+	if LoremParagraphsFrom(EnglishCorpus, 0) != "" || LoremParagraphsFrom(LoremCorpus(99), 2) != "" {
+		t.Errorf("LoremParagraphsFrom - expected empty result for invalid input")
+	}
+}
+
+func TestLoremCorpusTransforms(t *testing.T) {
+	tests := []struct {
+		name   string
+		corpus LoremCorpus
+	}{
+		{"GermanDiacritics", GermanCorpus},
+		{"FrenchDiacritics", FrenchCorpus},
+		{"GreekDiacritics", GreekCorpus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := LoremParagraphFrom(tt.corpus)
+			if normalized := NormalizeDiacritics(s); !utf8.ValidString(normalized) || normalized == "" {
+				t.Errorf("NormalizeDiacritics(%s) - unexpected result %q", tt.corpus, normalized)
+			}
+			if slug := Slugify(s, 200); strings.ContainsAny(slug, " .") {
+				t.Errorf("Slugify(%s) - unexpected result %q", tt.corpus, slug)
+			}
+		})
+	}
+	if LoremCorpus(99).String() != "" || EmojiCorpus.String() != "Emoji" {
+		t.Errorf("LoremCorpus.String - unexpected names")
+	}
+}
@@ -0,0 +1,158 @@
+package strutil
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// LSHIndex retrieves near-duplicate candidates for MinHash signatures with locality-sensitive hashing.
+// Each signature is split into bands of rows; two signatures become candidates when all rows of at least one
+// band are equal, which happens with high probability once their Jaccard similarity exceeds Threshold.
+// An LSHIndex is safe for concurrent use.
+type LSHIndex struct {
+	mu      sync.RWMutex
+	bands   int
+	rows    int
+	ngram   int
+	buckets []map[uint64][]string
+	keys    map[string][]uint64
+}
+
+// NewLSHIndex creates an empty LSHIndex for signatures of bands*rows hashes.
+// Returns ErrInvalidLSHBands if bands or rows is less than 1.
+func NewLSHIndex(bands, rows int) (*LSHIndex, error) {
+	if bands < 1 || rows < 1 {
+		return nil, errors.ErrInvalidLSHBands
+	}
+	li := &LSHIndex{
+		bands:   bands,
+		rows:    rows,
+		buckets: make([]map[uint64][]string, bands),
+		keys:    make(map[string][]uint64),
+	}
+	for b := range li.buckets {
+		li.buckets[b] = make(map[uint64][]string)
+	}
+	return li, nil
+}
+
+// Bands returns the number of bands each signature is split into.
+func (li *LSHIndex) Bands() int {
+	return li.bands
+}
+
+// Rows returns the number of signature hashes in each band.
+func (li *LSHIndex) Rows() int {
+	return li.rows
+}
+
+// Threshold returns the approximate Jaccard similarity (1/bands)^(1/rows) at which two signatures have an even
+// chance of becoming candidates. Pairs well above it are almost always found and pairs well below rarely are.
+func (li *LSHIndex) Threshold() float64 {
+	return math.Pow(1/float64(li.bands), 1/float64(li.rows))
+}
+
+// Len returns the number of signatures in the index.
+func (li *LSHIndex) Len() int {
+	li.mu.RLock()
+	defer li.mu.RUnlock()
+	return len(li.keys)
+}
+
+// Add indexes signature under id, replacing any signature previously added with the same id.
+// Returns ErrSignatureMismatch if the signature does not have bands*rows hashes or uses a different shingle
+// length than the signatures already indexed, or the error of the signature.
+func (li *LSHIndex) Add(id string, signature *MinHashResult) error {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	keys, err := li.bandKeys(signature)
+	if err != nil {
+		return err
+	}
+	li.remove(id)
+	if len(li.keys) == 0 {
+		li.ngram = signature.GetNgramLength()
+	}
+	for b, key := range keys {
+		li.buckets[b][key] = append(li.buckets[b][key], id)
+	}
+	li.keys[id] = keys
+	return nil
+}
+
+// Remove deletes the signature indexed under id and reports whether it was present.
+func (li *LSHIndex) Remove(id string) bool {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	return li.remove(id)
+}
+
+// Query returns the ids of every indexed signature that shares at least one band with signature, sorted
+// alphabetically. The candidates should be verified with MinHashResult.Jaccard or an exact comparison.
+// Returns the same errors as Add.
+func (li *LSHIndex) Query(signature *MinHashResult) ([]string, error) {
+	li.mu.RLock()
+	defer li.mu.RUnlock()
+	keys, err := li.bandKeys(signature)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var candidates []string
+	for b, key := range keys {
+		for _, id := range li.buckets[b][key] {
+			if !seen[id] {
+				seen[id] = true
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	slices.Sort(candidates)
+	return candidates, nil
+}
+
+// remove deletes id from its buckets. The caller must hold the write lock.
+func (li *LSHIndex) remove(id string) bool {
+	keys, ok := li.keys[id]
+	if !ok {
+		return false
+	}
+	for b, key := range keys {
+		bucket := slices.DeleteFunc(li.buckets[b][key], func(other string) bool { return other == id })
+		if len(bucket) == 0 {
+			delete(li.buckets[b], key)
+		} else {
+			li.buckets[b][key] = bucket
+		}
+	}
+	delete(li.keys, id)
+	return true
+}
+
+// bandKeys checks that signature fits the index and hashes the rows of each band into one key.
+// The caller must hold a lock.
+func (li *LSHIndex) bandKeys(signature *MinHashResult) ([]uint64, error) {
+	if signature == nil {
+		return nil, errors.ErrSignatureMismatch
+	}
+	if signature.GetError() != nil {
+		return nil, signature.GetError()
+	}
+	if len(signature.signature) != li.bands*li.rows || (len(li.keys) > 0 && signature.GetNgramLength() != li.ngram) {
+		return nil, errors.ErrSignatureMismatch
+	}
+	keys := make([]uint64, li.bands)
+	buf := make([]byte, 0, 8*li.rows)
+	for b := range keys {
+		buf = buf[:0]
+		for _, v := range signature.signature[b*li.rows : (b+1)*li.rows] {
+			buf = binary.BigEndian.AppendUint64(buf, v)
+		}
+		keys[b] = shingleHash(string(buf))
+	}
+	return keys, nil
+}
@@ -0,0 +1,24 @@
+package strutil
+
+// StripMarkdown renders the Markdown s as plain text. Emphasis and code markers are removed, links are replaced
+// by their text and images by their alt text, the contents of code blocks are kept with their whitespace,
+// headings and paragraphs are separated by blank lines, list items keep a "- " bullet or their number, and raw
+// HTML is reduced to its text.
+func StripMarkdown(s string) string {
+	return stripMarkdown(s)
+}
+
+// SanitizeMarkdown neutralizes the Markdown s according to policy while keeping it Markdown. Raw HTML is
+// sanitized as by SanitizeHTMLWith, with script and style elements removed together with their content. Links
+// are kept only if policy allows the "a" element and their URL, and images only if it allows the "img" element
+// and their URL; others are replaced by their text, so javascript: links are always removed. Link reference
+// definitions with disallowed URLs are dropped. Code blocks and code spans are kept verbatim.
+func SanitizeMarkdown(s string, policy SanitizePolicy) string {
+	return sanitizeMarkdown(s, policy)
+}
+
+// MarkdownToSafeHTML renders the Markdown s as HTML and sanitizes the result like SanitizeHTML. CommonMark is
+// supported along with GitHub-style tables and strikethrough.
+func MarkdownToSafeHTML(s string) string {
+	return markdownToSafeHTML(s)
+}
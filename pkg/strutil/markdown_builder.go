@@ -0,0 +1,30 @@
+package strutil
+
+// StripMarkdown renders the StringBuilder's value as Markdown and replaces it with its plain text.
+// See StripMarkdown for how elements are rendered.
+func (sb *StringBuilder) StripMarkdown() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(stripMarkdown(sb.value))
+	return sb
+}
+
+// SanitizeMarkdown removes the raw HTML, links and images that policy does not allow from the StringBuilder's
+// Markdown value. See SanitizeMarkdown for details.
+func (sb *StringBuilder) SanitizeMarkdown(policy SanitizePolicy) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeMarkdown(sb.value, policy))
+	return sb
+}
+
+// MarkdownToSafeHTML renders the StringBuilder's Markdown value as sanitized HTML.
+func (sb *StringBuilder) MarkdownToSafeHTML() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(markdownToSafeHTML(sb.value))
+	return sb
+}
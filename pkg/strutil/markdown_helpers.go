@@ -0,0 +1,558 @@
+package strutil
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// maxMarkdownSanitizePasses bounds how often sanitizeMarkdown rescans its own output. Removing markup can join
+// the text around it into new links or tags, so the output is sanitized again until it no longer changes.
+const maxMarkdownSanitizePasses = 16
+
+// mdContainerMarkers are the characters of the blockquote and list item markers that can precede a link
+// reference definition on its line.
+const mdContainerMarkers = " \t>-*+.)0123456789"
+
+// mdSkippedHTMLTags are the raw HTML elements removed from Markdown together with their content.
+var mdSkippedHTMLTags = []string{
+	"script", "style", "iframe", "object", "embed", "template", "noscript", "textarea", "title", "xmp",
+	"noembed", "noframes",
+}
+
+// mdRenderer parses CommonMark with GitHub-style tables and strikethrough. Raw HTML is rendered unchanged, so
+// the output must be sanitized before it is trusted.
+var mdRenderer = goldmark.New(
+	goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe(), goldmarkhtml.WithXHTML()),
+)
+
+var (
+	mdNewlines       = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+	mdHTMLTagName    = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9-]*)`)
+	mdHTMLEndTagName = regexp.MustCompile(`^</([A-Za-z][A-Za-z0-9-]*)`)
+	mdRawHTML        = regexp.MustCompile(`^(?s:` + mdOpenTagPattern + `|</[A-Za-z][A-Za-z0-9-]*\s*>|<!--.*?-->|` +
+		`<\?.*?\?>|<![A-Za-z][^>]*>|<!\[CDATA\[.*?\]\]>)`)
+	mdURLAutolink   = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.\-]{1,31}:[^<>\x00-\x20]*)>`)
+	mdEmailAutolink = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_{|}~\-]+@[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}` +
+		`[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*)>`)
+)
+
+// mdOpenTagPattern matches an HTML start tag with its attributes.
+const mdOpenTagPattern = `<[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:-]*` +
+	`(?:\s*=\s*(?:[^\s"'=<>` + "`" + `]+|'[^']*'|"[^"]*"))?)*\s*/?>`
+
+// markdownSanitizer rewrites Markdown source, keeping only the raw HTML, links and images its policy allows.
+// Code blocks, code spans and safe link reference definitions are copied verbatim.
+type markdownSanitizer struct {
+	policy   SanitizePolicy
+	html     *bluemonday.Policy
+	links    bool              // the policy allows a elements
+	images   bool              // the policy allows img elements
+	refs     map[string]bool   // normalized labels of the kept link reference definitions
+	openTags map[string][]bool // whether the open raw HTML elements of each name were kept, innermost last
+	skipping string            // name of the element in mdSkippedHTMLTags whose content is being removed
+}
+
+// mdSpanAction is what the sanitizer does with a span of a Markdown document.
+type mdSpanAction int
+
+const (
+	mdSanitize mdSpanAction = iota
+	mdKeep
+	mdDrop
+)
+
+// mdSpan is a range of a Markdown document that is sanitized on its own, copied verbatim or dropped.
+type mdSpan struct {
+	start, end int
+	action     mdSpanAction
+}
+
+// renderMarkdown renders the Markdown s as HTML without sanitizing it.
+func renderMarkdown(s string) string {
+	var b bytes.Buffer
+	if err := mdRenderer.Convert([]byte(mdNewlines.Replace(s)), &b); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// stripMarkdown renders s as Markdown and returns its plain text, keeping the text of links and the alt text of
+// images.
+func stripMarkdown(s string) string {
+	opts := NewHTMLToTextOptions()
+	opts.Links = LinksTextOnly
+	return htmlToText(renderMarkdown(s), opts)
+}
+
+// markdownToSafeHTML renders s as Markdown and sanitizes the resulting HTML like sanitizeHTML.
+func markdownToSafeHTML(s string) string {
+	return sanitizeHTML(renderMarkdown(s))
+}
+
+// sanitizeMarkdown removes from the Markdown s the raw HTML, links and images that policy does not allow,
+// rescanning the result until it is stable.
+func sanitizeMarkdown(s string, policy SanitizePolicy) string {
+	ms := &markdownSanitizer{
+		policy: policy,
+		html:   policy.bluemonday(),
+		links:  slices.Contains(policy.Elements, "a"),
+		images: slices.Contains(policy.Elements, "img"),
+	}
+	s = mdNewlines.Replace(s)
+	for range maxMarkdownSanitizePasses {
+		sanitized := ms.document(s)
+		if sanitized == s {
+			break
+		}
+		s = sanitized
+	}
+	return s
+}
+
+// document sanitizes s block by block, so inline constructs such as code spans cannot reach across blocks, and
+// copies its code blocks and safe link reference definitions verbatim. The text between blocks, such as
+// container and fence markers, is sanitized on its own.
+func (ms *markdownSanitizer) document(s string) string {
+	ms.refs = make(map[string]bool)
+	ms.openTags = make(map[string][]bool)
+	ms.skipping = ""
+	spans := ms.spans(s)
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		b.WriteString(ms.inline(s[last:span.start]))
+		switch span.action {
+		case mdSanitize:
+			b.WriteString(ms.inline(s[span.start:span.end]))
+		case mdKeep:
+			b.WriteString(s[span.start:span.end])
+		}
+		last = span.end
+	}
+	b.WriteString(ms.inline(s[last:]))
+	return b.String()
+}
+
+// spans parses s and returns its blocks in order: the lines of indented and fenced code blocks and safe link
+// reference definitions are kept, unsafe link reference definitions are dropped and other leaf blocks, such as
+// paragraphs, headings, table cells and HTML blocks, are sanitized. Code lines are kept without the container
+// prefixes and indentation before them.
+func (ms *markdownSanitizer) spans(s string) []mdSpan {
+	var spans []mdSpan
+	add := func(span mdSpan) {
+		if span.start < span.end && (len(spans) == 0 || spans[len(spans)-1].end <= span.start) {
+			spans = append(spans, span)
+		}
+	}
+	doc := mdRenderer.Parser().Parse(text.NewReader([]byte(s)))
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n.Type() != ast.TypeBlock {
+			return ast.WalkContinue, nil
+		}
+		lines := n.Lines()
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock:
+			if node.Info != nil {
+				add(mdSpan{start: node.Info.Segment.Start, end: node.Info.Segment.Stop, action: mdKeep})
+			}
+			for i := range lines.Len() {
+				add(mdSpan{start: lines.At(i).Start, end: lines.At(i).Stop, action: mdKeep})
+			}
+		case *ast.CodeBlock:
+			for i := range lines.Len() {
+				add(mdSpan{start: lines.At(i).Start, end: lines.At(i).Stop, action: mdKeep})
+			}
+		case *ast.LinkReferenceDefinition:
+			add(ms.definition(s, node))
+		case *ast.HTMLBlock:
+			span := mdLinesSpan(lines)
+			if node.HasClosure() {
+				span.end = max(span.end, node.ClosureLine.Stop)
+			}
+			add(span)
+		default:
+			if lines.Len() == 0 {
+				return ast.WalkContinue, nil
+			}
+			add(mdLinesSpan(lines))
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		// sanitize the document as a whole rather than keep code that was not found
+		return nil
+	}
+	return spans
+}
+
+// mdLinesSpan returns the span to sanitize from the first to the last of lines.
+func mdLinesSpan(lines *text.Segments) mdSpan {
+	if lines.Len() == 0 {
+		return mdSpan{}
+	}
+	return mdSpan{start: lines.At(0).Start, end: lines.At(lines.Len() - 1).Stop}
+}
+
+// definition returns the span of the link reference definition node of s. It is kept if the policy allows links
+// or images to point to its destination, and otherwise dropped together with the container markers before it
+// and a line break, so its line is removed.
+func (ms *markdownSanitizer) definition(s string, node *ast.LinkReferenceDefinition) mdSpan {
+	span := mdLinesSpan(node.Lines())
+	if span.start == span.end {
+		return span
+	}
+	if ms.allowsReference(mdUnescape(string(node.Destination))) {
+		ms.refs[mdNormalizeLabel(string(node.Label))] = true
+		span.action = mdKeep
+		return span
+	}
+	span.action = mdDrop
+	lineStart := strings.LastIndexByte(s[:span.start], '\n') + 1
+	if strings.Trim(s[lineStart:span.start], mdContainerMarkers) != "" {
+		return span
+	}
+	span.start = lineStart
+	if rest := strings.TrimLeft(s[span.end:], " \t"); strings.HasPrefix(rest, "\n") {
+		span.end = len(s) - len(rest) + 1
+	} else if rest == "" && lineStart > 0 {
+		span.start--
+	}
+	return span
+}
+
+// allowsReference reports whether the policy allows links or images to point to the destination dest of a link
+// reference definition.
+func (ms *markdownSanitizer) allowsReference(dest string) bool {
+	return (ms.links || ms.images) && ms.policy.allowsURL(dest)
+}
+
+// inline sanitizes the inline content s.
+func (ms *markdownSanitizer) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if ms.skipping != "" {
+			i = ms.skip(s, i)
+			continue
+		}
+		n := ms.inlineAt(s, i, &b)
+		if n == 0 {
+			b.WriteByte(s[i])
+			n = 1
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// inlineAt writes the sanitized inline construct starting at s[i] to b and returns its length in bytes, or
+// zero if s[i] is plain text.
+func (ms *markdownSanitizer) inlineAt(s string, i int, b *strings.Builder) int {
+	switch s[i] {
+	case '\\':
+		if i+1 < len(s) {
+			b.WriteString(s[i : i+2])
+			return 2
+		}
+	case '`':
+		_, n, _ := mdCodeSpan(s, i)
+		b.WriteString(s[i : i+n])
+		return n
+	case '<':
+		return ms.angleBracket(s[i:], b)
+	case '!':
+		if i+1 < len(s) && s[i+1] == '[' {
+			return ms.link(s, i, true, b)
+		}
+	case '[':
+		return ms.link(s, i, false, b)
+	}
+	return 0
+}
+
+// angleBracket writes the sanitized autolink or raw HTML at the start of s to b and returns its length.
+// Disallowed autolinks are replaced by their URL as text, and the elements in mdSkippedHTMLTags are removed up
+// to their end tag, or to the end of the document if they are not closed.
+func (ms *markdownSanitizer) angleBracket(s string, b *strings.Builder) int {
+	if m := mdURLAutolink.FindStringSubmatch(s); m != nil {
+		if ms.links && ms.policy.allowsURL(m[1]) {
+			b.WriteString(m[0])
+		} else {
+			b.WriteString(m[1])
+		}
+		return len(m[0])
+	}
+	if m := mdEmailAutolink.FindStringSubmatch(s); m != nil {
+		if ms.links && ms.policy.allowsURL("mailto:"+m[1]) {
+			b.WriteString(m[0])
+		} else {
+			b.WriteString(m[1])
+		}
+		return len(m[0])
+	}
+	tag := mdRawHTML.FindString(s)
+	if tag == "" {
+		return 0
+	}
+	if name := mdHTMLTagName.FindStringSubmatch(tag); name != nil &&
+		slices.Contains(mdSkippedHTMLTags, strings.ToLower(name[1])) {
+		ms.skipping = strings.ToLower(name[1])
+		return len(tag)
+	}
+	b.WriteString(ms.pairTag(tag, ms.html.Sanitize(tag)))
+	return len(tag)
+}
+
+// skip returns the index in s after the end tag of the element being skipped, or len(s) if it is not closed in s.
+func (ms *markdownSanitizer) skip(s string, i int) int {
+	endTag := "</" + ms.skipping
+	for j := i; j+len(endTag) <= len(s); j++ {
+		if !strings.EqualFold(s[j:j+len(endTag)], endTag) {
+			continue
+		}
+		if k := strings.IndexByte(s[j:], '>'); k >= 0 {
+			ms.skipping = ""
+			return j + k + 1
+		}
+		break
+	}
+	return len(s)
+}
+
+// pairTag returns sanitized, the sanitized form of the raw HTML tag, or nothing if tag is the end tag of an
+// element whose start tag the policy removed, such as the "</a>" of a link to a javascript: URL.
+func (ms *markdownSanitizer) pairTag(tag, sanitized string) string {
+	if m := mdHTMLEndTagName.FindStringSubmatch(tag); m != nil {
+		name := strings.ToLower(m[1])
+		open := ms.openTags[name]
+		if len(open) == 0 {
+			return sanitized
+		}
+		ms.openTags[name] = open[:len(open)-1]
+		if !open[len(open)-1] {
+			return ""
+		}
+		return sanitized
+	}
+	if m := mdHTMLTagName.FindStringSubmatch(tag); m != nil && !strings.HasSuffix(tag, "/>") {
+		name := strings.ToLower(m[1])
+		ms.openTags[name] = append(ms.openTags[name], sanitized != "")
+	}
+	return sanitized
+}
+
+// link writes the sanitized link or image whose text opens at s[i] to b and returns its length, or zero if the
+// bracket does not close. Disallowed links and images are replaced by their sanitized text.
+func (ms *markdownSanitizer) link(s string, i int, image bool, b *strings.Builder) int {
+	open := i
+	if image {
+		open++
+	}
+	closing := mdClosingBracket(s, open)
+	if closing < 0 {
+		return 0
+	}
+	text := s[open+1 : closing]
+	allowed := ms.links
+	if image {
+		allowed = ms.images
+	}
+	k := closing + 1
+	if k < len(s) && s[k] == '(' {
+		if dest, _, end, ok := mdInlineDestination(s, k); ok {
+			if allowed && ms.policy.allowsURL(dest) {
+				b.WriteString(s[i:open+1] + ms.inline(text) + "]" + s[k:end])
+			} else {
+				b.WriteString(ms.inline(text))
+			}
+			return end - i
+		}
+	}
+	label, end := text, k
+	if k < len(s) && s[k] == '[' {
+		if c := mdClosingBracket(s, k); c >= 0 {
+			if c > k+1 {
+				label = s[k+1 : c]
+			}
+			end = c + 1
+		}
+	}
+	if key := mdNormalizeLabel(label); !allowed && ms.refs[key] {
+		b.WriteString(ms.inline(text))
+		return end - i
+	}
+	b.WriteString(s[i:open+1] + ms.inline(text) + "]")
+	return k - i
+}
+
+// mdCodeSpan parses the code span opened by the backtick run at s[i] and returns its content and length.
+// If the run is not closed, it returns the length of the run and false.
+func mdCodeSpan(s string, i int) (string, int, bool) {
+	n := mdRunLength(s, i)
+	for j := i + n; j < len(s); {
+		k := strings.IndexByte(s[j:], '`')
+		if k < 0 {
+			break
+		}
+		j += k
+		m := mdRunLength(s, j)
+		if m == n {
+			content := strings.ReplaceAll(s[i+n:j], "\n", " ")
+			if len(content) >= 2 && content[0] == ' ' && content[len(content)-1] == ' ' &&
+				strings.TrimSpace(content) != "" {
+				content = content[1 : len(content)-1]
+			}
+			return content, j + m - i, true
+		}
+		j += m
+	}
+	return "", n, false
+}
+
+// mdRunLength returns the length of the run of the character at s[i].
+func mdRunLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// mdClosingBracket returns the index of the bracket closing the one at s[open], skipping escapes and code spans,
+// or -1 if it is not closed.
+func mdClosingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			_, n, _ := mdCodeSpan(s, i)
+			i += n - 1
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mdInlineDestination parses the parenthesized destination and optional title of an inline link at s[k],
+// returning them unescaped with the index after the closing parenthesis.
+func mdInlineDestination(s string, k int) (string, string, int, bool) {
+	j := mdSkipSpace(s, k+1)
+	dest, j, ok := mdDestination(s, j)
+	if !ok {
+		return "", "", 0, false
+	}
+	title := ""
+	if t := mdSkipSpace(s, j); t > j && t < len(s) && strings.IndexByte(`"'(`, s[t]) >= 0 {
+		if title, j, ok = mdTitle(s, t); !ok {
+			return "", "", 0, false
+		}
+	}
+	j = mdSkipSpace(s, j)
+	if j >= len(s) || s[j] != ')' {
+		return "", "", 0, false
+	}
+	return mdUnescape(dest), mdUnescape(title), j + 1, true
+}
+
+// mdDestination parses a link destination at s[j], either enclosed in angle brackets or running to whitespace
+// or an unbalanced parenthesis.
+func mdDestination(s string, j int) (string, int, bool) {
+	if j < len(s) && s[j] == '<' {
+		for i := j + 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '\n', '<':
+				return "", 0, false
+			case '>':
+				return s[j+1 : i], i + 1, true
+			}
+		}
+		return "", 0, false
+	}
+	depth := 0
+	i := j
+	for ; i < len(s) && s[i] > ' '; i++ {
+		if s[i] == '\\' {
+			i++
+		} else if s[i] == '(' {
+			depth++
+		} else if s[i] == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	i = min(i, len(s))
+	return s[j:i], i, depth == 0
+}
+
+// mdTitle parses a link title at s[t] enclosed in double quotes, single quotes or parentheses.
+func mdTitle(s string, t int) (string, int, bool) {
+	closing := s[t]
+	if closing == '(' {
+		closing = ')'
+	}
+	for i := t + 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+		} else if s[i] == closing {
+			return s[t+1 : i], i + 1, true
+		}
+	}
+	return "", 0, false
+}
+
+// mdSkipSpace returns the index of the first character at or after s[j] that is not a space, tab or line break.
+func mdSkipSpace(s string, j int) int {
+	for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\n') {
+		j++
+	}
+	return j
+}
+
+// mdUnescape resolves the backslash escapes and character references of a link destination or title.
+func mdUnescape(s string) string {
+	if !strings.ContainsAny(s, `\&`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && mdIsASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+// mdIsASCIIPunct reports whether c is an ASCII punctuation character, which a backslash escapes.
+func mdIsASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && (unicode.IsPunct(rune(c)) || unicode.IsSymbol(rune(c)))
+}
+
+// mdNormalizeLabel case-folds a link label and collapses its whitespace.
+func mdNormalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
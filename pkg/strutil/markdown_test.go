@@ -0,0 +1,144 @@
+package strutil
+
+import (
+	"testing"
+)
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Emphasis", "Some **bold**, _em_, ***both***, ~~gone~~ and `co*de`", "Some bold, em, both, gone and co*de"},
+		{"Headings", "# Title\nText\n\nSub\n---\nMore", "Title\n\nText\n\nSub\n\nMore"},
+		{"Links", `[the docs](https://x.io "Docs") and <https://auto.io>`, "the docs and https://auto.io"},
+		{"ReferenceLinks", "[docs][d], [D] and [missing]\n\n[d]: https://x.io", "docs, D and [missing]"},
+		{"Images", "![a cat](cat.png) sat", "a cat sat"},
+		{"CodeFence", "Before\n\n```go\nfunc main() {\n    x := `*y*`\n}\n```\nAfter",
+			"Before\n\nfunc main() {\n    x := `*y*`\n}\n\nAfter"},
+		{"UnclosedFence", "~~~\ncode\n  indented", "code\n  indented"},
+		{"IndentedCode", "Text\n\n    a  b\n    c", "Text\n\na  b\nc"},
+		{"Lists", "- one\n- two\n  - nested\n\n1. first\n2. second", "- one\n- two\n  - nested\n\n1. first\n2. second"},
+		{"Blockquote", "> quoted *text*\nlazy line", "> quoted text lazy line"},
+		{"Escapes", `\*not emphasis\* and 2 \< 3 &amp; 4`, "*not emphasis* and 2 < 3 & 4"},
+		{"IntrawordUnderscore", "snake_case_name", "snake_case_name"},
+		{"RawHTML", "a <b>bold</b> move <script>alert(1)</script>", "a bold move"},
+		{"HardBreak", "one  \ntwo\\\nthree", "one\ntwo\nthree"},
+		{"Table", "| a | b |\n|---|--:|\n| 1 | 22 |", "a  b\n-  --\n1  22"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := stripMarkdown(tt.input)
+			result := StripMarkdown(tt.input)
+			builderResult := New(tt.input).StripMarkdown().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("StripMarkdown - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
+
+func TestSanitizeMarkdown(t *testing.T) {
+	ugc := NewSanitizePolicy(SanitizeUGC)
+	rich := NewSanitizePolicy(SanitizeRichText)
+	strict := NewSanitizePolicy(SanitizeStrict)
+	tests := []struct {
+		name     string
+		input    string
+		policy   SanitizePolicy
+		expected string
+	}{
+		{"Empty", "", ugc, ""},
+		{"PlainMarkdownUnchanged", "# Title\n\n- **a**\n- _b_\n\n> quote", ugc, "# Title\n\n- **a**\n- _b_\n\n> quote"},
+		{"SafeLinksKept", `[a](https://x.io "T") [b](/rel) <https://auto.io>`, ugc,
+			`[a](https://x.io "T") [b](/rel) <https://auto.io>`},
+		{"JavascriptLinks", "[a](javascript:alert(1)) [b](JaVaScRiPt:x) <javascript:alert(1)>", ugc,
+			"a b javascript:alert(1)"},
+		{"EncodedJavascriptLinks", `[a](javascript&#58;alert(1)) [b](java\script:x) [c](<javascript:x>)`, ugc,
+			"a b c"},
+		{"NestedLinks", "[[a](javascript:1)](https://x.io)", ugc, "[a](https://x.io)"},
+		{"ImagesRemoved", "![a *cat*](https://x.io/cat.png) and ![ref][c]\n\n[c]: https://x.io/c.png", ugc,
+			"a *cat* and ref\n\n[c]: https://x.io/c.png"},
+		{"ImagesKept", "![cat](https://x.io/cat.png) ![x](data:image/png;base64,AA)", rich,
+			"![cat](https://x.io/cat.png) x"},
+		{"RawHTML", `<b onclick="x()">b</b> <img src=x onerror=alert(1)> <!-- c --> <a href="javascript:x">y</a>`, ugc,
+			"<b>b</b>   y"},
+		{"DroppedAnchorEndTag", `<a href="javascript:alert(1)">a</a> text`, ugc, "a text"},
+		{"KeptAnchorEndTag", `<a href="https://x.io">a</a> <A HREF="javascript:x">b</A>`, ugc,
+			`<a href="https://x.io" rel="nofollow">a</a> b`},
+		{"ScriptRemoved", "a <script>\nalert(1)\n</script> b <style>p{}</style>c", ugc, "a  b c"},
+		{"UnclosedScript", "a <script>alert(1)\n\nmore", ugc, "a "},
+		{"UnsafeDefinitions", "[a] [b]\n\n[a]: javascript:alert(1)\n[b]:\n  https://x.io", ugc,
+			"[a] [b]\n\n[b]:\n  https://x.io"},
+		{"QuotedDefinition", "> [a]: javascript:alert(1)\n> text", ugc, "> text"},
+		{"ListDefinition", "- [r]: javascript:alert(1)\n\n[r]", ugc, "\n[r]"},
+		{"OrderedListDefinition", "1. [r]: javascript:alert(1)\n\n[r]", ugc, "\n[r]"},
+		{"StarListDefinition", "* [r]: javascript:alert(1)\n\n[r]", ugc, "\n[r]"},
+		{"QuotedListDefinition", "> - [r]: javascript:alert(1)\n\n[r]", ugc, "\n[r]"},
+		{"NestedListDefinition", "- - a\n\n    [r]: javascript:alert(1)\n\n[r]", ugc, "- - a\n\n\n[r]"},
+		{"LazyListDefinition", "- a\nb\n\n  [r]: javascript:alert(1)\n\n[r]", ugc, "- a\nb\n\n\n[r]"},
+		{"SafeListDefinition", "- [r]: https://x.io\n\n[r]", ugc, "- [r]: https://x.io\n\n[r]"},
+		{"FenceEndsWithList", "- ```\n<script>alert(1)</script>\n```", ugc, "- ```\n\n```"},
+		{"FenceEndsWithQuote", "> ```\n<script>alert(1)</script>", ugc, "> ```\n"},
+		{"IndentedCodeKept", "    <script>x</script>\n\nText\n\n    [x](javascript:y)", ugc,
+			"    <script>x</script>\n\nText\n\n    [x](javascript:y)"},
+		{"IndentedCodeInList", "- a\n\n      <b onclick=x>c</b>", ugc, "- a\n\n      <b onclick=x>c</b>"},
+		{"IndentedContinuation", "Text\n    <script>x</script>", ugc, "Text\n    "},
+		{"HTMLBlockAcrossBlankLine", "<pre>\n\n    <script>alert(1)</script>\n</pre>", ugc, "<pre>\n\n    \n</pre>"},
+		{"CodeKept", "`<script>` and [x](javascript:y)\n\n```\n<script>[x](javascript:y)\n```", ugc,
+			"`<script>` and x\n\n```\n<script>[x](javascript:y)\n```"},
+		{"StrictRemovesLinks", "[a](https://x.io) [b][r] <b>c</b>\n\n[r]: https://x.io", strict, "a [b][r] c\n"},
+		{"JoinedAfterRemoval", "[a](<i>javascript:alert(1))", strict, "a"},
+		{"Escapes", `\[a](javascript:x) \<script>`, ugc, `\[a](javascript:x) \<script>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeMarkdown(tt.input, tt.policy)
+			result := SanitizeMarkdown(tt.input, tt.policy)
+			builderResult := New(tt.input).SanitizeMarkdown(tt.policy).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeMarkdown - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
+
+func TestMarkdownToSafeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Paragraphs", "Hello *world*\nagain\n\nBye", "<p>Hello <em>world</em>\nagain</p>\n<p>Bye</p>\n"},
+		{"Headings", "## Sub ##\n\nTitle\n===", "<h2>Sub</h2>\n<h1>Title</h1>\n"},
+		{"Links", `[a](https://x.io "T") [b](javascript:alert(1))`,
+			`<p><a href="https://x.io" title="T" rel="nofollow">a</a> b</p>` + "\n"},
+		{"Images", `![a *cat*](https://x.io/c.png)`, `<p><img src="https://x.io/c.png" alt="a cat"/></p>` + "\n"},
+		{"RawHTML", `<div onclick="x()">a</div>` + "\n\n<script>alert(1)</script>", "<div>a</div>\n"},
+		{"Code", "`<b>` and\n\n    <i>x</i>",
+			"<p><code>&lt;b&gt;</code> and</p>\n<pre><code>&lt;i&gt;x&lt;/i&gt;\n</code></pre>\n"},
+		{"TightList", "- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"LooseList", "3. a\n\n4. b", "<ol>\n<li>\n<p>a</p>\n</li>\n<li>\n<p>b</p>\n</li>\n</ol>\n"},
+		{"Quote", "> a\n> > b", "<blockquote>\n<p>a</p>\n<blockquote>\n<p>b</p>\n</blockquote>\n</blockquote>\n"},
+		{"Table", "| a | b |\n|:-:|---|\n| 1 |", "<table>\n<thead>\n<tr>\n<th align=\"center\">a</th>\n<th>b</th>\n" +
+			"</tr>\n</thead>\n<tbody>\n<tr>\n<td align=\"center\">1</td>\n<td></td>\n</tr>\n</tbody>\n</table>\n"},
+		{"Strikethrough", "~~a~~ ~b~", "<p><del>a</del> <del>b</del></p>\n"},
+		{"Rule", "a\n\n* * *", "<p>a</p>\n<hr/>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := markdownToSafeHTML(tt.input)
+			result := MarkdownToSafeHTML(tt.input)
+			builderResult := New(tt.input).MarkdownToSafeHTML().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("MarkdownToSafeHTML - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
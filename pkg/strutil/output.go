@@ -0,0 +1,77 @@
+package strutil
+
+import (
+	"log/slog"
+)
+
+// LogOptions configures SanitizeForLogWith.
+type LogOptions struct {
+	// MaxLength limits the sanitized output to this many bytes, including TruncationMarker. Zero or less means
+	// no limit.
+	MaxLength int
+	// TruncationMarker is appended when the output is truncated. A marker longer than MaxLength is shortened to
+	// fit.
+	TruncationMarker string
+}
+
+// NewLogOptions returns LogOptions without a length limit that mark truncation with "...".
+func NewLogOptions() LogOptions {
+	return LogOptions{
+		TruncationMarker: "...",
+	}
+}
+
+// TerminalOptions configures SanitizeForTerminal.
+type TerminalOptions struct {
+	// KeepColors keeps SGR escape sequences, which set colors and text attributes.
+	KeepColors bool
+}
+
+// NewTerminalOptions returns TerminalOptions that remove every escape sequence, including colors.
+func NewTerminalOptions() TerminalOptions {
+	return TerminalOptions{}
+}
+
+// SanitizeForLog makes s safe to write to a log as a single line. Line breaks, tabs and other control characters
+// are written as visible escapes such as "\n" and "\x1b", which also neutralizes ANSI escape sequences, and
+// backslashes are doubled so escapes cannot be forged. Invalid UTF-8 bytes, C1 controls, the line and paragraph
+// separators and bidirectional controls are escaped as well.
+func SanitizeForLog(s string) string {
+	return sanitizeForLog(s, NewLogOptions())
+}
+
+// SanitizeForLogWith sanitizes s like SanitizeForLog and truncates the result to opts.MaxLength bytes without
+// splitting an escape or a character.
+func SanitizeForLogWith(s string, opts LogOptions) string {
+	return sanitizeForLog(s, opts)
+}
+
+// SanitizeForTerminal makes s safe to print to a terminal. Escape sequences are removed, including OSC
+// hyperlinks, whose text is kept, window titles and cursor movement, unless opts.KeepColors keeps SGR color
+// sequences. Control characters other than newlines and tabs are removed and invalid UTF-8 bytes are replaced
+// with U+FFFD, so they cannot be read as 8-bit control sequences.
+func SanitizeForTerminal(s string, opts TerminalOptions) string {
+	return sanitizeForTerminal(s, opts)
+}
+
+// LogString is a string that is sanitized with SanitizeForLogWith when it is logged through log/slog, or
+// formatted with fmt.
+type LogString struct {
+	value string
+	opts  LogOptions
+}
+
+// NewLogString wraps s so that it is sanitized using opts whenever it is logged.
+func NewLogString(s string, opts LogOptions) LogString {
+	return LogString{value: s, opts: opts}
+}
+
+// LogValue implements slog.LogValuer, returning the sanitized string.
+func (ls LogString) LogValue() slog.Value {
+	return slog.StringValue(ls.String())
+}
+
+// String returns the sanitized string.
+func (ls LogString) String() string {
+	return sanitizeForLog(ls.value, ls.opts)
+}
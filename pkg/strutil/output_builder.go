@@ -0,0 +1,29 @@
+package strutil
+
+// SanitizeForLog escapes line breaks and other control characters in the string so it is safe to log.
+func (sb *StringBuilder) SanitizeForLog() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeForLog(sb.value, NewLogOptions()))
+	return sb
+}
+
+// SanitizeForLogWith escapes the string like SanitizeForLog and truncates it to opts.MaxLength bytes.
+func (sb *StringBuilder) SanitizeForLogWith(opts LogOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeForLog(sb.value, opts))
+	return sb
+}
+
+// SanitizeForTerminal removes escape sequences and control characters from the string so it is safe to print to
+// a terminal, keeping SGR colors if opts asks for them.
+func (sb *StringBuilder) SanitizeForTerminal(opts TerminalOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeForTerminal(sb.value, opts))
+	return sb
+}
@@ -0,0 +1,152 @@
+package strutil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sanitizeForLog escapes the control characters of s and truncates the result to opts.MaxLength. A marker longer
+// than opts.MaxLength is itself cut to fit, without splitting a character.
+func sanitizeForLog(s string, opts LogOptions) string {
+	marker := opts.TruncationMarker
+	if opts.MaxLength > 0 && len(marker) > opts.MaxLength {
+		n := opts.MaxLength
+		for n > 0 && !utf8.RuneStart(marker[n]) {
+			n--
+		}
+		marker = marker[:n]
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	cut := 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if opts.MaxLength > 0 && b.Len() <= opts.MaxLength-len(marker) {
+			cut = b.Len()
+		}
+		b.WriteString(logEscape(s[i:i+size], r))
+		if opts.MaxLength > 0 && b.Len() > opts.MaxLength {
+			return b.String()[:cut] + marker
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// logEscape returns the log safe form of the character c, which decodes to r.
+func logEscape(c string, r rune) string {
+	switch {
+	case r == utf8.RuneError && len(c) == 1:
+		return fmt.Sprintf(`\x%02x`, c[0])
+	case r == '\\':
+		return `\\`
+	case r == '\n':
+		return `\n`
+	case r == '\r':
+		return `\r`
+	case r == '\t':
+		return `\t`
+	case r < 0x20 || r == 0x7f:
+		return fmt.Sprintf(`\x%02x`, r)
+	case r >= 0x80 && r <= 0x9f, r == '\u2028', r == '\u2029':
+		return fmt.Sprintf(`\u%04x`, r)
+	}
+	if category, ok := invisibleCategory(r); ok && category == BidiControl {
+		return fmt.Sprintf(`\u%04x`, r)
+	}
+	return c
+}
+
+// sanitizeForTerminal removes the escape sequences and control characters of s.
+func sanitizeForTerminal(s string, opts TerminalOptions) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == 0x1b || strings.ContainsRune("\u0090\u0098\u009b\u009d\u009e\u009f", r):
+			n, keep := terminalSequence(s[i:], opts)
+			b.WriteString(keep)
+			i += n
+			continue
+		case r == utf8.RuneError && size == 1:
+			b.WriteRune(utf8.RuneError)
+		case r == '\n' || r == '\t' || !unicode.IsControl(r):
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// terminalSequence returns the length of the escape sequence at the start of s, which starts with ESC or an 8-bit
+// introducer, and the text to keep in its place.
+func terminalSequence(s string, opts TerminalOptions) (int, string) {
+	n := 1
+	kind := byte(0)
+	if s[0] == 0x1b {
+		if len(s) > 1 {
+			kind = s[1]
+			n = 2
+		}
+	} else {
+		// an 8-bit introducer U+0080+x is equivalent to ESC followed by x+0x40
+		r, size := utf8.DecodeRuneInString(s)
+		kind, n = byte(r-0x40), size
+	}
+	switch kind {
+	case '[':
+		return csiSequence(s, n, opts)
+	case ']', 'P', 'X', '^', '_':
+		return controlStringEnd(s, n), ""
+	}
+	// other escapes are ESC, intermediate bytes and a final byte
+	n = 1
+	for n < len(s) && s[n] >= 0x20 && s[n] <= 0x2f {
+		n++
+	}
+	if n < len(s) && s[n] >= 0x30 && s[n] <= 0x7e {
+		n++
+	}
+	return n, ""
+}
+
+// csiSequence returns the length of the control sequence whose parameters start at s[n] and, if it is an SGR
+// sequence and opts keeps colors, the sequence to keep.
+func csiSequence(s string, n int, opts TerminalOptions) (int, string) {
+	start := n
+	for n < len(s) && s[n] >= 0x30 && s[n] <= 0x3f {
+		n++
+	}
+	params := s[start:n]
+	for n < len(s) && s[n] >= 0x20 && s[n] <= 0x2f {
+		n++
+	}
+	if n >= len(s) || s[n] < 0x40 || s[n] > 0x7e {
+		return n, ""
+	}
+	n++
+	isSGR := s[n-1] == 'm' && n-1 == start+len(params) && strings.Trim(params, "0123456789;:") == ""
+	if opts.KeepColors && isSGR {
+		return n, "\x1b[" + params + "m"
+	}
+	return n, ""
+}
+
+// controlStringEnd returns the index after the terminator of the control string whose content starts at s[n],
+// or len(s) if it is not terminated. Control strings end with BEL or a string terminator.
+func controlStringEnd(s string, n int) int {
+	for ; n < len(s); n++ {
+		switch {
+		case s[n] == 0x07:
+			return n + 1
+		case s[n] == 0x1b && n+1 < len(s) && s[n+1] == '\\':
+			return n + 2
+		case strings.HasPrefix(s[n:], "\u009c"):
+			return n + len("\u009c")
+		}
+	}
+	return len(s)
+}
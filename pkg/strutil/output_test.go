@@ -0,0 +1,122 @@
+package strutil
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeForLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Plain", "user logged in: café", "user logged in: café"},
+		{"LineBreaks", "bob\r\nINFO admin logged in", `bob\r\nINFO admin logged in`},
+		{"Tab", "a\tb", `a\tb`},
+		{"Backslash", `a\nb`, `a\\nb`},
+		{"ANSI", "\x1b[31mred\x1b[0m", `\x1b[31mred\x1b[0m`},
+		{"Controls", "\x00\x07\x7f", `\x00\x07\x7f`},
+		{"C1", "a\u0085b\u009bc", `a\u0085b\u009bc`},
+		{"Separators", "a\u2028b\u2029c", `a\u2028b\u2029c`},
+		{"Bidi", "a\u202eb", `a\u202eb`},
+		{"InvalidUTF8", "a\xffb", `a\xffb`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeForLog(tt.input, NewLogOptions())
+			result := SanitizeForLog(tt.input)
+			builderResult := New(tt.input).SanitizeForLog().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeForLog - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestSanitizeForLogWith(t *testing.T) {
+	short := NewLogOptions()
+	short.MaxLength = 10
+	marker := LogOptions{MaxLength: 12, TruncationMarker: "[cut]"}
+	tests := []struct {
+		name     string
+		input    string
+		opts     LogOptions
+		expected string
+	}{
+		{"NoLimit", strings.Repeat("a", 50), NewLogOptions(), strings.Repeat("a", 50)},
+		{"Fits", "0123456789", short, "0123456789"},
+		{"Truncated", "0123456789a", short, "0123456..."},
+		{"KeepsEscapeWhole", "012345\nabc", short, "012345..."},
+		{"KeepsRuneWhole", "012345éabc", short, "012345..."},
+		{"Marker", "line one\nline two", marker, `line on[cut]`},
+		{"MarkerLongerThanLimit", "0123456789", LogOptions{MaxLength: 3, TruncationMarker: "[cut]"}, "[cu"},
+		{"MarkerKeepsRuneWhole", "0123456789", LogOptions{MaxLength: 2, TruncationMarker: "…"}, "01"},
+		{"MarkerFitsExactly", "0123456789", LogOptions{MaxLength: 5, TruncationMarker: "[cut]"}, "[cut]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeForLog(tt.input, tt.opts)
+			result := SanitizeForLogWith(tt.input, tt.opts)
+			builderResult := New(tt.input).SanitizeForLogWith(tt.opts).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeForLogWith - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestLogString(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("login", "user", NewLogString("bob\nadmin\x1b[2J", NewLogOptions()))
+	if expected := `"user":"bob\\nadmin\\x1b[2J"`; !strings.Contains(buf.String(), expected) {
+		t.Errorf("LogString - expected %s in %s", expected, buf.String())
+	}
+	s := NewLogString("a\r\nb", LogOptions{MaxLength: 4, TruncationMarker: "~"})
+	if result := fmt.Sprint(s); result != `a\r~` {
+		t.Errorf("LogString - expected %q - got %q", `a\r~`, result)
+	}
+}
+
+func TestSanitizeForTerminal(t *testing.T) {
+	colors := TerminalOptions{KeepColors: true}
+	tests := []struct {
+		name     string
+		input    string
+		opts     TerminalOptions
+		expected string
+	}{
+		{"Empty", "", NewTerminalOptions(), ""},
+		{"Plain", "hello\n\tworld é", NewTerminalOptions(), "hello\n\tworld é"},
+		{"SGRRemoved", "\x1b[1;31mred\x1b[0m", NewTerminalOptions(), "red"},
+		{"SGRKept", "\x1b[1;31mred\x1b[0m \x1b[38:5:208mx\x1b[m", colors, "\x1b[1;31mred\x1b[0m \x1b[38:5:208mx\x1b[m"},
+		{"PrivateSGRRemoved", "\x1b[>4;2mx", colors, "x"},
+		{"CursorMovement", "a\x1b[2J\x1b[1;1H\x1b[3Ab\x1b[?25l", colors, "ab"},
+		{"Hyperlink", "\x1b]8;;https://evil.example\x1b\\click\x1b]8;;\x1b\\", NewTerminalOptions(), "click"},
+		{"TitleBEL", "\x1b]0;pwned\x07text", NewTerminalOptions(), "text"},
+		{"UnterminatedOSC", "a\x1b]0;title", NewTerminalOptions(), "a"},
+		{"DCS", "a\x1bPq#0;2;0;0;0\x1b\\b", NewTerminalOptions(), "ab"},
+		{"OtherEscapes", "a\x1bcb\x1b(0c\x1b7d\x1b", NewTerminalOptions(), "abcd"},
+		{"EightBitCSI", "a\u009b31mb\u009d0;t\u009cc", colors, "a\x1b[31mbc"},
+		{"Controls", "a\rb\bc\x00d\x7fe\u0085f", NewTerminalOptions(), "abcdef"},
+		{"InvalidUTF8", "a\x9b2Jb", NewTerminalOptions(), "a\ufffd2Jb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeForTerminal(tt.input, tt.opts)
+			result := SanitizeForTerminal(tt.input, tt.opts)
+			builderResult := New(tt.input).SanitizeForTerminal(tt.opts).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeForTerminal - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
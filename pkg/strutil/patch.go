@@ -0,0 +1,82 @@
+package strutil
+
+import "slices"
+
+// Patch is a parsed or generated unified diff for a single file.
+type Patch struct {
+	// OldLabel is the name of the original file from the --- header.
+	OldLabel string
+	// NewLabel is the name of the changed file from the +++ header.
+	NewLabel string
+	// Hunks holds the changed regions in order.
+	Hunks []PatchHunk
+}
+
+// PatchHunk is one @@ region of a unified diff.
+type PatchHunk struct {
+	// OldStart is the first original line of the hunk, counting from 1, or the line it follows if OldLines is 0.
+	OldStart int
+	// OldLines is the number of context and deleted lines.
+	OldLines int
+	// NewStart is the first changed line of the hunk, counting from 1, or the line it follows if NewLines is 0.
+	NewStart int
+	// NewLines is the number of context and inserted lines.
+	NewLines int
+	// Lines holds the context, deleted and inserted lines in order.
+	Lines []PatchLine
+}
+
+// PatchLine is one line of a hunk.
+type PatchLine struct {
+	// Type is EditEqual for context, EditDelete for removed and EditInsert for added lines.
+	Type EditOpType
+	// Text is the line including its line feed, which is missing only for a last line without one.
+	Text string
+}
+
+// String renders the Patch in unified format, marking lines without a line feed with
+// "\ No newline at end of file".
+func (p *Patch) String() string {
+	return renderPatch(p)
+}
+
+// Apply applies the hunks in order to original, searching nearby when a hunk's context has moved.
+// Returns ErrPatchConflict if a hunk's context and deleted lines cannot be found.
+func (p *Patch) Apply(original string) (string, error) {
+	return applyParsedPatch(p, original)
+}
+
+// oldLines returns the context and deleted lines of the hunk, which must appear in the original text.
+func (h PatchHunk) oldLines() []string {
+	return h.linesExcept(EditInsert)
+}
+
+// newLines returns the context and inserted lines of the hunk, which replace the old lines.
+func (h PatchHunk) newLines() []string {
+	return h.linesExcept(EditDelete)
+}
+
+// linesExcept returns the text of every line whose type is not skip.
+func (h PatchHunk) linesExcept(skip EditOpType) []string {
+	var lines []string
+	for _, l := range h.Lines {
+		if l.Type != skip {
+			lines = append(lines, l.Text)
+		}
+	}
+	return lines
+}
+
+// IsMatch compares two Patch objects for equality of labels and hunks.
+func (p *Patch) IsMatch(other *Patch) bool {
+	if p == nil || other == nil {
+		return false
+	}
+	return p.OldLabel == other.OldLabel &&
+		p.NewLabel == other.NewLabel &&
+		slices.EqualFunc(p.Hunks, other.Hunks, func(h1, h2 PatchHunk) bool {
+			return h1.OldStart == h2.OldStart && h1.OldLines == h2.OldLines &&
+				h1.NewStart == h2.NewStart && h1.NewLines == h2.NewLines &&
+				slices.Equal(h1.Lines, h2.Lines)
+		})
+}
@@ -0,0 +1,293 @@
+package strutil
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// noNewlineMarker follows a diff line whose text does not end with a line feed.
+const noNewlineMarker = `\ No newline at end of file`
+
+// hunkHeaderRegex matches a unified diff hunk header such as "@@ -1,3 +1,4 @@"; omitted lengths default to 1.
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// patchLinePrefixes maps each PatchLine type to the prefix it is written with.
+var patchLinePrefixes = map[EditOpType]string{
+	EditEqual:  " ",
+	EditDelete: "-",
+	EditInsert: "+",
+}
+
+// patchLineTypes maps the first byte of a hunk line to its PatchLine type.
+var patchLineTypes = map[byte]EditOpType{
+	' ':  EditEqual,
+	'\n': EditEqual,
+	'-':  EditDelete,
+	'+':  EditInsert,
+}
+
+// diffLine is a single token of a diff together with its position in both texts.
+type diffLine struct {
+	t    EditOpType
+	text string
+	i    int
+	j    int
+}
+
+// diffPatch flattens edits into lines and groups them into hunks, merging changes separated by at most
+// twice the context.
+func diffPatch(edits []DiffEdit, granularity DiffGranularity, label1, label2 string, context int) *Patch {
+	lines := diffLines(edits, granularity)
+	p := &Patch{OldLabel: label1, NewLabel: label2}
+	for start := 0; start < len(lines); {
+		first := start
+		for first < len(lines) && lines[first].t == EditEqual {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for k := first + 1; k < len(lines) && k-last <= 2*context+1; k++ {
+			if lines[k].t != EditEqual {
+				last = k
+			}
+		}
+		hi := min(len(lines), last+context+1)
+		p.Hunks = append(p.Hunks, newPatchHunk(lines[max(first-context, start):hi]))
+		start = hi
+	}
+	return p
+}
+
+// diffLines flattens edits into one diffLine per token. Word tokens are escaped and terminated so each
+// fits on its own line.
+func diffLines(edits []DiffEdit, granularity DiffGranularity) []diffLine {
+	var lines []diffLine
+	for _, e := range edits {
+		for k, token := range e.Tokens {
+			l := diffLine{t: e.Type, text: token, i: e.Index1, j: e.Index2}
+			if e.Type != EditInsert {
+				l.i += k
+			}
+			if e.Type != EditDelete {
+				l.j += k
+			}
+			if granularity == DiffWords {
+				l.text = unifiedSegmentEscaper.Replace(token) + "\n"
+			}
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// newPatchHunk builds a hunk from consecutive diff lines, following the unified convention that an empty side
+// starts at the line it follows.
+func newPatchHunk(lines []diffLine) PatchHunk {
+	h := PatchHunk{OldStart: lines[0].i, NewStart: lines[0].j}
+	for _, l := range lines {
+		h.Lines = append(h.Lines, PatchLine{Type: l.t, Text: l.text})
+		if l.t != EditInsert {
+			h.OldLines++
+		}
+		if l.t != EditDelete {
+			h.NewLines++
+		}
+	}
+	if h.OldLines > 0 {
+		h.OldStart++
+	}
+	if h.NewLines > 0 {
+		h.NewStart++
+	}
+	return h
+}
+
+// renderPatch writes p in unified format.
+func renderPatch(p *Patch) string {
+	if p == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("--- " + p.OldLabel + "\n+++ " + p.NewLabel + "\n")
+	for _, h := range p.Hunks {
+		sb.WriteString("@@ -" + hunkRange(h.OldStart, h.OldLines) + " +" + hunkRange(h.NewStart, h.NewLines) + " @@\n")
+		for _, l := range h.Lines {
+			sb.WriteString(patchLinePrefixes[l.Type] + l.Text)
+			if !strings.HasSuffix(l.Text, "\n") {
+				sb.WriteString("\n" + noNewlineMarker + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// hunkRange formats one side of a hunk header, omitting the length when it is 1.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// parseUnifiedDiff reads the file labels and hunks of the first file in a unified diff.
+// Lines before the first hunk that are not labels, such as a diff command line, are skipped.
+func parseUnifiedDiff(diff string) (*Patch, error) {
+	p := &Patch{}
+	lines := diffTokens(diff, DiffLines)
+	k := 0
+	for ; k < len(lines) && !strings.HasPrefix(lines[k], "@@"); k++ {
+		if strings.HasPrefix(lines[k], "--- ") {
+			p.OldLabel = patchLabel(lines[k])
+		} else if strings.HasPrefix(lines[k], "+++ ") {
+			p.NewLabel = patchLabel(lines[k])
+		}
+	}
+	for k < len(lines) && strings.HasPrefix(lines[k], "@@") {
+		h, next, err := parsePatchHunk(lines, k)
+		if err != nil {
+			return nil, err
+		}
+		p.Hunks = append(p.Hunks, h)
+		k = next
+	}
+	if len(p.Hunks) == 0 && p.OldLabel == "" && strings.TrimSpace(diff) != "" {
+		return nil, errors.ErrInvalidPatch
+	}
+	return p, nil
+}
+
+// patchLabel returns the file name of a --- or +++ header line, dropping any tab-separated timestamp.
+func patchLabel(line string) string {
+	label, _, _ := strings.Cut(strings.TrimRight(line[4:], "\r\n"), "\t")
+	return label
+}
+
+// parsePatchHunk parses the hunk whose header is lines[k] and returns it with the index of the line after it.
+func parsePatchHunk(lines []string, k int) (PatchHunk, int, error) {
+	h, err := parseHunkHeader(lines[k])
+	if err != nil {
+		return h, k, err
+	}
+	oldSeen, newSeen := 0, 0
+	for k++; k < len(lines); k++ {
+		line := lines[k]
+		if strings.HasPrefix(line, `\`) {
+			if len(h.Lines) == 0 {
+				return h, k, errors.ErrInvalidPatch
+			}
+			h.Lines[len(h.Lines)-1].Text = strings.TrimSuffix(h.Lines[len(h.Lines)-1].Text, "\n")
+			continue
+		}
+		if oldSeen == h.OldLines && newSeen == h.NewLines {
+			break
+		}
+		t, ok := patchLineTypes[line[0]]
+		if !ok {
+			return h, k, errors.ErrInvalidPatch
+		}
+		text := line[1:]
+		if line == "\n" {
+			text = line
+		}
+		h.Lines = append(h.Lines, PatchLine{Type: t, Text: text})
+		oldSeen += boolToInt(t != EditInsert)
+		newSeen += boolToInt(t != EditDelete)
+		if oldSeen > h.OldLines || newSeen > h.NewLines {
+			return h, k, errors.ErrInvalidPatch
+		}
+	}
+	if oldSeen != h.OldLines || newSeen != h.NewLines {
+		return h, k, errors.ErrInvalidPatch
+	}
+	return h, k, nil
+}
+
+// parseHunkHeader parses the ranges of a hunk header.
+func parseHunkHeader(line string) (PatchHunk, error) {
+	m := hunkHeaderRegex.FindStringSubmatch(line)
+	if m == nil {
+		return PatchHunk{}, errors.ErrInvalidPatch
+	}
+	var values [4]int
+	for i, s := range m[1:] {
+		if s == "" {
+			values[i] = 1
+			continue
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return PatchHunk{}, errors.ErrInvalidPatch
+		}
+		values[i] = v
+	}
+	return PatchHunk{OldStart: values[0], OldLines: values[1], NewStart: values[2], NewLines: values[3]}, nil
+}
+
+// boolToInt returns 1 for true and 0 for false.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// applyPatch parses patch and applies it to original.
+func applyPatch(original, patch string) (string, error) {
+	p, err := parseUnifiedDiff(patch)
+	if err != nil {
+		return "", err
+	}
+	return applyParsedPatch(p, original)
+}
+
+// applyParsedPatch replaces the old lines of each hunk with its new lines. A hunk is first looked for where its
+// header says, shifted by how far the previous hunk moved, and then progressively further away.
+func applyParsedPatch(p *Patch, original string) (string, error) {
+	if p == nil {
+		return "", errors.ErrInvalidPatch
+	}
+	lines := diffTokens(original, DiffLines)
+	var out []string
+	pos, offset := 0, 0
+	for _, h := range p.Hunks {
+		old := h.oldLines()
+		want := h.OldStart
+		if h.OldLines > 0 {
+			want--
+		}
+		at, ok := findPatchHunk(lines, old, want+offset, pos)
+		if !ok {
+			return "", errors.ErrPatchConflict
+		}
+		out = append(out, lines[pos:at]...)
+		out = append(out, h.newLines()...)
+		pos = at + len(old)
+		offset = at - want
+	}
+	out = append(out, lines[pos:]...)
+	return strings.Join(out, ""), nil
+}
+
+// findPatchHunk returns the index closest to want, and not before from, where old appears in lines.
+func findPatchHunk(lines, old []string, want, from int) (int, bool) {
+	last := len(lines) - len(old)
+	matches := func(at int) bool {
+		return at >= from && at <= last && slices.Equal(lines[at:at+len(old)], old)
+	}
+	for delta := 0; want-delta >= from || want+delta <= last; delta++ {
+		if matches(want + delta) {
+			return want + delta, true
+		}
+		if delta > 0 && matches(want-delta) {
+			return want - delta, true
+		}
+	}
+	return 0, false
+}
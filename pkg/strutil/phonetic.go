@@ -0,0 +1,93 @@
+package strutil
+
+// PhoneticAlgorithm identifies a phonetic encoding used to compare how strings sound rather than how they are spelled.
+type PhoneticAlgorithm int
+
+// String returns the string representation of the PhoneticAlgorithm using PhoneticAlgorithmMap.
+func (p PhoneticAlgorithm) String() string {
+	return PhoneticAlgorithmMap[p]
+}
+
+// PhoneticSoundex encodes with American Soundex, producing a letter followed by three digits.
+// PhoneticRefinedSoundex encodes with Refined Soundex, producing a letter followed by a variable number of digits.
+// PhoneticMetaphone encodes with Lawrence Philips' original Metaphone.
+// PhoneticDoubleMetaphone encodes with Double Metaphone; two strings match if any of their primary or
+// alternate codes match.
+// PhoneticNYSIIS encodes with the New York State Identification and Intelligence System algorithm.
+// PhoneticCaverphoneII encodes with Caverphone 2.0, producing a ten character code.
+// PhoneticCologne encodes with Kölner Phonetik, which is tuned for German names.
+const (
+	PhoneticSoundex PhoneticAlgorithm = iota
+	PhoneticRefinedSoundex
+	PhoneticMetaphone
+	PhoneticDoubleMetaphone
+	PhoneticNYSIIS
+	PhoneticCaverphoneII
+	PhoneticCologne
+)
+
+// PhoneticAlgorithmMap maps PhoneticAlgorithm constants to their corresponding string representations.
+var PhoneticAlgorithmMap = map[PhoneticAlgorithm]string{
+	PhoneticSoundex:         "Soundex",
+	PhoneticRefinedSoundex:  "Refined Soundex",
+	PhoneticMetaphone:       "Metaphone",
+	PhoneticDoubleMetaphone: "Double Metaphone",
+	PhoneticNYSIIS:          "NYSIIS",
+	PhoneticCaverphoneII:    "Caverphone II",
+	PhoneticCologne:         "Cologne Phonetic",
+}
+
+// Soundex returns the American Soundex code of s, e.g. "Robert" and "Rupert" both encode to "R163".
+// Diacritics are normalized and non-letters are ignored; returns an empty string if s contains no letters.
+func Soundex(s string) string {
+	return soundex(s)
+}
+
+// RefinedSoundex returns the Refined Soundex code of s, which distinguishes more letter groups than Soundex
+// and is not truncated. Returns an empty string if s contains no letters.
+func RefinedSoundex(s string) string {
+	return refinedSoundex(s)
+}
+
+// Metaphone returns the original Metaphone code of s, e.g. "Smith" and "Smyth" both encode to "SM0".
+// The code is not truncated. Returns an empty string if s contains no letters.
+func Metaphone(s string) string {
+	return metaphone(s)
+}
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone codes of s, each at most 4 characters.
+// The alternate code equals the primary code when the name has a single likely pronunciation.
+func DoubleMetaphone(s string) (string, string) {
+	return doubleMetaphone(s)
+}
+
+// NYSIIS returns the New York State Identification and Intelligence System code of s, truncated to 6 characters.
+// Returns an empty string if s contains no letters.
+func NYSIIS(s string) string {
+	return nysiis(s)
+}
+
+// CaverphoneII returns the Caverphone 2.0 code of s, padded or truncated to 10 characters.
+// Returns an empty string if s contains no letters.
+func CaverphoneII(s string) string {
+	return caverphoneII(s)
+}
+
+// ColognePhonetic returns the Kölner Phonetik code of s, e.g. "Meyer" and "Maier" both encode to "67".
+// Returns an empty string if s contains no letters.
+func ColognePhonetic(s string) string {
+	return colognePhonetic(s)
+}
+
+// PhoneticEncode returns the code of s under the given phonetic algorithm.
+// Double Metaphone returns its primary code. Returns an error if the algorithm is invalid.
+func PhoneticEncode(s string, algorithm PhoneticAlgorithm) (string, error) {
+	return phoneticEncode(s, algorithm)
+}
+
+// PhoneticMatch compares the phonetic codes of s1 and s2 under the given algorithm and returns a
+// ComparisonResultInt scoring 1 if the codes match and 0 otherwise.
+// Strings without any encodable letters never match. Returns an error result if the algorithm is invalid.
+func PhoneticMatch(s1, s2 string, algorithm PhoneticAlgorithm) *ComparisonResultInt {
+	return phoneticMatch(s1, s2, algorithm)
+}
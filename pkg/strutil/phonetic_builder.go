@@ -0,0 +1,81 @@
+package strutil
+
+// Soundex replaces the StringBuilder's value with its American Soundex code.
+func (sb *StringBuilder) Soundex() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(soundex(sb.value))
+	return sb
+}
+
+// RefinedSoundex replaces the StringBuilder's value with its Refined Soundex code.
+func (sb *StringBuilder) RefinedSoundex() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(refinedSoundex(sb.value))
+	return sb
+}
+
+// Metaphone replaces the StringBuilder's value with its Metaphone code.
+func (sb *StringBuilder) Metaphone() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(metaphone(sb.value))
+	return sb
+}
+
+// DoubleMetaphone replaces the StringBuilder's value with its primary Double Metaphone code.
+// Use the functional DoubleMetaphone to also obtain the alternate code.
+func (sb *StringBuilder) DoubleMetaphone() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	primary, _ := doubleMetaphone(sb.value)
+	sb.setValue(primary)
+	return sb
+}
+
+// NYSIIS replaces the StringBuilder's value with its NYSIIS code.
+func (sb *StringBuilder) NYSIIS() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(nysiis(sb.value))
+	return sb
+}
+
+// CaverphoneII replaces the StringBuilder's value with its Caverphone 2.0 code.
+func (sb *StringBuilder) CaverphoneII() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(caverphoneII(sb.value))
+	return sb
+}
+
+// ColognePhonetic replaces the StringBuilder's value with its Kölner Phonetik code.
+func (sb *StringBuilder) ColognePhonetic() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(colognePhonetic(sb.value))
+	return sb
+}
+
+// PhoneticMatch compares the phonetic codes of the StringBuilder's value and another string under the
+// given algorithm and stores the result in the ComparisonManager.
+// Sets a non-fatal error, without storing a result, if the algorithm is invalid.
+func (sb *StringBuilder) PhoneticMatch(other string, algorithm PhoneticAlgorithm) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	pm := phoneticMatch(sb.value, other, algorithm)
+	if pm.GetError() != nil {
+		return sb.setError(pm.GetError(), false)
+	}
+	sb.WithComparisonManager().comparisonManager.AddComparisonResult(pm)
+	return sb
+}
@@ -0,0 +1,564 @@
+package strutil
+
+import (
+	"strings"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// phoneticComparisonTypes maps each PhoneticAlgorithm to the ComparisonResultType its matches are stored under.
+var phoneticComparisonTypes = map[PhoneticAlgorithm]ComparisonResultType{
+	PhoneticSoundex:         SoundexMatch,
+	PhoneticRefinedSoundex:  RefinedSoundexMatch,
+	PhoneticMetaphone:       MetaphoneMatch,
+	PhoneticDoubleMetaphone: DoubleMetaphoneMatch,
+	PhoneticNYSIIS:          NYSIISMatch,
+	PhoneticCaverphoneII:    CaverphoneIIMatch,
+	PhoneticCologne:         ColognePhoneticMatch,
+}
+
+// soundexCodes holds the Soundex digit for each letter A-Z; '0' marks vowels and the separators H, W and Y.
+const soundexCodes = "01230120022455012623010202"
+
+// refinedSoundexCodes holds the Refined Soundex digit for each letter A-Z.
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// phoneticLetters normalizes diacritics, upper-cases s and drops every rune that is not an ASCII letter.
+func phoneticLetters(s string) string {
+	s = strings.ToUpper(normalizeDiacritics(s))
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 'A' && s[i] <= 'Z' {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// isPhoneticVowel reports whether c is one of the ASCII vowels A, E, I, O or U.
+func isPhoneticVowel(c byte) bool {
+	return c == 'A' || c == 'E' || c == 'I' || c == 'O' || c == 'U'
+}
+
+// isFrontVowel reports whether c is E, I or Y, the letters that soften a preceding C or G.
+func isFrontVowel(c byte) bool {
+	return c == 'E' || c == 'I' || c == 'Y'
+}
+
+// phoneticAt returns w[i], or 0 if i is out of range.
+func phoneticAt(w string, i int) byte {
+	if i < 0 || i >= len(w) {
+		return 0
+	}
+	return w[i]
+}
+
+// phoneticIn reports whether c is a non-zero byte contained in set.
+func phoneticIn(c byte, set string) bool {
+	return c != 0 && strings.IndexByte(set, c) >= 0
+}
+
+// soundex encodes s with American Soundex. H and W do not separate letters with the same code,
+// vowels do, and the result is padded with zeros to four characters.
+func soundex(s string) string {
+	w := phoneticLetters(s)
+	if w == "" {
+		return ""
+	}
+	code := []byte{w[0]}
+	last := soundexCodes[w[0]-'A']
+	for i := 1; i < len(w) && len(code) < 4; i++ {
+		c := soundexCodes[w[i]-'A']
+		if c != '0' && c != last {
+			code = append(code, c)
+		}
+		if w[i] != 'H' && w[i] != 'W' {
+			last = c
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// refinedSoundex encodes s with Refined Soundex: the first letter followed by the code of every letter,
+// including the first, with adjacent duplicate codes collapsed.
+func refinedSoundex(s string) string {
+	w := phoneticLetters(s)
+	if w == "" {
+		return ""
+	}
+	code := []byte{w[0]}
+	var last byte
+	for i := 0; i < len(w); i++ {
+		c := refinedSoundexCodes[w[i]-'A']
+		if c != last {
+			code = append(code, c)
+		}
+		last = c
+	}
+	return string(code)
+}
+
+// metaphoneRule encodes the letter at w[i] and returns its code and the number of following letters it consumes.
+type metaphoneRule func(w string, i int) (string, int)
+
+// metaphoneRules holds the context dependent Metaphone rules, keyed by letter.
+var metaphoneRules = map[byte]metaphoneRule{
+	'A': metaphoneVowel, 'E': metaphoneVowel, 'I': metaphoneVowel, 'O': metaphoneVowel, 'U': metaphoneVowel,
+	'B': metaphoneB, 'C': metaphoneC, 'D': metaphoneD, 'G': metaphoneG, 'H': metaphoneH, 'K': metaphoneK,
+	'P': metaphoneP, 'S': metaphoneS, 'T': metaphoneT, 'W': metaphoneWY, 'Y': metaphoneWY,
+}
+
+// metaphoneSubstitutions holds the Metaphone letters that always encode to the same code.
+// Letters in neither metaphoneRules nor metaphoneSubstitutions encode to themselves.
+var metaphoneSubstitutions = map[byte]string{'Q': "K", 'V': "F", 'X': "KS", 'Z': "S"}
+
+// metaphone encodes s with the original Metaphone rules. '0' represents the "th" sound.
+func metaphone(s string) string {
+	w := metaphonePrefix(phoneticLetters(s))
+	var b strings.Builder
+	for i := 0; i < len(w); i++ {
+		if i > 0 && w[i] == w[i-1] && w[i] != 'C' {
+			continue
+		}
+		if rule, ok := metaphoneRules[w[i]]; ok {
+			code, skip := rule(w, i)
+			b.WriteString(code)
+			i += skip
+		} else if code, ok := metaphoneSubstitutions[w[i]]; ok {
+			b.WriteString(code)
+		} else {
+			b.WriteByte(w[i])
+		}
+	}
+	return b.String()
+}
+
+// metaphonePrefix applies the Metaphone rules for silent or altered initial letters.
+func metaphonePrefix(w string) string {
+	switch {
+	case len(w) < 2:
+		return w
+	case strings.HasPrefix(w, "AE"), strings.HasPrefix(w, "GN"), strings.HasPrefix(w, "KN"),
+		strings.HasPrefix(w, "PN"), strings.HasPrefix(w, "WR"):
+		return w[1:]
+	case w[0] == 'X':
+		return "S" + w[1:]
+	case strings.HasPrefix(w, "WH"):
+		return "W" + w[2:]
+	}
+	return w
+}
+
+// metaphoneVowel keeps a vowel only at the start of the word.
+func metaphoneVowel(w string, i int) (string, int) {
+	if i == 0 {
+		return w[:1], 0
+	}
+	return "", 0
+}
+
+// metaphoneB drops B after M at the end of the word, as in "dumb".
+func metaphoneB(w string, i int) (string, int) {
+	if i == len(w)-1 && phoneticAt(w, i-1) == 'M' {
+		return "", 0
+	}
+	return "B", 0
+}
+
+// metaphoneC encodes C as X in "cia" and "ch", S before a front vowel (silent in "sci", "sce" and "scy")
+// and K otherwise, including "sch" and an initial "ch" followed by a consonant.
+func metaphoneC(w string, i int) (string, int) {
+	prev, next := phoneticAt(w, i-1), phoneticAt(w, i+1)
+	switch {
+	case prev == 'S' && isFrontVowel(next):
+		return "", 0
+	case next == 'I' && phoneticAt(w, i+2) == 'A':
+		return "X", 0
+	case isFrontVowel(next):
+		return "S", 0
+	case next == 'H' && (prev == 'S' || (i == 0 && !isPhoneticVowel(phoneticAt(w, i+2)))):
+		return "K", 0
+	case next == 'H':
+		return "X", 0
+	}
+	return "K", 0
+}
+
+// metaphoneD encodes "dge", "dgi" and "dgy" as J, consuming the G and the vowel, and D as T otherwise.
+func metaphoneD(w string, i int) (string, int) {
+	if phoneticAt(w, i+1) == 'G' && isFrontVowel(phoneticAt(w, i+2)) {
+		return "J", 2
+	}
+	return "T", 0
+}
+
+// metaphoneG drops G in "gh" before a consonant and in a final "gn" or "gned", encodes it as J before a
+// front vowel unless doubled, and as K otherwise.
+func metaphoneG(w string, i int) (string, int) {
+	next := phoneticAt(w, i+1)
+	switch {
+	case next == 'H' && i+2 < len(w) && !isPhoneticVowel(w[i+2]):
+		return "", 0
+	case next == 'N' && (i+2 == len(w) || (i+4 == len(w) && w[i+2:] == "ED")):
+		return "", 0
+	case isFrontVowel(next) && phoneticAt(w, i-1) != 'G':
+		return "J", 0
+	}
+	return "K", 0
+}
+
+// metaphoneH drops H at the end of the word, after C, S, P, T or G, and between a vowel and a consonant.
+func metaphoneH(w string, i int) (string, int) {
+	prev := phoneticAt(w, i-1)
+	if i == len(w)-1 || phoneticIn(prev, "CSPTG") ||
+		(isPhoneticVowel(prev) && !isPhoneticVowel(phoneticAt(w, i+1))) {
+		return "", 0
+	}
+	return "H", 0
+}
+
+// metaphoneK drops K after C.
+func metaphoneK(w string, i int) (string, int) {
+	if phoneticAt(w, i-1) == 'C' {
+		return "", 0
+	}
+	return "K", 0
+}
+
+// metaphoneP encodes "ph" as F.
+func metaphoneP(w string, i int) (string, int) {
+	if phoneticAt(w, i+1) == 'H' {
+		return "F", 0
+	}
+	return "P", 0
+}
+
+// metaphoneS encodes "sh", "sio" and "sia" as X.
+func metaphoneS(w string, i int) (string, int) {
+	next, next2 := phoneticAt(w, i+1), phoneticAt(w, i+2)
+	if next == 'H' || (next == 'I' && (next2 == 'O' || next2 == 'A')) {
+		return "X", 0
+	}
+	return "S", 0
+}
+
+// metaphoneT encodes "tia" and "tio" as X and "th" as 0, and drops T in "tch".
+func metaphoneT(w string, i int) (string, int) {
+	next, next2 := phoneticAt(w, i+1), phoneticAt(w, i+2)
+	switch {
+	case next == 'I' && (next2 == 'O' || next2 == 'A'):
+		return "X", 0
+	case next == 'H':
+		return "0", 0
+	case next == 'C' && next2 == 'H':
+		return "", 0
+	}
+	return "T", 0
+}
+
+// metaphoneWY keeps W and Y only when followed by a vowel.
+func metaphoneWY(w string, i int) (string, int) {
+	if isPhoneticVowel(phoneticAt(w, i+1)) {
+		return w[i : i+1], 0
+	}
+	return "", 0
+}
+
+// nysiisPrefixes and nysiisSuffixes are the NYSIIS rewrites applied to the start and end of a name before encoding.
+var (
+	nysiisPrefixes = [][2]string{{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"}}
+	nysiisSuffixes = [][2]string{{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"},
+		{"ND", "D"}}
+)
+
+// nysiisSubstitutions holds the NYSIIS letters that always transcode to the same letter.
+var nysiisSubstitutions = map[byte]byte{'Q': 'G', 'Z': 'S', 'M': 'N'}
+
+// nysiis encodes s with the NYSIIS algorithm, transcoding letters in place so later rules
+// see the already transcoded neighbours, and truncates the key to 6 characters.
+func nysiis(s string) string {
+	w := phoneticLetters(s)
+	if w == "" {
+		return ""
+	}
+	chars := []byte(nysiisRewrite(w))
+	key := []byte{chars[0]}
+	for i := 1; i < len(chars); i++ {
+		nysiisTranscode(chars, i)
+		if chars[i] != key[len(key)-1] {
+			key = append(key, chars[i])
+		}
+	}
+	return nysiisFinish(key)
+}
+
+// nysiisRewrite applies the first matching prefix and suffix rewrite to w.
+func nysiisRewrite(w string) string {
+	for _, r := range nysiisPrefixes {
+		if strings.HasPrefix(w, r[0]) {
+			w = r[1] + w[len(r[0]):]
+			break
+		}
+	}
+	for _, r := range nysiisSuffixes {
+		if strings.HasSuffix(w, r[0]) {
+			w = w[:len(w)-len(r[0])] + r[1]
+			break
+		}
+	}
+	return w
+}
+
+// nysiisTranscode rewrites chars[i], and for multi-letter rules the letters following it, in place.
+func nysiisTranscode(chars []byte, i int) {
+	next, next2 := phoneticAt(string(chars), i+1), phoneticAt(string(chars), i+2)
+	switch c := chars[i]; c {
+	case 'A', 'E', 'I', 'O', 'U':
+		chars[i] = 'A'
+		if c == 'E' && next == 'V' {
+			chars[i+1] = 'F'
+		}
+	case 'K':
+		chars[i] = 'C'
+		if next == 'N' {
+			chars[i] = 'N'
+		}
+	case 'S':
+		if next == 'C' && next2 == 'H' {
+			chars[i+1], chars[i+2] = 'S', 'S'
+		}
+	case 'P':
+		if next == 'H' {
+			chars[i], chars[i+1] = 'F', 'F'
+		}
+	case 'H', 'W':
+		nysiisTranscodeHW(chars, i, next)
+	default:
+		if sub, ok := nysiisSubstitutions[c]; ok {
+			chars[i] = sub
+		}
+	}
+}
+
+// nysiisTranscodeHW replaces H with the preceding letter unless it sits between two vowels,
+// and W with the preceding letter if that letter is a vowel.
+func nysiisTranscodeHW(chars []byte, i int, next byte) {
+	prevVowel := isPhoneticVowel(chars[i-1])
+	if (chars[i] == 'H' && (!prevVowel || !isPhoneticVowel(next))) || (chars[i] == 'W' && prevVowel) {
+		chars[i] = chars[i-1]
+	}
+}
+
+// nysiisFinish removes a trailing S, replaces a trailing "AY" with Y, removes a trailing A
+// and truncates the key to 6 characters.
+func nysiisFinish(key []byte) string {
+	k := string(key)
+	if len(k) > 1 {
+		k = strings.TrimSuffix(k, "S")
+	}
+	if len(k) > 2 && strings.HasSuffix(k, "AY") {
+		k = k[:len(k)-2] + "Y"
+	}
+	if len(k) > 1 {
+		k = strings.TrimSuffix(k, "A")
+	}
+	if len(k) > 6 {
+		k = k[:6]
+	}
+	return k
+}
+
+// caverphoneRules are the ordered Caverphone 2.0 rewrite rules applied after the initial clean-up.
+// A leading '^' anchors a rule to the start of the string and a trailing '$' to the end.
+// A trailing '+' collapses a run of the letter into a single upper-case code.
+var caverphoneRules = [][2]string{
+	{"^cough", "cou2f"}, {"^rough", "rou2f"}, {"^tough", "tou2f"}, {"^enough", "enou2f"},
+	{"^trough", "trou2f"}, {"^gn", "2n"}, {"mb$", "m2"},
+	{"cq", "2q"}, {"ci", "si"}, {"ce", "se"}, {"cy", "sy"}, {"tch", "2ch"}, {"c", "k"}, {"q", "k"},
+	{"x", "k"}, {"v", "f"}, {"dg", "2g"}, {"tio", "sio"}, {"tia", "sia"}, {"d", "t"}, {"ph", "fh"},
+	{"b", "p"}, {"sh", "s2"}, {"z", "s"},
+	{"^a", "A"}, {"^e", "A"}, {"^i", "A"}, {"^o", "A"}, {"^u", "A"},
+	{"a", "3"}, {"e", "3"}, {"i", "3"}, {"o", "3"}, {"u", "3"},
+	{"j", "y"}, {"^y3", "Y3"}, {"^y", "A"}, {"y", "3"},
+	{"3gh3", "3kh3"}, {"gh", "22"}, {"g", "k"},
+	{"s+", "S"}, {"t+", "T"}, {"p+", "P"}, {"k+", "K"}, {"f+", "F"}, {"m+", "M"}, {"n+", "N"},
+	{"w3", "W3"}, {"wh3", "Wh3"}, {"w$", "3"}, {"w", "2"},
+	{"^h", "A"}, {"h", "2"},
+	{"r3", "R3"}, {"r$", "3"}, {"r", "2"},
+	{"l3", "L3"}, {"l$", "3"}, {"l", "2"},
+	{"2", ""}, {"3$", "A"}, {"3", ""},
+}
+
+// caverphoneII encodes s with Caverphone 2.0 by applying caverphoneRules to the lower-cased letters of s.
+func caverphoneII(s string) string {
+	w := strings.ToLower(phoneticLetters(s))
+	if w == "" {
+		return ""
+	}
+	w = strings.TrimSuffix(w, "e")
+	for _, rule := range caverphoneRules {
+		w = caverphoneApply(w, rule[0], rule[1])
+	}
+	w += strings.Repeat("1", 10)
+	return w[:10]
+}
+
+// caverphoneApply applies a single Caverphone rewrite rule to w.
+func caverphoneApply(w, pattern, replacement string) string {
+	switch {
+	case strings.HasPrefix(pattern, "^"):
+		if strings.HasPrefix(w, pattern[1:]) {
+			return replacement + w[len(pattern)-1:]
+		}
+		return w
+	case strings.HasSuffix(pattern, "$"):
+		if strings.HasSuffix(w, pattern[:len(pattern)-1]) {
+			return w[:len(w)-len(pattern)+1] + replacement
+		}
+		return w
+	case strings.HasSuffix(pattern, "+"):
+		c := pattern[0]
+		var b strings.Builder
+		for i := 0; i < len(w); i++ {
+			if w[i] != c {
+				b.WriteByte(w[i])
+			} else if i == 0 || w[i-1] != c {
+				b.WriteString(replacement)
+			}
+		}
+		return b.String()
+	default:
+		return strings.ReplaceAll(w, pattern, replacement)
+	}
+}
+
+// cologneCodes holds the Kölner Phonetik codes of the letters whose code does not depend on their neighbours.
+// H has no code.
+var cologneCodes = map[byte]string{
+	'A': "0", 'E': "0", 'I': "0", 'J': "0", 'O': "0", 'U': "0", 'Y': "0",
+	'B': "1", 'F': "3", 'V': "3", 'W': "3", 'G': "4", 'K': "4", 'Q': "4",
+	'L': "5", 'M': "6", 'N': "6", 'R': "7", 'S': "8", 'Z': "8", 'H': "",
+}
+
+// colognePhonetic encodes s with Kölner Phonetik: each letter is mapped to a digit based on its
+// neighbours, adjacent duplicate digits are collapsed and every '0' except a leading one is removed.
+func colognePhonetic(s string) string {
+	w := phoneticLetters(s)
+	var digits []byte
+	for i := 0; i < len(w); i++ {
+		code := cologneCode(w, i)
+		for j := 0; j < len(code); j++ {
+			if len(digits) == 0 || digits[len(digits)-1] != code[j] {
+				digits = append(digits, code[j])
+			}
+		}
+	}
+	if len(digits) == 0 {
+		return ""
+	}
+	out := digits[:1]
+	for _, d := range digits[1:] {
+		if d != '0' {
+			out = append(out, d)
+		}
+	}
+	return string(out)
+}
+
+// cologneCode returns the Kölner Phonetik code of the letter at w[i].
+func cologneCode(w string, i int) string {
+	c, prev, next := w[i], phoneticAt(w, i-1), phoneticAt(w, i+1)
+	if code, ok := cologneCodes[c]; ok {
+		return code
+	}
+	switch c {
+	case 'P':
+		if next == 'H' {
+			return "3"
+		}
+		return "1"
+	case 'D', 'T':
+		if phoneticIn(next, "CSZ") {
+			return "8"
+		}
+		return "2"
+	case 'C':
+		if (i == 0 && phoneticIn(next, "AHKLOQRUX")) ||
+			(i > 0 && phoneticIn(next, "AHKOQUX") && !phoneticIn(prev, "SZ")) {
+			return "4"
+		}
+		return "8"
+	}
+	// X
+	if phoneticIn(prev, "CKQ") {
+		return "8"
+	}
+	return "48"
+}
+
+// phoneticEncode dispatches to the encoder for the given algorithm; Double Metaphone returns its primary code.
+func phoneticEncode(s string, algorithm PhoneticAlgorithm) (string, error) {
+	switch algorithm {
+	case PhoneticSoundex:
+		return soundex(s), nil
+	case PhoneticRefinedSoundex:
+		return refinedSoundex(s), nil
+	case PhoneticMetaphone:
+		return metaphone(s), nil
+	case PhoneticDoubleMetaphone:
+		primary, _ := doubleMetaphone(s)
+		return primary, nil
+	case PhoneticNYSIIS:
+		return nysiis(s), nil
+	case PhoneticCaverphoneII:
+		return caverphoneII(s), nil
+	case PhoneticCologne:
+		return colognePhonetic(s), nil
+	default:
+		return "", errors.ErrInvalidPhoneticAlgorithm
+	}
+}
+
+// phoneticCodes returns every code of s under the given algorithm: the primary and alternate codes for
+// Double Metaphone and the single code otherwise.
+func phoneticCodes(s string, algorithm PhoneticAlgorithm) ([]string, error) {
+	if algorithm == PhoneticDoubleMetaphone {
+		primary, alternate := doubleMetaphone(s)
+		return []string{primary, alternate}, nil
+	}
+	code, err := phoneticEncode(s, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return []string{code}, nil
+}
+
+// phoneticMatch compares the phonetic codes of s1 and s2, scoring 1 for a match and 0 otherwise.
+// Double Metaphone matches if any primary or alternate code of s1 equals one of s2.
+func phoneticMatch(s1, s2 string, algorithm PhoneticAlgorithm) *ComparisonResultInt {
+	comparisonType, ok := phoneticComparisonTypes[algorithm]
+	if !ok {
+		return NewComparisonResultInt(comparisonType, s1, s2, nil, nil, errors.ErrInvalidPhoneticAlgorithm)
+	}
+	codes1, err := phoneticCodes(s1, algorithm)
+	if err != nil {
+		return NewComparisonResultInt(comparisonType, s1, s2, nil, nil, err)
+	}
+	codes2, err := phoneticCodes(s2, algorithm)
+	if err != nil {
+		return NewComparisonResultInt(comparisonType, s1, s2, nil, nil, err)
+	}
+	score := 0
+	for _, c1 := range codes1 {
+		for _, c2 := range codes2 {
+			if c1 != "" && c1 == c2 {
+				score = 1
+			}
+		}
+	}
+	return NewComparisonResultInt(comparisonType, s1, s2, nil, &score, nil)
+}
@@ -0,0 +1,171 @@
+package strutil
+
+import (
+	"errors"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+func TestPhoneticEncoders(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		algorithm PhoneticAlgorithm
+		expected  string
+	}{
+		{"SoundexRobert", "Robert", PhoneticSoundex, "R163"},
+		{"SoundexRupert", "Rupert", PhoneticSoundex, "R163"},
+		{"SoundexRubin", "Rubin", PhoneticSoundex, "R150"},
+		{"SoundexAshcraft", "Ashcraft", PhoneticSoundex, "A261"},
+		{"SoundexTymczak", "Tymczak", PhoneticSoundex, "T522"},
+		{"SoundexPfister", "Pfister", PhoneticSoundex, "P236"},
+		{"SoundexHoneyman", "Honeyman", PhoneticSoundex, "H555"},
+		{"SoundexEmpty", "", PhoneticSoundex, ""},
+		{"RefinedSoundexTesting", "testing", PhoneticRefinedSoundex, "T6036084"},
+		{"RefinedSoundexJumped", "jumped", PhoneticRefinedSoundex, "J408106"},
+		{"RefinedSoundexNoLetters", "123", PhoneticRefinedSoundex, ""},
+		{"MetaphoneSmith", "Smith", PhoneticMetaphone, "SM0"},
+		{"MetaphoneKnight", "Knight", PhoneticMetaphone, "NT"},
+		{"MetaphoneBishop", "Bishop", PhoneticMetaphone, "BXP"},
+		{"MetaphonePhilips", "Philips", PhoneticMetaphone, "FLPS"},
+		{"MetaphoneXavier", "Xavier", PhoneticMetaphone, "SFR"},
+		{"MetaphoneDumb", "Dumb", PhoneticMetaphone, "TM"},
+		{"DoubleMetaphoneSmith", "Smith", PhoneticDoubleMetaphone, "SM0"},
+		{"DoubleMetaphoneJose", "Jose", PhoneticDoubleMetaphone, "HS"},
+		{"NYSIISBishop", "Bishop", PhoneticNYSIIS, "BASAP"},
+		{"NYSIISKuhl", "Kuhl", PhoneticNYSIIS, "CAL"},
+		{"NYSIISKnight", "Knight", PhoneticNYSIIS, "NAGT"},
+		{"NYSIISTruncated", "Christopher", PhoneticNYSIIS, "CRASTA"},
+		{"CaverphonePeter", "Peter", PhoneticCaverphoneII, "PTA1111111"},
+		{"CaverphoneReady", "ready", PhoneticCaverphoneII, "RTA1111111"},
+		{"CaverphoneSocial", "social", PhoneticCaverphoneII, "SSA1111111"},
+		{"CaverphoneAble", "able", PhoneticCaverphoneII, "APA1111111"},
+		{"CaverphoneTedder", "Tedder", PhoneticCaverphoneII, "TTA1111111"},
+		{"CaverphoneKarleen", "Karleen", PhoneticCaverphoneII, "KLN1111111"},
+		{"CaverphoneDyun", "Dyun", PhoneticCaverphoneII, "TN11111111"},
+		{"CologneWikipedia", "Wikipedia", PhoneticCologne, "3412"},
+		{"CologneMueller", "Müller-Lüdenscheidt", PhoneticCologne, "65752682"},
+		{"CologneMeyer", "Meyer", PhoneticCologne, "67"},
+		{"CologneOnlyH", "h", PhoneticCologne, ""},
+	}
+	encoders := map[PhoneticAlgorithm]struct {
+		helper  func(string) string
+		public  func(string) string
+		builder func(*StringBuilder) *StringBuilder
+	}{
+		PhoneticSoundex:        {soundex, Soundex, (*StringBuilder).Soundex},
+		PhoneticRefinedSoundex: {refinedSoundex, RefinedSoundex, (*StringBuilder).RefinedSoundex},
+		PhoneticMetaphone:      {metaphone, Metaphone, (*StringBuilder).Metaphone},
+		PhoneticDoubleMetaphone: {
+			func(s string) string { p, _ := doubleMetaphone(s); return p },
+			func(s string) string { p, _ := DoubleMetaphone(s); return p },
+			(*StringBuilder).DoubleMetaphone,
+		},
+		PhoneticNYSIIS:       {nysiis, NYSIIS, (*StringBuilder).NYSIIS},
+		PhoneticCaverphoneII: {caverphoneII, CaverphoneII, (*StringBuilder).CaverphoneII},
+		PhoneticCologne:      {colognePhonetic, ColognePhonetic, (*StringBuilder).ColognePhonetic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := encoders[tt.algorithm]
+			helperResult := e.helper(tt.input)
+			result := e.public(tt.input)
+			builderResult := e.builder(New(tt.input)).String()
+			encoded, err := PhoneticEncode(tt.input, tt.algorithm)
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected ||
+				encoded != tt.expected || err != nil {
+				t.Errorf("%s(%q) = %q/%q/%q/%q; want %q", tt.algorithm, tt.input,
+					helperResult, result, builderResult, encoded, tt.expected)
+			}
+		})
+	}
+	if _, err := PhoneticEncode("Smith", PhoneticAlgorithm(99)); !errors.Is(err, errors2.ErrInvalidPhoneticAlgorithm) {
+		t.Errorf("PhoneticEncode - expected invalid algorithm error, got %v", err)
+	}
+}
+
+func TestDoubleMetaphone(t *testing.T) {
+	tests := []struct {
+		input     string
+		primary   string
+		alternate string
+	}{
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Gerald", "KRLT", "JRLT"},
+		{"Xavier", "SF", "SFR"},
+		{"Arnow", "ARN", "ARNF"},
+		{"Michael", "MKL", "MXL"},
+		{"Orchestra", "ARKS", "ARKS"},
+		{"Cabrillo", "KPRL", "KPR"},
+		{"Gallegos", "KLKS", "KKS"},
+		{"Zhao", "J", "J"},
+		{"Laugh", "LF", "LF"},
+		{"Tichner", "TXNR", "TKNR"},
+		{"Jankelowicz", "JNKL", "ANKL"},
+		{"Caesar", "SSR", "SSR"},
+		{"Wikipedia", "AKPT", "FKPT"},
+		{"Bacher", "PKR", "PKR"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run("DoubleMetaphone"+tt.input, func(t *testing.T) {
+			primary, alternate := DoubleMetaphone(tt.input)
+			if primary != tt.primary || alternate != tt.alternate {
+				t.Errorf("DoubleMetaphone(%q) = %q/%q; want %q/%q", tt.input, primary, alternate,
+					tt.primary, tt.alternate)
+			}
+		})
+	}
+}
+
+func TestPhoneticMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		s1        string
+		s2        string
+		algorithm PhoneticAlgorithm
+		resType   ComparisonResultType
+		expected  int
+		err       error
+	}{
+		{"SoundexSmithSmyth", "Smith", "Smyth", PhoneticSoundex, SoundexMatch, 1, nil},
+		{"SoundexRobertRubin", "Robert", "Rubin", PhoneticSoundex, SoundexMatch, 0, nil},
+		{"RefinedSoundexSmithSmyth", "Smith", "Smyth", PhoneticRefinedSoundex, RefinedSoundexMatch, 1, nil},
+		{"MetaphoneSmithSmyth", "Smith", "Smyth", PhoneticMetaphone, MetaphoneMatch, 1, nil},
+		{"DoubleMetaphoneAlternate", "Schmidt", "Smith", PhoneticDoubleMetaphone, DoubleMetaphoneMatch, 1, nil},
+		{"NYSIISKatherine", "Catherine", "Katherine", PhoneticNYSIIS, NYSIISMatch, 1, nil},
+		{"CaverphoneMeyerMaier", "Meyer", "Maier", PhoneticCaverphoneII, CaverphoneIIMatch, 1, nil},
+		{"CologneMeyerMaier", "Meyer", "Maier", PhoneticCologne, ColognePhoneticMatch, 1, nil},
+		{"CologneDifferent", "Meyer", "Schmidt", PhoneticCologne, ColognePhoneticMatch, 0, nil},
+		{"NoLetters", "123", "456", PhoneticSoundex, SoundexMatch, 0, nil},
+		{"InvalidAlgorithm", "Smith", "Smyth", PhoneticAlgorithm(99), LCSLength, 0,
+			errors2.ErrInvalidPhoneticAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := phoneticMatch(tt.s1, tt.s2, tt.algorithm)
+			result := PhoneticMatch(tt.s1, tt.s2, tt.algorithm)
+			if !helperResult.IsMatch(result) {
+				t.Errorf("PhoneticMatch(%q, %q) - helper and functional results differ", tt.s1, tt.s2)
+			}
+			score, err := result.GetScoreInt()
+			if !errors.Is(err, tt.err) || score != tt.expected || result.GetType() != tt.resType {
+				t.Errorf("PhoneticMatch(%q, %q) = %d (%v, %s); want %d (%v)", tt.s1, tt.s2, score, err,
+					result.GetTypeName(), tt.expected, tt.err)
+			}
+			sb := New(tt.s1).PhoneticMatch(tt.s2, tt.algorithm)
+			if !errors.Is(sb.Error(), tt.err) || sb.String() != tt.s1 {
+				t.Errorf("StringBuilder.PhoneticMatch(%q, %q) - unexpected error %v", tt.s1, tt.s2, sb.Error())
+			}
+			if tt.err != nil {
+				return
+			}
+			stored := sb.GetComparisonManager().GetComparisonResult(tt.resType, tt.s2)
+			if !result.IsMatch(CastComparisonResult(&stored)) {
+				t.Errorf("StringBuilder.PhoneticMatch(%q, %q) - result not stored in ComparisonManager", tt.s1, tt.s2)
+			}
+		})
+	}
+}
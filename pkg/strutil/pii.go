@@ -0,0 +1,161 @@
+package strutil
+
+import (
+	"github.com/bmj2728/utils/pkg/pattern"
+)
+
+// PIIType identifies the kind of personally identifiable information found by DetectPII.
+type PIIType int
+
+// String returns the string representation of the PIIType using PIITypeMap.
+func (pt PIIType) String() string {
+	return PIITypeMap[pt]
+}
+
+// PIIEmail is an email address accepted by IsEmail.
+// PIIPhone is a phone number with 7 to 15 digits.
+// PIICreditCard is a payment card number that passes the Luhn check.
+// PIIIBAN is an international bank account number with valid check digits.
+// PIISSN is a US social security number written as AAA-GG-SSSS.
+// PIIIPAddress is an IPv4 or IPv6 address.
+// PIIJWT is a JSON web token.
+// PIIAPIKey is a long, high entropy token that looks like an API key or other secret.
+// PIICustom is a match of a custom pattern added to PIIOptions.Patterns.
+const (
+	PIIEmail PIIType = iota
+	PIIPhone
+	PIICreditCard
+	PIIIBAN
+	PIISSN
+	PIIIPAddress
+	PIIJWT
+	PIIAPIKey
+	PIICustom
+)
+
+// PIITypeMap maps PIIType constants to their corresponding string representations. The names of the built-in
+// types are also the names of their patterns in DefaultPIIPatterns.
+var PIITypeMap = map[PIIType]string{
+	PIIEmail:      "Email",
+	PIIPhone:      "Phone",
+	PIICreditCard: "Credit Card",
+	PIIIBAN:       "IBAN",
+	PIISSN:        "SSN",
+	PIIIPAddress:  "IP Address",
+	PIIJWT:        "JWT",
+	PIIAPIKey:     "API Key",
+	PIICustom:     "Custom",
+}
+
+// PIIMatch is a span of personally identifiable information found by DetectPII.
+type PIIMatch struct {
+	Type PIIType
+	// Name is the name of the pattern that matched.
+	Name string
+	// Start and End are the byte offsets of the match, so Value is s[Start:End].
+	Start int
+	End   int
+	Value string
+	// Confidence estimates how likely the match is PII, from 0 to 1. Matches of custom patterns have
+	// confidence 1.
+	Confidence float64
+}
+
+// PIIOptions configures DetectPIIWith.
+type PIIOptions struct {
+	// Patterns are the detectors to run, keyed by name. Patterns named after a built-in PIIType, such as "Email"
+	// or "Credit Card", are validated like the built-in detector of that type, so their regular expressions can
+	// be replaced. Patterns with any other name report every match as PIICustom.
+	Patterns pattern.CustomPatternSet
+	// MinConfidence drops matches with a lower confidence.
+	MinConfidence float64
+}
+
+// NewPIIOptions returns PIIOptions that run every built-in detector and keep matches with a confidence of at
+// least 0.5.
+func NewPIIOptions() PIIOptions {
+	return PIIOptions{
+		Patterns:      DefaultPIIPatterns(),
+		MinConfidence: 0.5,
+	}
+}
+
+// RedactStrategy selects how Redact replaces the PII it finds.
+type RedactStrategy int
+
+// String returns the string representation of the RedactStrategy using RedactStrategyMap.
+func (rs RedactStrategy) String() string {
+	return RedactStrategyMap[rs]
+}
+
+// RedactMask replaces letters and digits with RedactOptions.MaskChar, keeping separators and, for card, phone,
+// bank account and social security numbers, the last RedactOptions.KeepLast digits, e.g. "****-****-****-1234".
+// RedactHash replaces PII with its type and a truncated SHA-256 hash, e.g. "[EMAIL:1f9d3c2a7b6e5d4c]", so
+// equal values can still be correlated.
+// RedactPlaceholder replaces PII with its type, e.g. "[EMAIL]".
+// RedactFake replaces PII with a fake value of the same format, derived deterministically from the original.
+// Fakes are detected as the same type: fake card numbers keep their network prefix and pass the Luhn check, fake
+// IBANs keep their country code and have valid check digits, fake emails keep their top-level domain, fake IPv6
+// addresses use hexadecimal digits and fake JWTs keep their header.
+const (
+	RedactMask RedactStrategy = iota
+	RedactHash
+	RedactPlaceholder
+	RedactFake
+)
+
+// RedactStrategyMap maps RedactStrategy constants to their corresponding string representations.
+var RedactStrategyMap = map[RedactStrategy]string{
+	RedactMask:        "Mask",
+	RedactHash:        "Hash",
+	RedactPlaceholder: "Placeholder",
+	RedactFake:        "Fake",
+}
+
+// RedactOptions configures Redact.
+type RedactOptions struct {
+	Strategy RedactStrategy
+	// Detection selects the PII to redact.
+	Detection PIIOptions
+	// MaskChar replaces letters and digits with RedactMask. Zero masks with '*'.
+	MaskChar rune
+	// KeepLast is the number of trailing digits RedactMask keeps for numbers such as card numbers.
+	KeepLast int
+	// HashKey, if set, keys the hashes of RedactHash and RedactFake with HMAC-SHA256 so they cannot be reversed
+	// by hashing guesses.
+	HashKey []byte
+}
+
+// NewRedactOptions returns RedactOptions that mask the PII found with NewPIIOptions using '*', keeping the last
+// four digits of numbers.
+func NewRedactOptions() RedactOptions {
+	return RedactOptions{
+		Strategy:  RedactMask,
+		Detection: NewPIIOptions(),
+		MaskChar:  '*',
+		KeepLast:  4,
+	}
+}
+
+// DefaultPIIPatterns returns a new pattern set containing the built-in PII detectors, keyed by the names in
+// PIITypeMap. Patterns can be removed, replaced or added before passing the set in PIIOptions.
+func DefaultPIIPatterns() pattern.CustomPatternSet {
+	return defaultPIIPatterns()
+}
+
+// DetectPII finds emails, phone numbers, card numbers, IBANs, US social security numbers, IP addresses, JWTs and
+// API-key-like tokens in s using NewPIIOptions. Matches are ordered by position and do not overlap; where
+// detectors disagree the most confident match wins.
+func DetectPII(s string) []PIIMatch {
+	return detectPII(s, NewPIIOptions())
+}
+
+// DetectPIIWith finds the PII in s using the detectors and minimum confidence of opts.
+func DetectPIIWith(s string, opts PIIOptions) []PIIMatch {
+	return detectPII(s, opts)
+}
+
+// Redact replaces the PII found in s according to opts.
+func Redact(s string, opts RedactOptions) string {
+	return redact(s, opts)
+}
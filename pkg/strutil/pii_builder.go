@@ -0,0 +1,10 @@
+package strutil
+
+// Redact replaces the PII found in the string according to opts.
+func (sb *StringBuilder) Redact(opts RedactOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(redact(sb.value, opts))
+	return sb
+}
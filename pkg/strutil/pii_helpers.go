@@ -0,0 +1,513 @@
+package strutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bmj2728/utils/pkg/pattern"
+)
+
+// piiDetector is a built-in PII detector. validate returns the confidence that a match of pattern is PII, or
+// zero if it is not.
+type piiDetector struct {
+	piiType  PIIType
+	pattern  *regexp.Regexp
+	validate func(string) float64
+}
+
+// piiDetectors are the built-in detectors, keyed by the name of their PIIType.
+var piiDetectors = map[string]piiDetector{
+	PIIEmail.String(): {PIIEmail, regexp.MustCompile(
+		`[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?` +
+			`(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)+`), emailConfidence},
+	PIIPhone.String(): {PIIPhone, regexp.MustCompile(
+		`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?\d{1,4}(?:[ .-]?\d{2,4}){1,4}`), phoneConfidence},
+	PIICreditCard.String(): {PIICreditCard, regexp.MustCompile(`\d(?:[ -]?\d){12,18}`), creditCardConfidence},
+	PIIIBAN.String(): {PIIIBAN, regexp.MustCompile(`[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?`),
+		ibanConfidence},
+	PIISSN.String():       {PIISSN, regexp.MustCompile(`\d{3}-\d{2}-\d{4}`), ssnConfidence},
+	PIIIPAddress.String(): {PIIIPAddress, ipPattern, ipConfidence},
+	PIIJWT.String(): {PIIJWT, regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`),
+		jwtConfidence},
+	PIIAPIKey.String(): {PIIAPIKey, regexp.MustCompile(`[A-Za-z0-9_-]{20,}`), apiKeyConfidence},
+}
+
+var (
+	ipPattern    = regexp.MustCompile(`(?:\d{1,3}\.){3}\d{1,3}|(?i)[0-9a-f]{0,4}(?::[0-9a-f]{0,4}){2,7}`)
+	nanpPhone    = regexp.MustCompile(`^\(?\d{3}\)?[ .-]?\d{3}[ .-]\d{4}$`)
+	cardPrefixes = regexp.MustCompile(`^(?:4|5[1-5]|2[2-7]|3[47]|3[068]|35|6011|64[4-9]|65)`)
+)
+
+// Confidence levels of the built-in detectors.
+const (
+	piiCertain  = 0.95
+	piiLikely   = 0.85
+	piiProbable = 0.7
+	piiPossible = 0.5
+	piiUnlikely = 0.3
+)
+
+// defaultPIIPatterns returns a new pattern set with the patterns of the built-in detectors.
+func defaultPIIPatterns() pattern.CustomPatternSet {
+	set := pattern.NewCustomPatternSet()
+	for name, detector := range piiDetectors {
+		set.Add(name, detector.pattern)
+	}
+	return set
+}
+
+// detectPII runs the patterns of opts over s and returns the most confident non-overlapping matches in order.
+func detectPII(s string, opts PIIOptions) []PIIMatch {
+	var candidates []PIIMatch
+	for name, re := range opts.Patterns {
+		if re == nil {
+			continue
+		}
+		detector, builtin := piiDetectors[name]
+		for _, loc := range re.FindAllStringIndex(s, -1) {
+			if loc[0] == loc[1] || (builtin && !isTokenBoundary(s, loc[0], loc[1])) {
+				continue
+			}
+			m := PIIMatch{Type: PIICustom, Name: name, Start: loc[0], End: loc[1], Value: s[loc[0]:loc[1]],
+				Confidence: 1}
+			if builtin {
+				m.Type, m.Confidence = detector.piiType, detector.validate(m.Value)
+			}
+			if m.Confidence > 0 && m.Confidence >= opts.MinConfidence {
+				candidates = append(candidates, m)
+			}
+		}
+	}
+	return resolvePIIOverlaps(candidates)
+}
+
+// resolvePIIOverlaps keeps the most confident, then longest, of overlapping matches and orders the result by
+// position.
+func resolvePIIOverlaps(candidates []PIIMatch) []PIIMatch {
+	return resolveOverlaps(candidates, func(m PIIMatch) (int, int) { return m.Start, m.End },
+		func(a, b PIIMatch) bool {
+			switch {
+			case a.Confidence != b.Confidence:
+				return a.Confidence > b.Confidence
+			case a.End-a.Start != b.End-b.Start:
+				return a.End-a.Start > b.End-b.Start
+			case a.Start != b.Start:
+				return a.Start < b.Start
+			}
+			return a.Name < b.Name
+		})
+}
+
+// resolveOverlaps keeps the candidates that do not overlap a candidate ordered before them by preferred, and
+// orders the result by position.
+func resolveOverlaps[T any](candidates []T, span func(T) (int, int), preferred func(a, b T) bool) []T {
+	sort.Slice(candidates, func(i, j int) bool { return preferred(candidates[i], candidates[j]) })
+	var kept []T
+	for _, c := range candidates {
+		start, end := span(c)
+		overlaps := false
+		for _, k := range kept {
+			if keptStart, keptEnd := span(k); start < keptEnd && keptStart < end {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, c)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		a, _ := span(kept[i])
+		b, _ := span(kept[j])
+		return a < b
+	})
+	return kept
+}
+
+// isTokenBoundary reports whether s[start:end] is not preceded or followed by a letter, digit or underscore.
+func isTokenBoundary(s string, start, end int) bool {
+	isWord := func(c byte) bool {
+		return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	return (start == 0 || !isWord(s[start-1])) && (end == len(s) || !isWord(s[end]))
+}
+
+// piiDigits returns the ASCII digits of s.
+func piiDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// emailConfidence accepts the addresses IsEmail accepts.
+func emailConfidence(s string) float64 {
+	if isEmail(s) {
+		return piiCertain
+	}
+	return 0
+}
+
+// phoneConfidence accepts numbers with 7 to 15 digits, the E.164 limit, and is most confident about numbers
+// with a country code or in the North American format.
+func phoneConfidence(s string) float64 {
+	digits := len(piiDigits(s))
+	switch {
+	case digits < 7 || digits > 15:
+		return 0
+	case strings.HasPrefix(s, "+"):
+		return piiLikely
+	case nanpPhone.MatchString(s):
+		return piiProbable
+	case digits >= 10:
+		return piiPossible
+	}
+	return piiUnlikely
+}
+
+// creditCardConfidence accepts numbers that pass the Luhn check and is most confident about those with the
+// prefix of a major card network.
+func creditCardConfidence(s string) float64 {
+	digits := piiDigits(s)
+	if len(digits) < 13 || len(digits) > 19 || !luhnValid(digits) {
+		return 0
+	}
+	if cardPrefixes.MatchString(digits) {
+		return piiCertain
+	}
+	return piiPossible
+}
+
+// luhnValid reports whether the digits pass the Luhn check.
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// ibanConfidence accepts IBANs whose check digits are valid under ISO 7064 MOD 97-10.
+func ibanConfidence(s string) float64 {
+	iban := strings.ReplaceAll(s, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return 0
+	}
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		value := int(c - '0')
+		if c >= 'A' && c <= 'Z' {
+			value = int(c-'A') + 10
+			remainder = remainder * 10 % 97
+		}
+		remainder = (remainder*10 + value) % 97
+	}
+	if remainder == 1 {
+		return piiCertain
+	}
+	return 0
+}
+
+// ssnConfidence accepts nine digit numbers, rejecting the area, group and serial numbers that are never issued.
+func ssnConfidence(s string) float64 {
+	digits := piiDigits(s)
+	if len(digits) != 9 {
+		return 0
+	}
+	area, group, serial := digits[:3], digits[3:5], digits[5:]
+	if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+		return 0
+	}
+	return piiLikely
+}
+
+// ipConfidence accepts valid IPv4 and IPv6 addresses other than the unspecified IPv6 address "::".
+func ipConfidence(s string) float64 {
+	addr, err := netip.ParseAddr(s)
+	if err != nil || (addr.Is6() && addr.IsUnspecified()) {
+		return 0
+	}
+	return piiLikely
+}
+
+// jwtConfidence is most confident about tokens whose header decodes to JSON.
+func jwtConfidence(s string) float64 {
+	header, _, _ := strings.Cut(s, ".")
+	decoded, err := base64.RawURLEncoding.DecodeString(header)
+	if err == nil && json.Valid(decoded) {
+		return piiCertain
+	}
+	return piiPossible
+}
+
+// apiKeyConfidence accepts tokens of letters and digits whose Shannon entropy is high enough to be random.
+// Hexadecimal tokens, which have at most 4 bits of entropy per character, must be at least 32 characters long.
+func apiKeyConfidence(s string) float64 {
+	hasLetter := strings.ContainsFunc(s, unicode.IsLetter)
+	hasDigit := strings.ContainsFunc(s, unicode.IsDigit)
+	if !hasLetter || !hasDigit {
+		return 0
+	}
+	entropy := shannonEntropy(s)
+	isHex := strings.Trim(strings.ToLower(s), "0123456789abcdef") == ""
+	switch {
+	case isHex && len(s) >= 32 && entropy >= 3:
+		return piiPossible
+	case isHex:
+		return 0
+	case entropy >= 4:
+		return piiLikely
+	case entropy >= 3.5:
+		return piiPossible
+	}
+	return 0
+}
+
+// shannonEntropy returns the Shannon entropy of the characters of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	// sum in a fixed order so the result does not depend on map iteration
+	entropy := 0.0
+	for _, r := range slices.Sorted(maps.Keys(counts)) {
+		p := float64(counts[r]) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// redact replaces the PII of s according to opts.
+func redact(s string, opts RedactOptions) string {
+	matches := detectPII(s, opts.Detection)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	last := 0
+	for _, m := range matches {
+		b.WriteString(s[last:m.Start])
+		b.WriteString(redactPII(m, opts))
+		last = m.End
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// redactPII returns the replacement of m.
+func redactPII(m PIIMatch, opts RedactOptions) string {
+	switch opts.Strategy {
+	case RedactHash:
+		return "[" + piiLabel(m) + ":" + hex.EncodeToString(piiDigest(m.Value, opts.HashKey))[:16] + "]"
+	case RedactPlaceholder:
+		return "[" + piiLabel(m) + "]"
+	case RedactFake:
+		return fakePII(m, opts.HashKey)
+	}
+	return maskPII(m, opts)
+}
+
+// piiLabel returns the upper case name of the type of m, or of its pattern if it is custom.
+func piiLabel(m PIIMatch) string {
+	name := m.Type.String()
+	if m.Type == PIICustom {
+		name = m.Name
+	}
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+}
+
+// piiDigest hashes value with SHA-256, or with HMAC-SHA256 if a key is given.
+func piiDigest(value string, key []byte) []byte {
+	if len(key) == 0 {
+		sum := sha256.Sum256([]byte(value))
+		return sum[:]
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// maskPII replaces the letters and digits of m with opts.MaskChar, or '*' if it is zero, keeping the last
+// opts.KeepLast digits of numbers.
+func maskPII(m PIIMatch, opts RedactOptions) string {
+	maskChar := opts.MaskChar
+	if maskChar == 0 {
+		maskChar = '*'
+	}
+	keep := 0
+	switch m.Type {
+	case PIICreditCard, PIIPhone, PIIIBAN, PIISSN:
+		keep = opts.KeepLast
+	}
+	runes := []rune(m.Value)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			continue
+		}
+		if keep > 0 {
+			keep--
+			continue
+		}
+		runes[i] = maskChar
+	}
+	return string(runes)
+}
+
+// maxFakePIIAttempts is the number of fake values fakePII generates before giving up on one its detector
+// accepts as confidently as the original.
+const maxFakePIIAttempts = 100
+
+// fakePII returns a value with the format of m, generated from a seed derived from its value. Values of the
+// built-in types are regenerated until their detector accepts them as confidently as m, so the fake is detected
+// as the same type.
+func fakePII(m PIIMatch, key []byte) string {
+	rng := rand.New(rand.NewChaCha8([32]byte(piiDigest(m.Value, key))))
+	detector, builtin := piiDetectors[m.Type.String()]
+	fake := fakePIIValue(m, rng)
+	for attempt := 1; m.Type != PIICustom && builtin && attempt < maxFakePIIAttempts; attempt++ {
+		if detector.validate(fake) >= m.Confidence {
+			break
+		}
+		fake = fakePIIValue(m, rng)
+	}
+	return fake
+}
+
+// fakePIIValue returns a random value with the format of m.
+func fakePIIValue(m PIIMatch, rng *rand.Rand) string {
+	if m.Type == PIIIPAddress && strings.Count(m.Value, ".") == 3 {
+		return fmt.Sprintf("%d.%d.%d.%d", rng.IntN(256), rng.IntN(256), rng.IntN(256), rng.IntN(256))
+	}
+	runes := []rune(m.Value)
+	start, end := fakePIISpan(m)
+	fake := fakeRune
+	if m.Type == PIIIPAddress {
+		fake = fakeHexRune
+	}
+	for i := start; i < end; i++ {
+		runes[i] = fake(runes[i], rng)
+	}
+	switch m.Type {
+	case PIICreditCard:
+		fixLuhnCheckDigit(runes)
+	case PIIIBAN:
+		fixIBANCheckDigits(runes)
+	}
+	return string(runes)
+}
+
+// fakePIISpan returns the rune range of the value of m that fakePIIValue replaces, leaving the parts that
+// identify its format: the top-level domain of emails, the country code of IBANs, the network prefix of card
+// numbers and the header and payload prefix of JWTs.
+func fakePIISpan(m PIIMatch) (int, int) {
+	// replaced patterns may match non-ASCII values, so count runes
+	start, end := 0, utf8.RuneCountInString(m.Value)
+	switch m.Type {
+	case PIIEmail:
+		if dot := strings.LastIndex(m.Value, "."); dot >= 0 {
+			end = utf8.RuneCountInString(m.Value[:dot])
+		}
+	case PIIIBAN:
+		start = 2
+	case PIICreditCard:
+		prefix := len(cardPrefixes.FindString(piiDigits(m.Value)))
+		for i, r := range []rune(m.Value) {
+			if prefix == 0 {
+				break
+			}
+			if r >= '0' && r <= '9' {
+				prefix--
+			}
+			start = i + 1
+		}
+	case PIIJWT:
+		if dot := strings.Index(m.Value, "."); dot >= 0 {
+			start = min(utf8.RuneCountInString(m.Value[:dot])+1+len("eyJ"), end)
+		}
+	}
+	return start, end
+}
+
+// fakeRune returns a random character of the same class as r, or r if it is not an ASCII letter or digit.
+func fakeRune(r rune, rng *rand.Rand) rune {
+	switch {
+	case r >= '0' && r <= '9':
+		return '0' + rng.Int32N(10)
+	case r >= 'a' && r <= 'z':
+		return 'a' + rng.Int32N(26)
+	case r >= 'A' && r <= 'Z':
+		return 'A' + rng.Int32N(26)
+	}
+	return r
+}
+
+// fakeHexRune returns a random hexadecimal digit in the case of r, or r if it is not a hexadecimal digit.
+func fakeHexRune(r rune, rng *rand.Rand) rune {
+	const digits = "0123456789abcdef"
+	switch {
+	case r >= '0' && r <= '9' || r >= 'a' && r <= 'f':
+		return rune(digits[rng.IntN(len(digits))])
+	case r >= 'A' && r <= 'F':
+		return unicode.ToUpper(rune(digits[rng.IntN(len(digits))]))
+	}
+	return r
+}
+
+// fixIBANCheckDigits replaces the check digits after the country code so that runes is a valid IBAN.
+func fixIBANCheckDigits(runes []rune) {
+	if len(runes) < 4 {
+		return
+	}
+	for n := 2; n < 100; n++ {
+		runes[2], runes[3] = '0'+rune(n/10), '0'+rune(n%10)
+		if ibanConfidence(string(runes)) > 0 {
+			return
+		}
+	}
+}
+
+// fixLuhnCheckDigit replaces the last digit of runes so that its digits pass the Luhn check.
+func fixLuhnCheckDigit(runes []rune) {
+	last := -1
+	for i, r := range runes {
+		if r >= '0' && r <= '9' {
+			last = i
+		}
+	}
+	if last < 0 {
+		return
+	}
+	for d := '0'; d <= '9'; d++ {
+		runes[last] = d
+		if luhnValid(piiDigits(string(runes))) {
+			return
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package strutil
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// ResultMeta holds the metadata shared by every comparison outcome, whatever the type of its value.
+type ResultMeta struct {
+	Algorithm string            // name of the algorithm or result type that produced the value
+	Inputs    []string          // the compared or processed strings, in order
+	Params    map[string]string // parameters of the computation, such as the n-gram length
+	Duration  time.Duration     // time spent computing the value, zero if it was not measured
+	Err       error             // error encountered during the computation, if any
+}
+
+// clone returns a copy of m that shares no slices or maps with it.
+func (m ResultMeta) clone() ResultMeta {
+	m.Inputs = slices.Clone(m.Inputs)
+	m.Params = maps.Clone(m.Params)
+	return m
+}
+
+// Result is the outcome of a comparison holding a value of type T, such as a float32 score, an int distance or a
+// []string of substrings, together with its ResultMeta. It is the common form of SimilarityResult,
+// ComparisonResultInt, ComparisonResultFloat, LCSResult and the shingle results, which convert to it with their
+// ToResult methods, and is stored in a ResultStore.
+type Result[T any] struct {
+	value *T
+	meta  ResultMeta
+}
+
+// NewResult initializes and returns a Result holding value and a copy of meta. A nil value means the
+// computation produced no value.
+func NewResult[T any](value *T, meta ResultMeta) *Result[T] {
+	return &Result[T]{value: value, meta: meta.clone()}
+}
+
+// ComputeResult runs compute, timing it, and returns its value or error as a Result of the named algorithm over
+// inputs with the given params. The value is discarded if compute returns an error.
+func ComputeResult[T any](algorithm string,
+	inputs []string,
+	params map[string]string,
+	compute func() (T, error)) *Result[T] {
+	start := time.Now()
+	value, err := compute()
+	meta := ResultMeta{Algorithm: algorithm, Inputs: inputs, Params: params, Duration: time.Since(start), Err: err}
+	if err != nil {
+		return NewResult[T](nil, meta)
+	}
+	return NewResult(&value, meta)
+}
+
+// GetValue returns the value of the Result, or the error of the computation if there is one.
+// Returns ErrNilScore if the Result holds neither a value nor an error.
+func (r *Result[T]) GetValue() (T, error) {
+	var zero T
+	if r.meta.Err != nil {
+		return zero, r.meta.Err
+	}
+	if r.value == nil {
+		return zero, errors.ErrNilScore
+	}
+	return *r.value, nil
+}
+
+// GetMeta returns a copy of the metadata of the Result.
+func (r *Result[T]) GetMeta() ResultMeta {
+	return r.meta.clone()
+}
+
+// GetAlgorithm returns the name of the algorithm or result type that produced the Result.
+func (r *Result[T]) GetAlgorithm() string {
+	return r.meta.Algorithm
+}
+
+// GetInputs returns a copy of the strings the Result was computed from.
+func (r *Result[T]) GetInputs() []string {
+	return slices.Clone(r.meta.Inputs)
+}
+
+// GetParam returns the named parameter of the computation and reports whether it was set.
+func (r *Result[T]) GetParam(name string) (string, bool) {
+	value, ok := r.meta.Params[name]
+	return value, ok
+}
+
+// GetDuration returns the time spent computing the Result, or zero if it was not measured.
+func (r *Result[T]) GetDuration() time.Duration {
+	return r.meta.Duration
+}
+
+// GetError returns the error encountered during the computation, if any.
+func (r *Result[T]) GetError() error {
+	return r.meta.Err
+}
+
+// IsMatch compares the Result with another based on algorithm, inputs, parameters, error and value.
+// The durations are ignored.
+func (r *Result[T]) IsMatch(other *Result[T]) bool {
+	if r == nil || other == nil {
+		return false
+	}
+	if r.meta.Algorithm != other.meta.Algorithm ||
+		!slices.Equal(r.meta.Inputs, other.meta.Inputs) ||
+		!maps.Equal(r.meta.Params, other.meta.Params) ||
+		!errors.CompareErrors(r.meta.Err, other.meta.Err) {
+		return false
+	}
+	if r.value == nil || other.value == nil {
+		return r.value == other.value
+	}
+	return reflect.DeepEqual(*r.value, *other.value)
+}
+
+// Print outputs the formatted Result, including the parameters and duration if v is true.
+func (r *Result[T]) Print(v bool) {
+	fmt.Print(formatResultOutput(r, v))
+}
+
+// formatResultOutput generates a formatted string representation of a Result, with parameters sorted by name
+// and the duration included in verbose mode.
+func formatResultOutput[T any](r *Result[T], verbose bool) string {
+	if r == nil {
+		return ""
+	}
+	output := fmt.Sprintf("Algorithm: %s\nInputs: %q\n", r.meta.Algorithm, r.meta.Inputs)
+	if verbose {
+		names := make([]string, 0, len(r.meta.Params))
+		for name := range r.meta.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		params := make([]string, 0, len(names))
+		for _, name := range names {
+			params = append(params, name+"="+r.meta.Params[name])
+		}
+		output += fmt.Sprintf("Params: %s\nDuration: %s\n", strings.Join(params, ", "), r.meta.Duration)
+	}
+	value, err := r.GetValue()
+	if err != nil {
+		return output + fmt.Sprintf("Error: %s\n", err)
+	}
+	return output + fmt.Sprintf("Value: %v\n", value)
+}
+
+// input returns the i-th input of the Result, or "" if it has fewer inputs.
+func (r *Result[T]) input(i int) string {
+	if i < len(r.meta.Inputs) {
+		return r.meta.Inputs[i]
+	}
+	return ""
+}
+
+// intParam returns the named parameter as an int and reports whether it is set to one.
+func (r *Result[T]) intParam(name string) (int, bool) {
+	value, err := strconv.Atoi(r.meta.Params[name])
+	return value, err == nil
+}
+
+// ToResult converts the SimilarityResult to a Result holding its score.
+func (sr *SimilarityResult) ToResult() *Result[float32] {
+	return NewResult(sr.score, ResultMeta{
+		Algorithm: sr.GetAlgorithmName(),
+		Inputs:    []string{sr.string1, sr.string2},
+		Err:       sr.err,
+	})
+}
+
+// ToResult converts the ComparisonResultInt to a Result holding its score, with the split length as the
+// "split_length" parameter if it is set.
+func (c *ComparisonResultInt) ToResult() *Result[int] {
+	return NewResult(c.score, ResultMeta{
+		Algorithm: c.GetTypeName(),
+		Inputs:    []string{c.string1, c.string2},
+		Params:    splitLengthParams(c.splitLength),
+		Err:       c.err,
+	})
+}
+
+// ToResult converts the ComparisonResultFloat to a Result holding its score, with the split length as the
+// "split_length" parameter if it is set.
+func (c *ComparisonResultFloat) ToResult() *Result[float32] {
+	return NewResult(c.score, ResultMeta{
+		Algorithm: c.GetTypeName(),
+		Inputs:    []string{c.string1, c.string2},
+		Params:    splitLengthParams(c.splitLength),
+		Err:       c.err,
+	})
+}
+
+// ToResult converts the LCSResult to a Result holding its substrings.
+func (lcs *LCSResult) ToResult() *Result[[]string] {
+	return NewResult(lcs.result, ResultMeta{
+		Algorithm: lcs.GetTypeName(),
+		Inputs:    []string{lcs.string1, lcs.string2},
+		Err:       lcs.err,
+	})
+}
+
+// ToResult converts the ShingleMapResult to a Result holding a copy of its shingle counts, with the n-gram length
+// as the "ngram" parameter.
+func (s *ShingleMapResult) ToResult() *Result[map[string]int] {
+	var shingles *map[string]int
+	if s.shingles != nil {
+		clone := maps.Clone(s.shingles)
+		shingles = &clone
+	}
+	return NewResult(shingles, shingleMeta(s.resultType, s.input, s.ngram, s.err))
+}
+
+// ToResult converts the ShingleSliceResult to a Result holding a copy of its shingles, with the n-gram length as
+// the "ngram" parameter.
+func (s *ShingleSliceResult) ToResult() *Result[[]string] {
+	var shingles *[]string
+	if s.shingles != nil {
+		clone := slices.Clone(*s.shingles)
+		shingles = &clone
+	}
+	return NewResult(shingles, shingleMeta(s.resultType, s.input, s.ngram, s.err))
+}
+
+// ToResult converts the MinHashResult to a Result holding a copy of its signature, with the n-gram length as the
+// "ngram" parameter.
+func (m *MinHashResult) ToResult() *Result[[]uint64] {
+	var signature *[]uint64
+	if m.signature != nil {
+		clone := slices.Clone(m.signature)
+		signature = &clone
+	}
+	return NewResult(signature, shingleMeta(m.resultType, m.input, m.ngram, m.err))
+}
+
+// ToResult converts the SimHashResult to a Result holding its fingerprint, with the n-gram length as the "ngram"
+// parameter.
+func (s *SimHashResult) ToResult() *Result[uint64] {
+	fingerprint := s.fingerprint
+	return NewResult(&fingerprint, shingleMeta(s.resultType, s.input, s.ngram, s.err))
+}
+
+// splitLengthParams returns the "split_length" parameter of a comparison result, or nil if it is not set.
+func splitLengthParams(splitLength *int) map[string]string {
+	if splitLength == nil {
+		return nil
+	}
+	return map[string]string{"split_length": strconv.Itoa(*splitLength)}
+}
+
+// shingleMeta returns the ResultMeta of a shingle result over input with the n-gram length as the "ngram"
+// parameter.
+func shingleMeta(resultType ShingleResultType, input string, ngram int, err error) ResultMeta {
+	return ResultMeta{
+		Algorithm: resultType.String(),
+		Inputs:    []string{input},
+		Params:    map[string]string{"ngram": strconv.Itoa(ngram)},
+		Err:       err,
+	}
+}
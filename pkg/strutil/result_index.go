@@ -0,0 +1,107 @@
+package strutil
+
+// resultIndex is the two-level shape shared by ComparisonResultsMap, SimilarityResultsMap, LCSResultsMap and
+// ShingleResultsMap: results keyed by their kind, such as an algorithm, and then by comparison string or n-gram
+// length. The map types keep their names and methods so callers can index them directly, and delegate to it.
+type resultIndex[K, S comparable, V any] map[K]map[S]*V
+
+// add inserts or replaces value under kind and key.
+func (ri resultIndex[K, S, V]) add(kind K, key S, value V) {
+	if ri[kind] == nil {
+		ri[kind] = make(map[S]*V)
+	}
+	ri[kind][key] = &value
+}
+
+// clone returns a copy of the index holding copies of every result, keeping kinds without results.
+func (ri resultIndex[K, S, V]) clone() resultIndex[K, S, V] {
+	cloned := make(resultIndex[K, S, V], len(ri))
+	for kind, results := range ri {
+		cloned[kind] = make(map[S]*V, len(results))
+		for key, result := range results {
+			if result != nil {
+				resultCopy := *result
+				cloned[kind][key] = &resultCopy
+			}
+		}
+	}
+	return cloned
+}
+
+// filter returns a new index holding copies of the results whose kind and key are accepted by keep,
+// or nil if none are.
+func (ri resultIndex[K, S, V]) filter(keep func(kind K, key S) bool) resultIndex[K, S, V] {
+	filtered := make(resultIndex[K, S, V])
+	for kind, results := range ri {
+		for key, result := range results {
+			if result != nil && keep(kind, key) {
+				filtered.add(kind, key, *result)
+			}
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+// values returns the results whose kind and key are accepted by keep, or nil if none are.
+func (ri resultIndex[K, S, V]) values(keep func(kind K, key S) bool) []*V {
+	var values []*V
+	for kind, results := range ri {
+		for key, result := range results {
+			if result != nil && keep(kind, key) {
+				values = append(values, result)
+			}
+		}
+	}
+	return values
+}
+
+// entryCount returns the number of non-nil results in the index.
+func (ri resultIndex[K, S, V]) entryCount() int {
+	count := 0
+	for _, results := range ri {
+		for _, result := range results {
+			if result != nil {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// isMatch reports whether both indexes hold the same kinds and keys with results accepted by match.
+func (ri resultIndex[K, S, V]) isMatch(other resultIndex[K, S, V], match func(a, b *V) bool) bool {
+	if len(ri) != len(other) || ri.entryCount() != other.entryCount() {
+		return false
+	}
+	for kind, results := range ri {
+		if other[kind] == nil {
+			return false
+		}
+		for key, result := range results {
+			if other[kind][key] == nil || !match(result, other[kind][key]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// convertResults converts every value with convert, returning nil for no values.
+func convertResults[V, R any](values []*V, convert func(*V) R) []R {
+	if len(values) == 0 {
+		return nil
+	}
+	results := make([]R, 0, len(values))
+	for _, v := range values {
+		results = append(results, convert(v))
+	}
+	return results
+}
+
+// derefResult returns the value v points to, for use with convertResults.
+func derefResult[V any](v *V) V {
+	return *v
+}
@@ -0,0 +1,191 @@
+package strutil
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// ResultStore is a collection of Results keyed by K, such as an Algorithm or a comparison string. Besides lookup
+// by key, stored results are indexed by algorithm name and by input string. Results are returned in the order
+// their keys were first added. A ResultStore is safe for concurrent use.
+type ResultStore[K comparable, T any] struct {
+	mu          sync.RWMutex
+	results     map[K]*Result[T]
+	order       []K
+	byAlgorithm map[string]map[K]bool
+	byInput     map[string]map[K]bool
+}
+
+// NewResultStore initializes and returns an empty ResultStore.
+func NewResultStore[K comparable, T any]() *ResultStore[K, T] {
+	return &ResultStore[K, T]{
+		results:     make(map[K]*Result[T]),
+		byAlgorithm: make(map[string]map[K]bool),
+		byInput:     make(map[string]map[K]bool),
+	}
+}
+
+// Add stores result under key, replacing any result previously stored under the same key while keeping its
+// position. A nil result is ignored.
+func (rs *ResultStore[K, T]) Add(key K, result *Result[T]) {
+	if result == nil {
+		return
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if old, ok := rs.results[key]; ok {
+		rs.unindex(key, old)
+	} else {
+		rs.order = append(rs.order, key)
+	}
+	rs.results[key] = result
+	addToIndex(rs.byAlgorithm, result.meta.Algorithm, key)
+	for _, input := range result.meta.Inputs {
+		addToIndex(rs.byInput, input, key)
+	}
+}
+
+// Get returns the result stored under key and reports whether it was present.
+func (rs *ResultStore[K, T]) Get(key K) (*Result[T], bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	result, ok := rs.results[key]
+	return result, ok
+}
+
+// Remove deletes the result stored under key and reports whether it was present.
+func (rs *ResultStore[K, T]) Remove(key K) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	result, ok := rs.results[key]
+	if !ok {
+		return false
+	}
+	rs.unindex(key, result)
+	delete(rs.results, key)
+	rs.order = slices.DeleteFunc(rs.order, func(k K) bool { return k == key })
+	return true
+}
+
+// Len returns the number of stored results.
+func (rs *ResultStore[K, T]) Len() int {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return len(rs.results)
+}
+
+// Keys returns the keys of the stored results, or nil if the store is empty.
+func (rs *ResultStore[K, T]) Keys() []K {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	if len(rs.order) == 0 {
+		return nil
+	}
+	return slices.Clone(rs.order)
+}
+
+// Results returns every stored result, or nil if the store is empty.
+func (rs *ResultStore[K, T]) Results() []*Result[T] {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.collect(func(K, *Result[T]) bool { return true })
+}
+
+// All returns an iterator over the stored keys and results in key order. It iterates over a snapshot taken when
+// iteration starts, so the store may be changed while iterating.
+func (rs *ResultStore[K, T]) All() iter.Seq2[K, *Result[T]] {
+	return func(yield func(K, *Result[T]) bool) {
+		rs.mu.RLock()
+		keys := slices.Clone(rs.order)
+		results := make([]*Result[T], len(keys))
+		for i, key := range keys {
+			results[i] = rs.results[key]
+		}
+		rs.mu.RUnlock()
+		for i, key := range keys {
+			if !yield(key, results[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ByAlgorithm returns the stored results produced by the named algorithm, or nil if there are none.
+func (rs *ResultStore[K, T]) ByAlgorithm(algorithm string) []*Result[T] {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.collect(func(key K, _ *Result[T]) bool { return rs.byAlgorithm[algorithm][key] })
+}
+
+// ByInput returns the stored results computed from the given input string, or nil if there are none.
+func (rs *ResultStore[K, T]) ByInput(input string) []*Result[T] {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.collect(func(key K, _ *Result[T]) bool { return rs.byInput[input][key] })
+}
+
+// Filter returns a new ResultStore holding the results for which keep returns true.
+func (rs *ResultStore[K, T]) Filter(keep func(key K, result *Result[T]) bool) *ResultStore[K, T] {
+	filtered := NewResultStore[K, T]()
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	for _, key := range rs.order {
+		if result := rs.results[key]; keep(key, result) {
+			filtered.Add(key, result)
+		}
+	}
+	return filtered
+}
+
+// GetCopy returns a new ResultStore holding the same results. Adding or removing results in either store does not
+// affect the other, but the *Result values are shared, as are the slices and maps they hold, so changing the
+// elements of a value returned by GetValue shows in both stores.
+func (rs *ResultStore[K, T]) GetCopy() *ResultStore[K, T] {
+	return rs.Filter(func(K, *Result[T]) bool { return true })
+}
+
+// Print outputs every stored result, optionally in verbose mode.
+func (rs *ResultStore[K, T]) Print(v bool) *ResultStore[K, T] {
+	for _, result := range rs.Results() {
+		fmt.Print(formatResultOutput(result, v) + "\n")
+	}
+	return rs
+}
+
+// collect returns the stored results accepted by keep in key order, or nil if there are none.
+// Callers must hold the read lock.
+func (rs *ResultStore[K, T]) collect(keep func(key K, result *Result[T]) bool) []*Result[T] {
+	var results []*Result[T]
+	for _, key := range rs.order {
+		if keep(key, rs.results[key]) {
+			results = append(results, rs.results[key])
+		}
+	}
+	return results
+}
+
+// unindex removes key from the indexes of result. Callers must hold the write lock.
+func (rs *ResultStore[K, T]) unindex(key K, result *Result[T]) {
+	removeFromIndex(rs.byAlgorithm, result.meta.Algorithm, key)
+	for _, input := range result.meta.Inputs {
+		removeFromIndex(rs.byInput, input, key)
+	}
+}
+
+// addToIndex records key under name in index.
+func addToIndex[K comparable](index map[string]map[K]bool, name string, key K) {
+	if index[name] == nil {
+		index[name] = make(map[K]bool)
+	}
+	index[name][key] = true
+}
+
+// removeFromIndex deletes key from name in index, dropping name once it has no keys left.
+func removeFromIndex[K comparable](index map[string]map[K]bool, name string, key K) {
+	delete(index[name], key)
+	if len(index[name]) == 0 {
+		delete(index, name)
+	}
+}
@@ -0,0 +1,96 @@
+package strutil
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// resultAlgorithms returns the algorithm name of every result, in order.
+func resultAlgorithms[T any](results []*Result[T]) []string {
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.GetAlgorithm())
+	}
+	return names
+}
+
+func TestResultStore(t *testing.T) {
+	store := NewResultStore[Algorithm, float32]()
+	for _, algo := range []Algorithm{Jaro, Levenshtein, Cosine} {
+		store.Add(algo, similarity("apple", "apply", algo).ToResult())
+	}
+	store.Add(Hamming, nil)
+	if store.Len() != 3 || fmt.Sprint(store.Keys()) != fmt.Sprint([]Algorithm{Jaro, Levenshtein, Cosine}) {
+		t.Fatalf("Keys() = %v; want Jaro, Levenshtein and Cosine in insertion order", store.Keys())
+	}
+	store.Add(Jaro, similarity("apple", "maple", Jaro).ToResult())
+	if r, ok := store.Get(Jaro); !ok || r.GetInputs()[1] != "maple" || store.Keys()[0] != Jaro {
+		t.Errorf("Get(Jaro) = %v, %v; want the replacement in its original position", r, ok)
+	}
+	if got := resultAlgorithms(store.ByInput("apply")); fmt.Sprint(got) != "[Levenshtein Cosine]" {
+		t.Errorf("ByInput(apply) = %v; want Levenshtein and Cosine", got)
+	}
+	if got := store.ByInput("maple"); len(got) != 1 || got[0].GetAlgorithm() != "Jaro" {
+		t.Errorf("ByInput(maple) = %v; want the Jaro result", resultAlgorithms(got))
+	}
+	if got := store.ByAlgorithm("Cosine"); len(got) != 1 || store.ByAlgorithm("Hamming") != nil {
+		t.Errorf("ByAlgorithm(Cosine) = %v; want one result and none for Hamming", resultAlgorithms(got))
+	}
+
+	var all []Algorithm
+	for algo, r := range store.All() {
+		all = append(all, algo)
+		store.Add(Hamming, r)
+		break
+	}
+	if fmt.Sprint(all) != "[Jaro]" || store.Len() != 4 || !store.Remove(Hamming) {
+		t.Errorf("All() yielded %v and allowed an Add; want Jaro first and the Hamming result stored", all)
+	}
+
+	filtered := store.Filter(func(algo Algorithm, _ *Result[float32]) bool { return algo != Jaro })
+	if filtered.Len() != 2 || filtered.ByInput("maple") != nil {
+		t.Errorf("Filter() kept %v; want Levenshtein and Cosine", filtered.Keys())
+	}
+	copied := store.GetCopy()
+	if !store.Remove(Levenshtein) || store.Remove(Levenshtein) || store.Len() != 2 || copied.Len() != 3 {
+		t.Errorf("Remove(Levenshtein) left %v and the copy %v; want it removed from the store only",
+			store.Keys(), copied.Keys())
+	}
+	if got := resultAlgorithms(store.ByInput("apply")); fmt.Sprint(got) != "[Cosine]" {
+		t.Errorf("ByInput(apply) after Remove = %v; want Cosine", got)
+	}
+	if results := NewResultStore[string, int]().Results(); results != nil {
+		t.Errorf("Results() of an empty store = %v; want nil", results)
+	}
+}
+
+// TestResultStoreConcurrentAccess shares one store between writers and readers; run it with -race to check
+// the locking.
+func TestResultStoreConcurrentAccess(t *testing.T) {
+	store := NewResultStore[string, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				other := fmt.Sprintf("word%d-%d", w, i)
+				store.Add(other, levenshteinDistance("word", other).ToResult())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				store.ByInput("word")
+				store.ByAlgorithm(LevDist.String())
+				store.Get(fmt.Sprintf("word%d-%d", w, i))
+				store.GetCopy()
+			}
+		}()
+	}
+	wg.Wait()
+	if store.Len() != 400 || len(store.ByInput("word")) != 400 {
+		t.Errorf("concurrent Add stored %d results; want 400 indexed by input", store.Len())
+	}
+}
@@ -0,0 +1,117 @@
+package strutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+func TestComputeResult(t *testing.T) {
+	params := map[string]string{"q": "2"}
+	inputs := []string{"night", "nacht"}
+	r := ComputeResult("Q-Gram Distance", inputs, params, func() (int, error) {
+		time.Sleep(time.Millisecond)
+		return 4, nil
+	})
+	params["q"], inputs[0] = "3", "day"
+	if value, err := r.GetValue(); err != nil || value != 4 {
+		t.Errorf("GetValue() = %d, %v; want 4", value, err)
+	}
+	if q, ok := r.GetParam("q"); !ok || q != "2" || !reflect.DeepEqual(r.GetInputs(), []string{"night", "nacht"}) {
+		t.Errorf("ComputeResult kept params %v and inputs %v; want copies of the originals",
+			r.GetMeta().Params, r.GetInputs())
+	}
+	if r.GetDuration() < time.Millisecond || r.GetAlgorithm() != "Q-Gram Distance" {
+		t.Errorf("ComputeResult meta = %+v; want the algorithm and a measured duration", r.GetMeta())
+	}
+
+	failed := ComputeResult("Hamming Distance", []string{"a", "bc"}, nil, func() (int, error) {
+		return 7, errors2.ErrHammingDistanceFailure
+	})
+	if value, err := failed.GetValue(); !errors.Is(err, errors2.ErrHammingDistanceFailure) || value != 0 {
+		t.Errorf("GetValue() = %d, %v; want 0 and %v", value, err, errors2.ErrHammingDistanceFailure)
+	}
+	if _, err := NewResult[int](nil, ResultMeta{}).GetValue(); !errors.Is(err, errors2.ErrNilScore) {
+		t.Errorf("GetValue() of an empty Result error = %v; want %v", err, errors2.ErrNilScore)
+	}
+}
+
+func TestResultIsMatch(t *testing.T) {
+	one, two := 1, 2
+	meta := ResultMeta{Algorithm: "LCS Length", Inputs: []string{"a", "b"}, Duration: time.Second}
+	base := NewResult(&one, meta)
+	tests := []struct {
+		name     string
+		other    *Result[int]
+		expected bool
+	}{
+		{"MatchIgnoresDuration", NewResult(&one, ResultMeta{Algorithm: "LCS Length", Inputs: []string{"a", "b"}}), true},
+		{"MatchValue", NewResult(&two, meta), false},
+		{"MatchNilValue", NewResult[int](nil, meta), false},
+		{"MatchInputs", NewResult(&one, ResultMeta{Algorithm: "LCS Length", Inputs: []string{"b", "a"}}), false},
+		{"MatchParams", NewResult(&one, ResultMeta{Algorithm: "LCS Length", Inputs: []string{"a", "b"},
+			Params: map[string]string{"q": "2"}}), false},
+		{"MatchError", NewResult(&one, ResultMeta{Algorithm: "LCS Length", Inputs: []string{"a", "b"},
+			Err: errors2.ErrNilScore}), false},
+		{"MatchNil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if match := base.IsMatch(tt.other); match != tt.expected {
+				t.Errorf("IsMatch() = %v; want %v", match, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToResult(t *testing.T) {
+	sim := similarity("kitten", "sitting", Levenshtein)
+	simScore, simErr := sim.GetScore()
+	if r := sim.ToResult(); r.GetAlgorithm() != "Levenshtein" ||
+		!reflect.DeepEqual(r.GetInputs(), []string{"kitten", "sitting"}) {
+		t.Errorf("SimilarityResult.ToResult() meta = %+v; want Levenshtein over both strings", r.GetMeta())
+	} else if score, err := r.GetValue(); score != simScore || err != simErr {
+		t.Errorf("SimilarityResult.ToResult() value = %f, %v; want %f, %v", score, err, simScore, simErr)
+	}
+
+	jaccard := jaccardSimilarity("kitten", "sitting", 2)
+	if r := jaccard.ToResult(); r.GetAlgorithm() != JaccardSim.String() {
+		t.Errorf("ComparisonResultFloat.ToResult() algorithm = %s; want %s", r.GetAlgorithm(), JaccardSim)
+	} else if split, ok := r.GetParam("split_length"); !ok || split != "2" {
+		t.Errorf("ComparisonResultFloat.ToResult() split_length = %q, %v; want 2", split, ok)
+	}
+	if distance, err := levenshteinDistance("kitten", "sitting").ToResult().GetValue(); err != nil || distance != 3 {
+		t.Errorf("ComparisonResultInt.ToResult() value = %d, %v; want 3", distance, err)
+	}
+	if _, ok := levenshteinDistance("a", "b").ToResult().GetParam("split_length"); ok {
+		t.Error("ComparisonResultInt.ToResult() set split_length without a split length")
+	}
+
+	lcs := lcsBacktrackAll("ABCBDAB", "BDCABA").ToResult()
+	if substrings, err := lcs.GetValue(); err != nil || len(substrings) == 0 {
+		t.Errorf("LCSResult.ToResult() value = %v, %v; want the substrings", substrings, err)
+	}
+
+	shingles := shingle("abab", 2)
+	r := shingles.ToResult()
+	counts, err := r.GetValue()
+	if err != nil || !reflect.DeepEqual(counts, map[string]int{"ab": 2, "ba": 1}) {
+		t.Errorf("ShingleMapResult.ToResult() value = %v, %v; want the shingle counts", counts, err)
+	}
+	counts["ab"] = 9
+	if shingles.GetShinglesMap()["ab"] != 2 {
+		t.Error("ShingleMapResult.ToResult() shares its shingles with the result")
+	}
+	if ngram, _ := shingleSlice("abab", 3).ToResult().GetParam("ngram"); ngram != "3" {
+		t.Errorf("ShingleSliceResult.ToResult() ngram = %q; want 3", ngram)
+	}
+
+	out := formatResultOutput(jaccard.ToResult(), true)
+	if !strings.Contains(out, "Algorithm: Jaccard Similarity\n") || !strings.Contains(out, "Params: split_length=2\n") {
+		t.Errorf("formatResultOutput() = %q; want the algorithm and params", out)
+	}
+}
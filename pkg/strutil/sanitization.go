@@ -5,6 +5,12 @@ func SanitizeHTML(s string) string {
 	return sanitizeHTML(s)
 }
 
+// SanitizeHTMLWith removes all markup from the input HTML string that policy does not allow.
+// Use NewSanitizePolicy to start from a preset.
+func SanitizeHTMLWith(s string, policy SanitizePolicy) string {
+	return sanitizeHTMLWith(s, policy)
+}
+
 // RemoveWhitespace removes all whitespace characters (spaces, tabs, newlines, etc.)
 // from the input string and returns the result.
 func RemoveWhitespace(s string) string {
@@ -67,6 +67,15 @@ func (sb *StringBuilder) SanitizeHTML() *StringBuilder {
 	return sb
 }
 
+// SanitizeHTMLWith removes all markup from the StringBuilder's value that policy does not allow.
+func (sb *StringBuilder) SanitizeHTMLWith(policy SanitizePolicy) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeHTMLWith(sb.value, policy))
+	return sb
+}
+
 // RemoveNonPrintable removes non-printable characters from the StringBuilder's value and replaces them with '_'.
 // Returns the modified StringBuilder instance.
 func (sb *StringBuilder) RemoveNonPrintable() *StringBuilder {
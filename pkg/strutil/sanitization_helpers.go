@@ -13,26 +13,46 @@ import (
 var (
 	// StrictPolicy is a sanitizer instance that removes all HTML tags
 	// and only allows plain text content for strict enforcement.
+	//
+	// Deprecated: RemoveHTML no longer uses it, so changing it has no effect; use SanitizeHTMLWith with
+	// NewSanitizePolicy(SanitizeStrict) instead.
 	StrictPolicy = bluemonday.StrictPolicy()
 	// StrictPolicyWithSpaces is a sanitizer that removes all HTML tags but
 	//retains spaces when stripping tags for better readability.
+	//
+	// Deprecated: RemoveHTML no longer uses it, so changing it has no effect; use SanitizeHTMLWith with a
+	// SanitizeStrict policy that sets AddSpaceWhenStrippingTag instead.
 	StrictPolicyWithSpaces = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
 	// UGCPolicy defines a bluemonday policy specifically designed for user-generated content sanitization.
+	//
+	// Deprecated: SanitizeHTML no longer uses it, so changing it has no effect; use SanitizeHTMLWith with
+	// NewSanitizePolicy(SanitizeUGC) instead.
 	UGCPolicy = bluemonday.UGCPolicy()
+
+	// strictPolicy, strictPolicyWithSpaces and ugcPolicy back RemoveHTML and SanitizeHTML. They are unexported
+	// so callers cannot change the behavior of those functions for everyone else.
+	strictPolicy           = bluemonday.StrictPolicy()
+	strictPolicyWithSpaces = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
+	ugcPolicy              = bluemonday.UGCPolicy()
 )
 
 // sanitizeHTML sanitizes an input HTML string by removing potentially unsafe or harmful content.
 func sanitizeHTML(s string) string {
-	return UGCPolicy.Sanitize(s)
+	return ugcPolicy.Sanitize(s)
+}
+
+// sanitizeHTMLWith sanitizes an input HTML string, keeping only the markup allowed by policy.
+func sanitizeHTMLWith(s string, policy SanitizePolicy) string {
+	return policy.bluemonday().Sanitize(s)
 }
 
 // removeHTML removes all HTML tags and sanitizes the input string to prevent potential security risks.
 func removeHTML(s string, preserveSpaces bool) string {
 	stripped := ""
 	if preserveSpaces {
-		stripped = StrictPolicyWithSpaces.Sanitize(s)
+		stripped = strictPolicyWithSpaces.Sanitize(s)
 	} else {
-		stripped = StrictPolicy.Sanitize(s)
+		stripped = strictPolicy.Sanitize(s)
 	}
 	return strings.TrimSpace(stripped)
 }
@@ -1,6 +1,10 @@
 package strutil
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/microcosm-cc/bluemonday"
+)
 
 func TestCleanWhitespace(t *testing.T) {
 	tests := []struct {
@@ -202,6 +206,79 @@ func TestSanitizeHTML(t *testing.T) {
 	}
 }
 
+func TestSanitizeHTMLWith(t *testing.T) {
+	custom := NewSanitizePolicy(SanitizeBasicFormatting)
+	custom.Elements = append(custom.Elements, "a")
+	custom.Attributes = map[string][]string{"a": {"href"}, "*": {"title", "style"}}
+	custom.URLSchemes = []string{"https"}
+	custom.StyleProperties = []string{"color"}
+	spaced := NewSanitizePolicy(SanitizeStrict)
+	spaced.AddSpaceWhenStrippingTag = true
+	tests := []struct {
+		name     string
+		policy   SanitizePolicy
+		input    string
+		expected string
+	}{
+		{"Strict", NewSanitizePolicy(SanitizeStrict), "<p>Hi <b>there</b></p><script>x()</script>", "Hi there"},
+		{"StrictSpaces", spaced, "<p>one</p><p>two</p>", " one  two "},
+		{"UnknownPreset", NewSanitizePolicy(SanitizePreset(99)), "<b>bold</b>", "bold"},
+		{"BasicKeepsFormatting", NewSanitizePolicy(SanitizeBasicFormatting),
+			`<p class="x">a <strong>b</strong> <a href="https://x.io">c</a></p>`, "<p>a <strong>b</strong> c</p>"},
+		{"UGCLinks", NewSanitizePolicy(SanitizeUGC),
+			`<a onclick="x()" href="https://x.io">x</a> <a href="javascript:alert(1)">y</a> <img src="a.png">`,
+			`<a href="https://x.io" rel="nofollow">x</a> y `},
+		{"UGCRelative", NewSanitizePolicy(SanitizeUGC), `<a href="/docs">docs</a>`,
+			`<a href="/docs" rel="nofollow">docs</a>`},
+		{"UGCDropsStyle", NewSanitizePolicy(SanitizeUGC), `<span style="color: red">r</span>`, `<span>r</span>`},
+		{"RichTextImages", NewSanitizePolicy(SanitizeRichText),
+			`<img src="https://x.io/a.png" alt="a" onerror="x()"><span style="color: red; position: fixed">r</span>`,
+			`<img src="https://x.io/a.png" alt="a"><span style="color: red">r</span>`},
+		{"EmailLayout", NewSanitizePolicy(SanitizeEmail),
+			`<table width="600" onload="x()"><tr><td bgcolor="#fff"><font color="red">Hi</font>` +
+				`<img src="cid:logo"></td></tr></table>`,
+			`<table width="600"><tr><td bgcolor="#fff"><font color="red">Hi</font><img src="cid:logo"></td></tr></table>`},
+		{"EmailNoNoFollow", NewSanitizePolicy(SanitizeEmail), `<a href="mailto:a@b.io">a</a>`,
+			`<a href="mailto:a@b.io">a</a>`},
+		{"CustomAllowLists", custom,
+			`<b title="t" style="color: red; width: 1px">b</b><a href="http://x.io">h</a><a href="https://x.io">s</a>`,
+			`<b title="t" style="color: red">b</b>h<a href="https://x.io">s</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeHTMLWith(tt.input, tt.policy)
+			result := SanitizeHTMLWith(tt.input, tt.policy)
+			builderResult := New(tt.input).SanitizeHTMLWith(tt.policy).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeHTMLWith - expected %q - got %q / %q / %q", tt.expected, helperResult, result,
+					builderResult)
+			}
+		})
+	}
+}
+
+func TestSanitizePolicyIsolation(t *testing.T) {
+	changed := NewSanitizePolicy(SanitizeRichText)
+	changed.Elements[0] = "script"
+	changed.Attributes["a"] = append(changed.Attributes["a"], "onclick")
+	fresh := NewSanitizePolicy(SanitizeRichText)
+	if fresh.Elements[0] == "script" || len(fresh.Attributes["a"]) != 2 {
+		t.Errorf("NewSanitizePolicy(SanitizeRichText) = %+v; want it unaffected by changes to another policy", fresh)
+	}
+	if ugc := NewSanitizePolicy(SanitizeUGC); len(ugc.StyleProperties) != 0 || ugc.Attributes["img"] != nil {
+		t.Errorf("NewSanitizePolicy(SanitizeUGC) = %+v; want it unaffected by the rich text preset", ugc)
+	}
+	if SanitizeEmail.String() != "Email" {
+		t.Errorf("SanitizeEmail.String() = %q; want Email", SanitizeEmail.String())
+	}
+
+	UGCPolicy.AllowElements("script")
+	defer func() { UGCPolicy = bluemonday.UGCPolicy() }()
+	if out := SanitizeHTML("<script>x()</script>ok"); out != "ok" {
+		t.Errorf("SanitizeHTML after changing UGCPolicy = %q; want ok", out)
+	}
+}
+
 func TestEscapeHTML(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,157 @@
+package strutil
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// SanitizePreset selects the starting allow-lists of a SanitizePolicy.
+type SanitizePreset int
+
+// String returns the string representation of the SanitizePreset using SanitizePresetMap.
+func (p SanitizePreset) String() string {
+	return SanitizePresetMap[p]
+}
+
+// SanitizeStrict removes every tag and keeps only text.
+// SanitizeBasicFormatting keeps inline and block formatting such as bold, italics, paragraphs and code.
+// SanitizeUGC keeps the markup of user comments: formatting, headings, lists, tables and nofollow links.
+// SanitizeRichText keeps the UGC markup plus images, figures and a small set of inline text styles.
+// SanitizeEmail keeps the table layouts, font tags and inline styles of HTML email, with cid: images.
+const (
+	SanitizeStrict SanitizePreset = iota
+	SanitizeBasicFormatting
+	SanitizeUGC
+	SanitizeRichText
+	SanitizeEmail
+)
+
+// SanitizePresetMap maps SanitizePreset constants to their corresponding string representations.
+var SanitizePresetMap = map[SanitizePreset]string{
+	SanitizeStrict:          "Strict",
+	SanitizeBasicFormatting: "Basic Formatting",
+	SanitizeUGC:             "User-Generated Content",
+	SanitizeRichText:        "Rich Text With Images",
+	SanitizeEmail:           "Email",
+}
+
+// SanitizePolicy lists the HTML that SanitizeHTMLWith keeps; everything else is removed. Policies are plain
+// values, so each caller can start from a preset and adjust it without affecting anyone else.
+type SanitizePolicy struct {
+	// Elements lists the tags that are kept, in lower case. The text of removed tags is kept, except for
+	// script and style contents.
+	Elements []string
+	// Attributes maps an element of Elements to the attributes it may carry; the "*" key applies to every
+	// kept element. Style attributes are controlled by StyleProperties instead.
+	Attributes map[string][]string
+	// URLSchemes lists the schemes allowed in URL attributes such as href and src. Unparseable URLs and URLs
+	// with other schemes are removed.
+	URLSchemes []string
+	// AllowRelativeURLs keeps URLs without a scheme.
+	AllowRelativeURLs bool
+	// StyleProperties lists the CSS properties kept in style attributes of every kept element.
+	StyleProperties []string
+	// RequireNoFollowLinks adds rel="nofollow" to links.
+	RequireNoFollowLinks bool
+	// AddSpaceWhenStrippingTag replaces removed tags with a space so words on either side stay apart.
+	AddSpaceWhenStrippingTag bool
+}
+
+// basicFormattingElements are the formatting tags kept by every preset except SanitizeStrict.
+var basicFormattingElements = []string{
+	"b", "strong", "i", "em", "u", "s", "del", "ins", "mark", "small", "sub", "sup", "br", "p", "code",
+	"pre", "blockquote",
+}
+
+// ugcElements are the structural tags added by SanitizeUGC.
+var ugcElements = []string{
+	"a", "abbr", "cite", "q", "span", "div", "hr", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "li", "dl",
+	"dt", "dd", "table", "caption", "thead", "tbody", "tfoot", "tr", "th", "td",
+}
+
+// textStyleProperties are the CSS properties kept by SanitizeRichText.
+var textStyleProperties = []string{
+	"color", "background-color", "text-align", "text-decoration", "font-weight", "font-style",
+}
+
+// NewSanitizePolicy returns a new SanitizePolicy holding the allow-lists of the given preset.
+// Unknown presets return the SanitizeStrict policy.
+func NewSanitizePolicy(preset SanitizePreset) SanitizePolicy {
+	switch preset {
+	case SanitizeBasicFormatting:
+		return SanitizePolicy{Elements: slices.Clone(basicFormattingElements)}
+	case SanitizeUGC:
+		return SanitizePolicy{
+			Elements:             slices.Concat(basicFormattingElements, ugcElements),
+			Attributes:           map[string][]string{"a": {"href", "title"}, "abbr": {"title"}},
+			URLSchemes:           []string{"http", "https", "mailto"},
+			AllowRelativeURLs:    true,
+			RequireNoFollowLinks: true,
+		}
+	case SanitizeRichText:
+		policy := NewSanitizePolicy(SanitizeUGC)
+		policy.Elements = append(policy.Elements, "img", "figure", "figcaption")
+		policy.Attributes["img"] = []string{"src", "alt", "title", "width", "height"}
+		policy.StyleProperties = slices.Clone(textStyleProperties)
+		return policy
+	case SanitizeEmail:
+		policy := NewSanitizePolicy(SanitizeRichText)
+		policy.Elements = append(policy.Elements, "font", "center", "col", "colgroup")
+		policy.Attributes["font"] = []string{"color", "face", "size"}
+		for _, el := range []string{"table", "tr", "td", "th", "col", "colgroup"} {
+			policy.Attributes[el] = []string{"align", "valign", "width", "height", "bgcolor", "border",
+				"cellpadding", "cellspacing", "colspan", "rowspan"}
+		}
+		policy.URLSchemes = append(policy.URLSchemes, "cid")
+		policy.StyleProperties = append(policy.StyleProperties, "font-family", "font-size", "line-height",
+			"margin", "padding", "border", "width", "height", "vertical-align")
+		policy.RequireNoFollowLinks = false
+		return policy
+	default:
+		return SanitizePolicy{}
+	}
+}
+
+// bluemonday builds the bluemonday policy enforcing the allow-lists.
+func (sp SanitizePolicy) bluemonday() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(sp.Elements...)
+	for el, attrs := range sp.Attributes {
+		attrs = slices.DeleteFunc(slices.Clone(attrs), func(attr string) bool { return attr == "style" })
+		if len(attrs) == 0 {
+			continue
+		}
+		if el == "*" {
+			p.AllowAttrs(attrs...).OnElements(sp.Elements...)
+		} else if slices.Contains(sp.Elements, el) {
+			p.AllowAttrs(attrs...).OnElements(el)
+		}
+	}
+	if len(sp.StyleProperties) > 0 && len(sp.Elements) > 0 {
+		p.AllowStyles(sp.StyleProperties...).OnElements(sp.Elements...)
+	}
+	p.RequireParseableURLs(true)
+	p.AllowRelativeURLs(sp.AllowRelativeURLs)
+	if len(sp.URLSchemes) > 0 {
+		p.AllowURLSchemes(sp.URLSchemes...)
+	}
+	p.RequireNoFollowOnLinks(sp.RequireNoFollowLinks)
+	p.AddSpaceWhenStrippingTag(sp.AddSpaceWhenStrippingTag)
+	return p
+}
+
+// allowsURL reports whether the policy allows a link or image to point to rawURL: relative URLs if
+// AllowRelativeURLs is set, and absolute URLs with one of the URLSchemes. Unparseable URLs are not allowed.
+func (sp SanitizePolicy) allowsURL(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		return sp.AllowRelativeURLs
+	}
+	return slices.ContainsFunc(sp.URLSchemes, func(scheme string) bool { return strings.EqualFold(scheme, u.Scheme) })
+}
@@ -0,0 +1,94 @@
+package strutil
+
+import (
+	"github.com/bmj2728/utils/pkg/pattern"
+)
+
+// The IDs of the built-in secret scanning rules, which are also their names in DefaultSecretRules.
+const (
+	// SecretAWSAccessKey matches AWS access key IDs such as "AKIA...".
+	SecretAWSAccessKey = "aws-access-key-id"
+	// SecretGitHubToken matches GitHub personal access, OAuth, app and refresh tokens.
+	SecretGitHubToken = "github-token"
+	// SecretGitLabToken matches GitLab personal, deploy, runner and trigger tokens.
+	SecretGitLabToken = "gitlab-token"
+	// SecretSlackToken matches Slack bot, user, app and refresh tokens.
+	SecretSlackToken = "slack-token"
+	// SecretSlackWebhook matches Slack incoming webhook URLs.
+	SecretSlackWebhook = "slack-webhook-url"
+	// SecretPrivateKey matches PEM encoded private keys, from the BEGIN header to the END footer if there is one.
+	SecretPrivateKey = "private-key"
+	// SecretPasswordAssignment matches the value of assignments such as `password=...` or `"api_key": "..."`.
+	SecretPasswordAssignment = "password-assignment"
+	// SecretHighEntropyBase64 matches base64 blobs of at least 20 characters whose entropy is at least
+	// SecretOptions.Base64Entropy.
+	SecretHighEntropyBase64 = "high-entropy-base64"
+	// SecretHighEntropyHex matches hexadecimal blobs of at least 32 characters whose entropy is at least
+	// SecretOptions.HexEntropy. Note that this includes git object IDs and other hashes.
+	SecretHighEntropyHex = "high-entropy-hex"
+)
+
+// SecretFinding is a credential found by ScanSecrets.
+type SecretFinding struct {
+	// RuleID is the name of the rule that matched.
+	RuleID string
+	// Start and End are the byte offsets of the secret. Finding does not hold the secret itself, which is
+	// s[Start:End], so findings can be logged safely.
+	Start int
+	End   int
+	// Preview shows at most the first four characters of the secret followed by "****".
+	Preview string
+	// Entropy is the Shannon entropy of the secret in bits per character.
+	Entropy float64
+}
+
+// SecretOptions configures ScanSecretsWith.
+type SecretOptions struct {
+	// Rules are the rules to run, keyed by rule ID. If a rule has capturing groups, the first group that
+	// participates in a match is the secret, otherwise the whole match is. Rules named after a built-in rule are
+	// validated like it, so their patterns can be replaced. The rules can be serialized to and from JSON.
+	Rules pattern.CustomPatternSet
+	// Base64Entropy is the minimum entropy of a SecretHighEntropyBase64 match.
+	Base64Entropy float64
+	// HexEntropy is the minimum entropy of a SecretHighEntropyHex match.
+	HexEntropy float64
+}
+
+// NewSecretOptions returns SecretOptions that run every built-in rule, with minimum entropies of 4.5 bits per
+// character for base64 blobs and 3 bits per character for hexadecimal blobs.
+func NewSecretOptions() SecretOptions {
+	return SecretOptions{
+		Rules:         DefaultSecretRules(),
+		Base64Entropy: 4.5,
+		HexEntropy:    3,
+	}
+}
+
+// DefaultSecretRules returns a new rule set containing the built-in secret scanning rules. Rules can be removed,
+// replaced or added before passing the set in SecretOptions.
+func DefaultSecretRules() pattern.CustomPatternSet {
+	return defaultSecretRules()
+}
+
+// ShannonEntropy returns the Shannon entropy of the characters of s in bits per character. Random base64 has an
+// entropy of up to 6 and random hexadecimal up to 4, while English text is usually below 4.5.
+func ShannonEntropy(s string) float64 {
+	return shannonEntropy(s)
+}
+
+// ScanSecrets finds leaked credentials in s using NewSecretOptions: cloud and chat service tokens, private keys,
+// password assignments and high entropy blobs. Findings are ordered by position and do not overlap; where rules
+// overlap the specific rules win over the high entropy rules, then the longest match wins.
+func ScanSecrets(s string) []SecretFinding {
+	return scanSecrets(s, NewSecretOptions())
+}
+
+// ScanSecretsWith finds leaked credentials in s using the rules and entropy thresholds of opts.
+func ScanSecretsWith(s string, opts SecretOptions) []SecretFinding {
+	return scanSecrets(s, opts)
+}
+
+// HasSecrets reports whether ScanSecretsWith finds any credential in s.
+func HasSecrets(s string, opts SecretOptions) bool {
+	return hasSecrets(s, opts)
+}
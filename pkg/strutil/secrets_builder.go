@@ -0,0 +1,28 @@
+package strutil
+
+import (
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// HasSecrets reports whether the string contains a credential found by the rules of opts.
+func (sb *StringBuilder) HasSecrets(opts SecretOptions) bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return hasSecrets(sb.value, opts)
+}
+
+// RequireNoSecrets ensures the string contains no credential found by the rules of opts, setting ErrSecretFound
+// otherwise.
+func (sb *StringBuilder) RequireNoSecrets(opts SecretOptions) *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	if findings := scanSecrets(value, opts); len(findings) > 0 {
+		ve := newValidationError(value, "no_secrets", ReasonContainsSecret, findings[0].Start, errors.ErrSecretFound)
+		ve.Params = map[string]string{"secret_rule": findings[0].RuleID}
+		return sb.failValidation(ve)
+	}
+	return sb
+}
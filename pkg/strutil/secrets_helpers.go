@@ -0,0 +1,136 @@
+package strutil
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bmj2728/utils/pkg/pattern"
+)
+
+// secretRules are the patterns of the built-in secret scanning rules.
+var secretRules = map[string]*regexp.Regexp{
+	SecretAWSAccessKey: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA|A3T[A-Z0-9])[A-Z0-9]{16}\b`),
+	SecretGitHubToken:  regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{82})\b`),
+	SecretGitLabToken:  regexp.MustCompile(`\bgl(?:pat|dt|rt|ptt|ft|soat)-[A-Za-z0-9_-]{20,}`),
+	SecretSlackToken:   regexp.MustCompile(`\bxox[abposre]-(?:\d+-)*[A-Za-z0-9-]{10,}`),
+	SecretSlackWebhook: regexp.MustCompile(
+		`https://hooks\.slack\.com/(?:services|workflows)/[A-Za-z0-9_]+/[A-Za-z0-9_]+/[A-Za-z0-9_]+`),
+	SecretPrivateKey: regexp.MustCompile(`-----BEGIN[A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----` +
+		`(?:[\s\S]*?-----END[A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----)?`),
+	SecretPasswordAssignment: regexp.MustCompile(
+		`(?i)(?:password|passwd|pwd|passphrase|secret|api[_-]?key|access[_-]?key|auth[_-]?token)` +
+			`[A-Za-z0-9_-]*["']?\s*(?::=|[:=])\s*(?:"([^"\n]+)"|'([^'\n]+)'|([^\s"',;&=][^\s"',;&]*))`),
+	SecretHighEntropyBase64: regexp.MustCompile(`[A-Za-z0-9+/_-]{20,}={0,2}`),
+	SecretHighEntropyHex:    regexp.MustCompile(`[0-9A-Fa-f]{32,}`),
+}
+
+// secretPlaceholders are values of password assignments that are not secrets.
+var secretPlaceholders = map[string]bool{
+	"null": true, "nil": true, "none": true, "true": true, "false": true, "empty": true, "redacted": true,
+	"changeme": true, "password": true, "secret": true,
+}
+
+// defaultSecretRules returns a new pattern set with the patterns of the built-in rules.
+func defaultSecretRules() pattern.CustomPatternSet {
+	set := pattern.NewCustomPatternSet()
+	for id, re := range secretRules {
+		set.Add(id, re)
+	}
+	return set
+}
+
+// scanSecrets runs the rules of opts over s and returns the non-overlapping findings in order.
+func scanSecrets(s string, opts SecretOptions) []SecretFinding {
+	var candidates []SecretFinding
+	for id, re := range opts.Rules {
+		if re == nil {
+			continue
+		}
+		for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+			start, end := secretSpan(loc)
+			if start == end || !isSecret(s, start, end, id, opts) {
+				continue
+			}
+			candidates = append(candidates, SecretFinding{
+				RuleID:  id,
+				Start:   start,
+				End:     end,
+				Preview: secretPreview(s[start:end]),
+				Entropy: shannonEntropy(s[start:end]),
+			})
+		}
+	}
+	return resolveSecretOverlaps(candidates)
+}
+
+// hasSecrets reports whether the rules of opts find a credential in s.
+func hasSecrets(s string, opts SecretOptions) bool {
+	return len(scanSecrets(s, opts)) > 0
+}
+
+// secretSpan returns the span of the first capturing group that participates in the match loc, or of the whole
+// match if there is none.
+func secretSpan(loc []int) (int, int) {
+	for i := 2; i+1 < len(loc); i += 2 {
+		if loc[i] >= 0 {
+			return loc[i], loc[i+1]
+		}
+	}
+	return loc[0], loc[1]
+}
+
+// isSecret validates a match of the rule id at s[start:end]. Matches of custom rules are always secrets.
+func isSecret(s string, start, end int, id string, opts SecretOptions) bool {
+	value := s[start:end]
+	switch id {
+	case SecretPasswordAssignment:
+		return !isSecretPlaceholder(value)
+	case SecretHighEntropyBase64:
+		return isTokenBoundary(s, start, end) && shannonEntropy(value) >= opts.Base64Entropy
+	case SecretHighEntropyHex:
+		return isTokenBoundary(s, start, end) && shannonEntropy(value) >= opts.HexEntropy
+	}
+	return true
+}
+
+// isSecretPlaceholder reports whether the value of a password assignment is a placeholder rather than a secret,
+// such as "null", "****" or a template variable like "${DB_PASSWORD}".
+func isSecretPlaceholder(value string) bool {
+	if secretPlaceholders[strings.ToLower(value)] || strings.ContainsAny(value[:1], "$<{%") {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(value)
+	return strings.Trim(value, string(first)) == "" && utf8.RuneCountInString(value) > 1
+}
+
+// secretPreview returns the first characters of value, at most four and at most a quarter of them, followed by
+// "****".
+func secretPreview(value string) string {
+	keep := min(4, utf8.RuneCountInString(value)/4)
+	runes := []rune(value)
+	return string(runes[:keep]) + "****"
+}
+
+// isGenericSecretRule reports whether id is one of the built-in high entropy rules, which match many kinds of
+// secrets and so give way to more specific rules.
+func isGenericSecretRule(id string) bool {
+	return id == SecretHighEntropyBase64 || id == SecretHighEntropyHex
+}
+
+// resolveSecretOverlaps keeps the specific, then longest, of overlapping findings and orders the result by
+// position.
+func resolveSecretOverlaps(candidates []SecretFinding) []SecretFinding {
+	return resolveOverlaps(candidates, func(f SecretFinding) (int, int) { return f.Start, f.End },
+		func(a, b SecretFinding) bool {
+			switch {
+			case isGenericSecretRule(a.RuleID) != isGenericSecretRule(b.RuleID):
+				return !isGenericSecretRule(a.RuleID)
+			case a.End-a.Start != b.End-b.Start:
+				return a.End-a.Start > b.End-b.Start
+			case a.Start != b.Start:
+				return a.Start < b.Start
+			}
+			return a.RuleID < b.RuleID
+		})
+}
@@ -18,15 +18,21 @@ func (s ShingleResultType) String() string {
 
 // ShinglesMap represents a result type where shingles are stored in a map.
 // ShinglesSlice represents a result type where shingles are stored in a slice.
+// ShinglesMinHash represents a result type where shingles are summarized as a MinHash signature.
+// ShinglesSimHash represents a result type where shingles are summarized as a 64-bit SimHash fingerprint.
 const (
 	ShinglesMap ShingleResultType = iota
 	ShinglesSlice
+	ShinglesMinHash
+	ShinglesSimHash
 )
 
 // ShingleResultTypeMap maps ShingleResultType constants to their corresponding descriptive string representations.
 var ShingleResultTypeMap = map[ShingleResultType]string{
-	ShinglesMap:   "Shingle Map",
-	ShinglesSlice: "Shingle Slice",
+	ShinglesMap:     "Shingle Map",
+	ShinglesSlice:   "Shingle Slice",
+	ShinglesMinHash: "MinHash Signature",
+	ShinglesSimHash: "SimHash Fingerprint",
 }
 
 // ShingleResult defines an interface for managing and retrieving shingle-related results and their metadata.
@@ -248,6 +254,18 @@ func CastShingleResult(raw *ShingleResult) ShingleResult {
 			return nil
 		}
 		return casted
+	case ShinglesMinHash:
+		casted, ok := (*raw).(*MinHashResult)
+		if !ok {
+			return nil
+		}
+		return casted
+	case ShinglesSimHash:
+		casted, ok := (*raw).(*SimHashResult)
+		if !ok {
+			return nil
+		}
+		return casted
 	default:
 		return nil
 	}
@@ -304,6 +322,10 @@ func formatShingleResultOutput(result ShingleResult, v bool) string {
 		} else {
 			payload = fmt.Sprintf("%d shingles found\n", len(shinglesSlice))
 		}
+	case *MinHashResult:
+		payload = formatMinHashPayload(s, v)
+	case *SimHashResult:
+		payload = fmt.Sprintf("Fingerprint: %016x\n", s.GetFingerprint())
 	}
 	formatted := header + payload
 	return formatted
@@ -12,26 +12,18 @@ func NewShingleResultsMap() ShingleResultsMap {
 
 // Add inserts a ShingleResult into the ShingleResultsMap, organizing it by type and n-gram length.
 func (srm ShingleResultsMap) Add(result ShingleResult) {
-	if srm[result.GetType()] == nil {
-		srm[result.GetType()] = make(map[int]*ShingleResult)
-	}
-	srm[result.GetType()][result.GetNgramLength()] = &result
+	srm.index().add(result.GetType(), result.GetNgramLength(), result)
+}
+
+// index returns the map as the resultIndex its methods delegate to.
+func (srm ShingleResultsMap) index() resultIndex[ShingleResultType, int, ShingleResult] {
+	return resultIndex[ShingleResultType, int, ShingleResult](srm)
 }
 
 // GetCopy creates and returns a deep copy of the ShingleResultsMap,
 // preserving its nested structure and data integrity.
 func (srm ShingleResultsMap) GetCopy() ShingleResultsMap {
-	cloned := NewShingleResultsMap()
-	for resType, v := range srm {
-		cloned[resType] = make(map[int]*ShingleResult)
-		for ngramLength, v2 := range v {
-			if v2 != nil {
-				resultCopy := *v2
-				cloned[resType][ngramLength] = &resultCopy
-			}
-		}
-	}
-	return cloned
+	return ShingleResultsMap(srm.index().clone())
 }
 
 // Get retrieves a ShingleResult from the ShingleResultsMap based on the specified type and n-gram length.
@@ -47,67 +39,31 @@ func (srm ShingleResultsMap) Get(resType ShingleResultType, ngramLength int) Shi
 
 // GetByType retrieves all ShingleResult instances of the specified ShingleResultType from the ShingleResultsMap.
 func (srm ShingleResultsMap) GetByType(resType ShingleResultType) []ShingleResult {
-	if srm[resType] == nil {
-		return nil
-	}
-	var results []ShingleResult
-	for _, v := range srm[resType] {
-		results = append(results, CastShingleResult(v))
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(srm.index().values(func(t ShingleResultType, _ int) bool { return t == resType }),
+		CastShingleResult)
 }
 
 // FilterByType filters the ShingleResultsMap by the specified ShingleResultType and
 // returns a new map with matching results.
 func (srm ShingleResultsMap) FilterByType(resType ShingleResultType) ShingleResultsMap {
-	if srm[resType] == nil {
-		return nil
-	}
-	results := NewShingleResultsMap()
-	for _, v := range srm[resType] {
-		results.Add(*v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return ShingleResultsMap(srm.index().filter(func(t ShingleResultType, _ int) bool { return t == resType }))
 }
 
 // GetByNGramLength retrieves all ShingleResult instances from the map that match the specified n-gram length.
 func (srm ShingleResultsMap) GetByNGramLength(ngramLength int) []ShingleResult {
-	if len(srm) == 0 || ngramLength < 1 {
-		return nil
-	}
-	var results []ShingleResult
-	for _, v := range srm {
-		if v[ngramLength] != nil {
-			results = append(results, CastShingleResult(v[ngramLength]))
-		}
-	}
-	if len(results) == 0 {
+	if ngramLength < 1 {
 		return nil
 	}
-	return results
+	return convertResults(srm.index().values(func(_ ShingleResultType, n int) bool { return n == ngramLength }),
+		CastShingleResult)
 }
 
 // FilterByNGramLength filters the map by a specified n-gram length and returns a new map containing the results.
 func (srm ShingleResultsMap) FilterByNGramLength(ngramLength int) ShingleResultsMap {
-	if len(srm) == 0 || ngramLength < 1 {
-		return nil
-	}
-	results := NewShingleResultsMap()
-	for _, v := range srm {
-		if v[ngramLength] != nil {
-			results.Add(*v[ngramLength])
-		}
-	}
-	if len(results) == 0 {
+	if ngramLength < 1 {
 		return nil
 	}
-	return results
+	return ShingleResultsMap(srm.index().filter(func(_ ShingleResultType, n int) bool { return n == ngramLength }))
 }
 
 // TypeCount returns the total number of ShingleResultType keys in the ShingleResultsMap.
@@ -117,38 +73,15 @@ func (srm ShingleResultsMap) TypeCount() int {
 
 // EntryCount returns the total number of non-nil entries across all nested maps within the ShingleResultsMap.
 func (srm ShingleResultsMap) EntryCount() int {
-	mapLength := 0
-	for _, v := range srm {
-		for _, v2 := range v {
-			if v2 != nil {
-				mapLength++
-			}
-		}
-	}
-	return mapLength
+	return srm.index().entryCount()
 }
 
 // IsMatch compares the current ShingleResultsMap to another and returns true
 // if they are structurally and value-wise identical.
 func (srm ShingleResultsMap) IsMatch(other ShingleResultsMap) bool {
-	if srm.TypeCount() != other.TypeCount() || srm.EntryCount() != other.EntryCount() {
-		return false
-	}
-	for resType, v := range srm {
-		if other[resType] == nil {
-			return false
-		}
-		for ngramLength, v2 := range v {
-			if other[resType][ngramLength] == nil {
-				return false
-			} else {
-				if !CastShingleResult(v2).IsMatch(CastShingleResult(other[resType][ngramLength])) {
-					return false
-				}
-			}
-		}
-	}
-	return true
+	return srm.index().isMatch(other.index(), func(a, b *ShingleResult) bool {
+		return CastShingleResult(a).IsMatch(CastShingleResult(b))
+	})
 }
 
 // Print outputs the ShingleResultsMap as a formatted string and optionally includes verbose details if 'v' is true.
@@ -0,0 +1,150 @@
+package strutil
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+
+	"github.com/hbollon/go-edlib"
+)
+
+// minHashSignatureTag and simHashSignatureTag are the first byte of a binary encoded signature.
+// signatureVersion is the second byte and changes whenever the hashing or the encoding changes.
+const (
+	minHashSignatureTag byte = 'M'
+	simHashSignatureTag byte = 'S'
+	signatureVersion    byte = 1
+)
+
+// simHashBits is the number of bits in a SimHash fingerprint.
+const simHashBits = 64
+
+// fnvOffset64 and fnvPrime64 are the parameters of the 64-bit FNV-1a hash.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// minHash computes a MinHash signature of numHashes components over the set of k-shingles of s.
+// A non-empty string shorter than k is treated as a single shingle.
+func minHash(s string, k, numHashes int) *MinHashResult {
+	if k < 1 {
+		return NewMinHashResult(ShinglesMinHash, s, k, nil, errors.ErrShingleLengthOutOfRange)
+	}
+	if numHashes < 1 {
+		return NewMinHashResult(ShinglesMinHash, s, k, nil, errors.ErrInvalidHashCount)
+	}
+	seeds := make([]uint64, numHashes)
+	signature := make([]uint64, numHashes)
+	var seed uint64
+	for i := range signature {
+		seed++
+		seeds[i] = mix64(seed)
+		signature[i] = math.MaxUint64
+	}
+	for shingle := range signatureShingles(s, k) {
+		h := shingleHash(shingle)
+		for i, seed := range seeds {
+			signature[i] = min(signature[i], mix64(h^seed))
+		}
+	}
+	return NewMinHashResult(ShinglesMinHash, s, k, signature, nil)
+}
+
+// simHash computes a 64-bit SimHash fingerprint of the k-shingles of s, weighting each shingle by its count.
+// A non-empty string shorter than k is treated as a single shingle.
+func simHash(s string, k int) *SimHashResult {
+	if k < 1 {
+		return NewSimHashResult(ShinglesSimHash, s, k, 0, errors.ErrShingleLengthOutOfRange)
+	}
+	var weights [simHashBits]int
+	for shingle, count := range signatureShingles(s, k) {
+		h := shingleHash(shingle)
+		for b := range weights {
+			if h&(1<<b) != 0 {
+				weights[b] += count
+			} else {
+				weights[b] -= count
+			}
+		}
+	}
+	var fingerprint uint64
+	for b, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << b
+		}
+	}
+	return NewSimHashResult(ShinglesSimHash, s, k, fingerprint, nil)
+}
+
+// signatureShingles returns the k-shingles of s with their counts, or s itself if it is shorter than k.
+func signatureShingles(s string, k int) map[string]int {
+	shingles := edlib.Shingle(s, k)
+	if len(shingles) == 0 && s != "" {
+		shingles[s] = 1
+	}
+	return shingles
+}
+
+// shingleHash returns the mixed 64-bit FNV-1a hash of a shingle. It is stable across processes,
+// so signatures can be stored and compared later.
+func shingleHash(shingle string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(shingle); i++ {
+		h ^= uint64(shingle[i])
+		h *= fnvPrime64
+	}
+	return mix64(h)
+}
+
+// mix64 is the SplitMix64 finalizer, which spreads every input bit over the whole output.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// simHashDistance returns the number of differing bits between two fingerprints.
+func simHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// encodeSignature writes the tag, version, shingle length, component count and big-endian components.
+func encodeSignature(tag byte, ngram int, signature []uint64) []byte {
+	out := []byte{tag, signatureVersion}
+	out = binary.AppendVarint(out, int64(ngram))
+	out = binary.AppendVarint(out, int64(len(signature)))
+	for _, v := range signature {
+		out = binary.BigEndian.AppendUint64(out, v)
+	}
+	return out
+}
+
+// decodeSignature reads data written by encodeSignature with the given tag.
+func decodeSignature(tag byte, data []byte) (int, []uint64, error) {
+	if len(data) < 2 || data[0] != tag || data[1] != signatureVersion {
+		return 0, nil, errors.ErrInvalidSignature
+	}
+	data = data[2:]
+	ngram, n := binary.Varint(data)
+	if n <= 0 || ngram < 1 || ngram > math.MaxInt32 {
+		return 0, nil, errors.ErrInvalidSignature
+	}
+	data = data[n:]
+	count, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, nil, errors.ErrInvalidSignature
+	}
+	data = data[n:]
+	if count < 1 || len(data)%8 != 0 || count != int64(len(data)/8) {
+		return 0, nil, errors.ErrInvalidSignature
+	}
+	signature := make([]uint64, count)
+	for i := range signature {
+		signature[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+	return int(ngram), signature, nil
+}
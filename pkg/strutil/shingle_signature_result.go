@@ -0,0 +1,324 @@
+package strutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// MinHashResult holds a MinHash signature of the k-shingles of a string. Each component is the minimum of one
+// hash function over the shingle set, so the fraction of equal components of two signatures estimates the
+// Jaccard similarity of the shingle sets.
+type MinHashResult struct {
+	resultType ShingleResultType
+	input      string
+	ngram      int
+	signature  []uint64
+	err        error
+}
+
+// NewMinHashResult creates and returns a new instance of MinHashResult with the provided parameters.
+func NewMinHashResult(resultType ShingleResultType,
+	input string,
+	ngram int,
+	signature []uint64,
+	err error) *MinHashResult {
+	return &MinHashResult{
+		resultType: resultType,
+		input:      input,
+		ngram:      ngram,
+		signature:  signature,
+		err:        err,
+	}
+}
+
+// GetType returns the ShingleResultType associated with the MinHashResult.
+func (m *MinHashResult) GetType() ShingleResultType {
+	return m.resultType
+}
+
+// GetTypeName returns the string representation of the result type associated with the MinHashResult.
+func (m *MinHashResult) GetTypeName() string {
+	return m.resultType.String()
+}
+
+// GetInput returns the input string the signature was computed from.
+// Signatures restored with UnmarshalBinary or UnmarshalJSON have an empty input.
+func (m *MinHashResult) GetInput() string {
+	return m.input
+}
+
+// GetNgramLength returns the shingle length the signature was computed with.
+func (m *MinHashResult) GetNgramLength() int {
+	return m.ngram
+}
+
+// GetSignature returns a copy of the signature components.
+func (m *MinHashResult) GetSignature() []uint64 {
+	return slices.Clone(m.signature)
+}
+
+// GetNumHashes returns the number of hash functions, which is the length of the signature.
+func (m *MinHashResult) GetNumHashes() int {
+	return len(m.signature)
+}
+
+// GetError returns the error associated with the MinHashResult, if any.
+func (m *MinHashResult) GetError() error {
+	return m.err
+}
+
+// Jaccard estimates the Jaccard similarity of the shingle sets of m and other as the fraction of equal
+// signature components. Returns ErrSignatureMismatch if the signatures differ in shingle or signature length,
+// or the error of either result.
+func (m *MinHashResult) Jaccard(other *MinHashResult) (float64, error) {
+	if m == nil || other == nil {
+		return 0, errors.ErrSignatureMismatch
+	}
+	if err := compareSignatureParameters(m, other); err != nil {
+		return 0, err
+	}
+	if len(m.signature) != len(other.signature) {
+		return 0, errors.ErrSignatureMismatch
+	}
+	equal := 0
+	for i, v := range m.signature {
+		if v == other.signature[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(m.signature)), nil
+}
+
+// IsMatch compares the current MinHashResult with another ShingleResult for equality of their fields and
+// signatures.
+func (m *MinHashResult) IsMatch(other ShingleResult) bool {
+	casted, ok := other.(*MinHashResult)
+	if !ok {
+		return false
+	}
+	return compareShingleInputFields(m, casted) &&
+		slices.Equal(m.signature, casted.signature) &&
+		errors.CompareErrors(m.err, casted.err)
+}
+
+// Print outputs the signature or error information based on the verbose flag.
+func (m *MinHashResult) Print(v bool) {
+	fmt.Print(formatShingleResultOutput(m, v))
+}
+
+// MarshalBinary encodes the shingle length and signature in a compact binary form. The input is not included.
+func (m *MinHashResult) MarshalBinary() ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return encodeSignature(minHashSignatureTag, m.ngram, m.signature), nil
+}
+
+// UnmarshalBinary restores a signature encoded with MarshalBinary.
+// Returns ErrInvalidSignature if the data is malformed.
+func (m *MinHashResult) UnmarshalBinary(data []byte) error {
+	ngram, signature, err := decodeSignature(minHashSignatureTag, data)
+	if err != nil {
+		return err
+	}
+	*m = *NewMinHashResult(ShinglesMinHash, "", ngram, signature, nil)
+	return nil
+}
+
+// MarshalJSON encodes the shingle length and signature as a JSON object. The input is not included.
+func (m *MinHashResult) MarshalJSON() ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return json.Marshal(signatureJSON{Type: m.GetTypeName(), Ngram: m.ngram, Signature: m.signature})
+}
+
+// UnmarshalJSON restores a signature encoded with MarshalJSON.
+// Returns ErrInvalidSignature if the object describes a different kind of signature.
+func (m *MinHashResult) UnmarshalJSON(data []byte) error {
+	var raw signatureJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Type != ShinglesMinHash.String() || raw.Ngram < 1 || len(raw.Signature) == 0 {
+		return errors.ErrInvalidSignature
+	}
+	*m = *NewMinHashResult(ShinglesMinHash, "", raw.Ngram, raw.Signature, nil)
+	return nil
+}
+
+// SimHashResult holds a 64-bit SimHash fingerprint of the k-shingles of a string. Strings with similar shingles
+// have fingerprints that differ in few bits, so the Hamming distance between fingerprints measures similarity.
+type SimHashResult struct {
+	resultType  ShingleResultType
+	input       string
+	ngram       int
+	fingerprint uint64
+	err         error
+}
+
+// NewSimHashResult creates and returns a new instance of SimHashResult with the provided parameters.
+func NewSimHashResult(resultType ShingleResultType,
+	input string,
+	ngram int,
+	fingerprint uint64,
+	err error) *SimHashResult {
+	return &SimHashResult{
+		resultType:  resultType,
+		input:       input,
+		ngram:       ngram,
+		fingerprint: fingerprint,
+		err:         err,
+	}
+}
+
+// GetType returns the ShingleResultType associated with the SimHashResult.
+func (s *SimHashResult) GetType() ShingleResultType {
+	return s.resultType
+}
+
+// GetTypeName returns the string representation of the result type associated with the SimHashResult.
+func (s *SimHashResult) GetTypeName() string {
+	return s.resultType.String()
+}
+
+// GetInput returns the input string the fingerprint was computed from.
+// Fingerprints restored with UnmarshalBinary or UnmarshalJSON have an empty input.
+func (s *SimHashResult) GetInput() string {
+	return s.input
+}
+
+// GetNgramLength returns the shingle length the fingerprint was computed with.
+func (s *SimHashResult) GetNgramLength() int {
+	return s.ngram
+}
+
+// GetFingerprint returns the 64-bit fingerprint.
+func (s *SimHashResult) GetFingerprint() uint64 {
+	return s.fingerprint
+}
+
+// GetError returns the error associated with the SimHashResult, if any.
+func (s *SimHashResult) GetError() error {
+	return s.err
+}
+
+// HammingDistance returns the number of bits in which the fingerprints of s and other differ.
+// Returns ErrSignatureMismatch if the fingerprints were computed with different shingle lengths,
+// or the error of either result.
+func (s *SimHashResult) HammingDistance(other *SimHashResult) (int, error) {
+	if s == nil || other == nil {
+		return 0, errors.ErrSignatureMismatch
+	}
+	if err := compareSignatureParameters(s, other); err != nil {
+		return 0, err
+	}
+	return simHashDistance(s.fingerprint, other.fingerprint), nil
+}
+
+// Similarity returns 1 minus the Hamming distance between the fingerprints divided by 64.
+func (s *SimHashResult) Similarity(other *SimHashResult) (float64, error) {
+	distance, err := s.HammingDistance(other)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - float64(distance)/simHashBits, nil
+}
+
+// IsMatch compares the current SimHashResult with another ShingleResult for equality of their fields and
+// fingerprints.
+func (s *SimHashResult) IsMatch(other ShingleResult) bool {
+	casted, ok := other.(*SimHashResult)
+	if !ok {
+		return false
+	}
+	return compareShingleInputFields(s, casted) &&
+		s.fingerprint == casted.fingerprint &&
+		errors.CompareErrors(s.err, casted.err)
+}
+
+// Print outputs the fingerprint or error information based on the verbose flag.
+func (s *SimHashResult) Print(v bool) {
+	fmt.Print(formatShingleResultOutput(s, v))
+}
+
+// MarshalBinary encodes the shingle length and fingerprint in a compact binary form. The input is not included.
+func (s *SimHashResult) MarshalBinary() ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return encodeSignature(simHashSignatureTag, s.ngram, []uint64{s.fingerprint}), nil
+}
+
+// UnmarshalBinary restores a fingerprint encoded with MarshalBinary.
+// Returns ErrInvalidSignature if the data is malformed.
+func (s *SimHashResult) UnmarshalBinary(data []byte) error {
+	ngram, signature, err := decodeSignature(simHashSignatureTag, data)
+	if err != nil {
+		return err
+	}
+	if len(signature) != 1 {
+		return errors.ErrInvalidSignature
+	}
+	*s = *NewSimHashResult(ShinglesSimHash, "", ngram, signature[0], nil)
+	return nil
+}
+
+// MarshalJSON encodes the shingle length and fingerprint as a JSON object. The input is not included.
+func (s *SimHashResult) MarshalJSON() ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return json.Marshal(signatureJSON{Type: s.GetTypeName(), Ngram: s.ngram, Signature: []uint64{s.fingerprint}})
+}
+
+// UnmarshalJSON restores a fingerprint encoded with MarshalJSON.
+// Returns ErrInvalidSignature if the object describes a different kind of signature.
+func (s *SimHashResult) UnmarshalJSON(data []byte) error {
+	var raw signatureJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Type != ShinglesSimHash.String() || raw.Ngram < 1 || len(raw.Signature) != 1 {
+		return errors.ErrInvalidSignature
+	}
+	*s = *NewSimHashResult(ShinglesSimHash, "", raw.Ngram, raw.Signature[0], nil)
+	return nil
+}
+
+// signatureJSON is the serialized form of a MinHashResult or SimHashResult.
+type signatureJSON struct {
+	Type      string   `json:"type"`
+	Ngram     int      `json:"ngram"`
+	Signature []uint64 `json:"signature"`
+}
+
+// compareSignatureParameters returns the error of either result, or ErrSignatureMismatch if they were computed
+// with different shingle lengths.
+func compareSignatureParameters(s1, s2 ShingleResult) error {
+	if s1.GetError() != nil {
+		return s1.GetError()
+	}
+	if s2.GetError() != nil {
+		return s2.GetError()
+	}
+	if s1.GetNgramLength() != s2.GetNgramLength() {
+		return errors.ErrSignatureMismatch
+	}
+	return nil
+}
+
+// formatMinHashPayload formats the signature of a MinHashResult, listing every component in verbose mode.
+func formatMinHashPayload(m *MinHashResult, v bool) string {
+	if !v {
+		return fmt.Sprintf("%d hashes\n", len(m.signature))
+	}
+	payload := "Signature:\n"
+	for _, h := range m.signature {
+		payload += fmt.Sprintf("%016x\n", h)
+	}
+	return payload
+}
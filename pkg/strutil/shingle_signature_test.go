@@ -0,0 +1,262 @@
+package strutil
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// exactJaccard returns the Jaccard similarity of the k-shingle sets of s1 and s2.
+func exactJaccard(s1, s2 string, k int) float64 {
+	a, b := signatureShingles(s1, k), signatureShingles(s2, k)
+	intersection := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+const (
+	signatureArticle   = "the quick brown fox jumps over the lazy dog while the farmer sleeps in the afternoon sun"
+	signatureDuplicate = "the quick brown fox jumped over the lazy dog while the farmer slept in the afternoon sun"
+	signatureUnrelated = "stock markets rallied on friday as investors cheered unexpectedly strong earnings reports"
+)
+
+func TestMinHash(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		k         int
+		numHashes int
+		signature []uint64
+		err       error
+	}{
+		{"MinHashGolden", "hello world", 3, 2, []uint64{0x63f18143d8e97c4, 0x38e12e90a62dd382}, nil},
+		{"MinHashInvalidK", "hello world", 0, 2, nil, errors2.ErrShingleLengthOutOfRange},
+		{"MinHashInvalidHashes", "hello world", 3, 0, nil, errors2.ErrInvalidHashCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := minHash(tt.input, tt.k, tt.numHashes)
+			result := MinHash(tt.input, tt.k, tt.numHashes)
+			builder := New(tt.input).MinHash(tt.k, tt.numHashes)
+			builderResult := builder.GetComparisonManager().GetShingleResult(ShinglesMinHash, tt.k)
+			if !helperResult.IsMatch(result) || !result.IsMatch(builderResult) {
+				t.Fatalf("MinHash(%q, %d, %d) - helper, functional and builder results differ",
+					tt.input, tt.k, tt.numHashes)
+			}
+			if !reflect.DeepEqual(result.GetSignature(), tt.signature) || !errors.Is(result.GetError(), tt.err) {
+				t.Errorf("MinHash(%q, %d, %d) = %#x, %v; want %#x, %v", tt.input, tt.k, tt.numHashes,
+					result.GetSignature(), result.GetError(), tt.signature, tt.err)
+			}
+			if !errors.Is(builder.Error(), tt.err) {
+				t.Errorf("MinHash(%q, %d, %d) builder error = %v; want %v",
+					tt.input, tt.k, tt.numHashes, builder.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestMinHashJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		s1   string
+		s2   string
+	}{
+		{"JaccardDuplicate", signatureArticle, signatureDuplicate},
+		{"JaccardUnrelated", signatureArticle, signatureUnrelated},
+		{"JaccardIdentical", signatureArticle, signatureArticle},
+		{"JaccardShort", "ab", "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, err := MinHash(tt.s1, 3, 512).Jaccard(MinHash(tt.s2, 3, 512))
+			exact := exactJaccard(tt.s1, tt.s2, 3)
+			if err != nil || math.Abs(estimate-exact) > 0.1 {
+				t.Errorf("Jaccard(%q, %q) = %f, %v; want about %f", tt.s1, tt.s2, estimate, err, exact)
+			}
+		})
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	if _, err := MinHash("abc", 3, 8).Jaccard(MinHash("abc", 3, 16)); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("Jaccard with different hash counts error = %v", err)
+	}
+	if _, err := MinHash("abc", 3, 8).Jaccard(MinHash("abc", 2, 8)); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("Jaccard with different shingle lengths error = %v", err)
+	}
+	if _, err := MinHash("abc", 3, 8).Jaccard(nil); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("Jaccard with nil error = %v", err)
+	}
+	if _, err := MinHash("abc", 3, 0).Jaccard(MinHash("abc", 3, 8)); !errors.Is(err, errors2.ErrInvalidHashCount) {
+		t.Errorf("Jaccard with error result error = %v", err)
+	}
+	if _, err := SimHash("abc", 3).HammingDistance(SimHash("abc", 2)); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("HammingDistance with different shingle lengths error = %v", err)
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	result := SimHash("hello world", 3)
+	builder := New("hello world").SimHash(3)
+	if !simHash("hello world", 3).IsMatch(result) ||
+		!result.IsMatch(builder.GetComparisonManager().GetShingleResult(ShinglesSimHash, 3)) {
+		t.Fatal("SimHash - helper, functional and builder results differ")
+	}
+	if result.GetFingerprint() != 0x27a7693796ec6865 {
+		t.Errorf("SimHash(%q, 3) = %#x; want %#x", "hello world", result.GetFingerprint(), 0x27a7693796ec6865)
+	}
+	if err := New("hello").SimHash(0).Error(); !errors.Is(err, errors2.ErrShingleLengthOutOfRange) {
+		t.Errorf("SimHash(0) builder error = %v", err)
+	}
+
+	article := SimHash(signatureArticle, 3)
+	near, err := article.HammingDistance(SimHash(signatureDuplicate, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	far, err := article.HammingDistance(SimHash(signatureUnrelated, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if near >= far || near > 16 {
+		t.Errorf("HammingDistance duplicate = %d, unrelated = %d; want the duplicate much closer", near, far)
+	}
+	if similarity, err := article.Similarity(article); err != nil || similarity != 1 {
+		t.Errorf("Similarity with itself = %f, %v; want 1", similarity, err)
+	}
+}
+
+func TestSignatureSerialization(t *testing.T) {
+	mh := MinHash(signatureArticle, 3, 32)
+	sh := SimHash(signatureArticle, 3)
+
+	data, err := mh.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mhBinary MinHashResult
+	err = mhBinary.UnmarshalBinary(data)
+	if err != nil || !reflect.DeepEqual(mhBinary.GetSignature(), mh.GetSignature()) || mhBinary.GetNgramLength() != 3 {
+		t.Errorf("MinHash binary round trip = %v, %v", mhBinary.GetSignature(), err)
+	}
+	data, err = json.Marshal(mh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mhJSON MinHashResult
+	if err := json.Unmarshal(data, &mhJSON); err != nil || !mhJSON.IsMatch(&mhBinary) {
+		t.Errorf("MinHash JSON round trip of %s = %v, %v", data, mhJSON.GetSignature(), err)
+	}
+
+	data, err = sh.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shBinary SimHashResult
+	if err := shBinary.UnmarshalBinary(data); err != nil || shBinary.GetFingerprint() != sh.GetFingerprint() {
+		t.Errorf("SimHash binary round trip = %#x, %v", shBinary.GetFingerprint(), err)
+	}
+	data, err = json.Marshal(sh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shJSON SimHashResult
+	if err := json.Unmarshal(data, &shJSON); err != nil || !shJSON.IsMatch(&shBinary) {
+		t.Errorf("SimHash JSON round trip of %s = %#x, %v", data, shJSON.GetFingerprint(), err)
+	}
+
+	if _, err := MinHash("abc", 3, 0).MarshalBinary(); !errors.Is(err, errors2.ErrInvalidHashCount) {
+		t.Errorf("MarshalBinary of an error result error = %v", err)
+	}
+}
+
+func TestSignatureUnmarshalInvalid(t *testing.T) {
+	valid, err := MinHash("abc", 3, 2).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"InvalidEmpty", nil},
+		{"InvalidTag", append([]byte{'S'}, valid[1:]...)},
+		{"InvalidVersion", append([]byte{'M', 9}, valid[2:]...)},
+		{"InvalidTruncated", valid[:len(valid)-1]},
+		{"InvalidZeroNgram", []byte{'M', 1, 0, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mh MinHashResult
+			if err := mh.UnmarshalBinary(tt.data); !errors.Is(err, errors2.ErrInvalidSignature) {
+				t.Errorf("UnmarshalBinary(%x) error = %v; want %v", tt.data, err, errors2.ErrInvalidSignature)
+			}
+		})
+	}
+	var sh SimHashResult
+	if err := json.Unmarshal([]byte(`{"type":"MinHash Signature","ngram":3,"signature":[1]}`), &sh); !errors.Is(
+		err, errors2.ErrInvalidSignature) {
+		t.Errorf("UnmarshalJSON of a MinHash into a SimHashResult error = %v", err)
+	}
+}
+
+func TestLSHIndex(t *testing.T) {
+	if _, err := NewLSHIndex(0, 4); !errors.Is(err, errors2.ErrInvalidLSHBands) {
+		t.Fatalf("NewLSHIndex(0, 4) error = %v", err)
+	}
+	index, err := NewLSHIndex(32, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.Bands() != 32 || index.Rows() != 4 || math.Abs(index.Threshold()-math.Pow(1.0/32, 0.25)) > 1e-12 {
+		t.Errorf("NewLSHIndex(32, 4) = %d bands, %d rows, threshold %f", index.Bands(), index.Rows(), index.Threshold())
+	}
+	for id, doc := range map[string]string{"article": signatureArticle, "unrelated": signatureUnrelated} {
+		if err := index.Add(id, MinHash(doc, 3, 128)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := index.Add("article", MinHash(signatureArticle, 3, 128)); err != nil || index.Len() != 2 {
+		t.Fatalf("re-adding an id = %v, Len() = %d; want 2", err, index.Len())
+	}
+	candidates, err := index.Query(MinHash(signatureDuplicate, 3, 128))
+	if err != nil || !reflect.DeepEqual(candidates, []string{"article"}) {
+		t.Errorf("Query(duplicate) = %v, %v; want [article]", candidates, err)
+	}
+	if _, err := index.Query(MinHash(signatureDuplicate, 3, 64)); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("Query with the wrong hash count error = %v", err)
+	}
+	if err := index.Add("other", MinHash(signatureDuplicate, 4, 128)); !errors.Is(err, errors2.ErrSignatureMismatch) {
+		t.Errorf("Add with a different shingle length error = %v", err)
+	}
+	if !index.Remove("article") || index.Remove("article") || index.Len() != 1 {
+		t.Errorf("Remove(article) did not remove exactly one signature")
+	}
+	if candidates, err := index.Query(MinHash(signatureDuplicate, 3, 128)); err != nil || len(candidates) != 0 {
+		t.Errorf("Query(duplicate) after Remove = %v, %v; want none", candidates, err)
+	}
+}
+
+func TestSignatureResultPrint(t *testing.T) {
+	mh := MinHash("hello world", 3, 2)
+	if output := formatShingleResultOutput(mh, false); output != "MinHash Signature (hello world/3):\n2 hashes\n" {
+		t.Errorf("formatShingleResultOutput(MinHash, false) = %q", output)
+	}
+	if output := formatShingleResultOutput(mh, true); !strings.HasSuffix(output,
+		"Signature:\n063f18143d8e97c4\n38e12e90a62dd382\n") {
+		t.Errorf("formatShingleResultOutput(MinHash, true) = %q", output)
+	}
+	if output := formatShingleResultOutput(SimHash("hello world", 3), false); !strings.HasSuffix(output,
+		"Fingerprint: 27a7693796ec6865\n") {
+		t.Errorf("formatShingleResultOutput(SimHash, false) = %q", output)
+	}
+}
@@ -0,0 +1,251 @@
+package strutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/bmj2728/utils/pkg/internal/comparison"
+	"github.com/bmj2728/utils/pkg/internal/errors"
+	"github.com/bmj2728/utils/pkg/internal/types"
+)
+
+// SimilarityMatrixResult holds the N×N similarity scores computed pairwise over a set of strings.
+// scores[i][j] is the similarity between items[i] and items[j]; the matrix is symmetric and the
+// diagonal is always 1. Pairs whose comparison fails (e.g. Hamming on unequal lengths) score 0.
+type SimilarityMatrixResult struct {
+	algorithm Algorithm
+	items     []string
+	scores    [][]float32
+	err       error
+}
+
+// NewSimilarityMatrixResult initializes and returns a new SimilarityMatrixResult with the provided parameters.
+func NewSimilarityMatrixResult(algorithm Algorithm,
+	items []string,
+	scores [][]float32,
+	err error) *SimilarityMatrixResult {
+	return &SimilarityMatrixResult{
+		algorithm: algorithm,
+		items:     items,
+		scores:    scores,
+		err:       err,
+	}
+}
+
+// GetAlgorithm returns the algorithm used to compute the matrix.
+// A nil matrix returns the zero Algorithm, Levenshtein; use GetError to tell it apart.
+func (m *SimilarityMatrixResult) GetAlgorithm() Algorithm {
+	if m == nil {
+		return 0
+	}
+	return m.algorithm
+}
+
+// GetAlgorithmName returns the string representation of the algorithm used to compute the matrix,
+// or an empty string if the matrix is nil.
+func (m *SimilarityMatrixResult) GetAlgorithmName() string {
+	if m == nil {
+		return ""
+	}
+	return m.algorithm.String()
+}
+
+// GetItems returns the strings the matrix was computed over, in row order, or nil if the matrix is nil.
+func (m *SimilarityMatrixResult) GetItems() []string {
+	if m == nil {
+		return nil
+	}
+	return m.items
+}
+
+// Len returns the number of items (rows and columns) in the matrix.
+func (m *SimilarityMatrixResult) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// GetError returns the error encountered while computing the matrix, if any.
+// Returns ErrNilSimilarityMatrix if the matrix is nil.
+func (m *SimilarityMatrixResult) GetError() error {
+	if m == nil {
+		return errors.ErrNilSimilarityMatrix
+	}
+	return m.err
+}
+
+// Get returns the similarity score between items i and j.
+// Returns an error if the matrix is nil, failed to compute or either index is out of range.
+func (m *SimilarityMatrixResult) Get(i, j int) (float32, error) {
+	if m == nil {
+		return 0, errors.ErrNilSimilarityMatrix
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	if i < 0 || j < 0 || i >= m.Len() || j >= m.Len() {
+		return 0, errors.ErrMatrixIndexOutOfRange
+	}
+	return m.scores[i][j], nil
+}
+
+// GetRow returns a copy of the scores for item i against every item, or nil if i is out of range or the matrix
+// is nil.
+func (m *SimilarityMatrixResult) GetRow(i int) []float32 {
+	if m == nil || m.err != nil || i < 0 || i >= m.Len() {
+		return nil
+	}
+	row := make([]float32, len(m.scores[i]))
+	copy(row, m.scores[i])
+	return row
+}
+
+// TopK returns up to k SimilarityResults for the items most similar to item i, excluding i itself,
+// ordered by descending score. Ties are broken by item order.
+// Returns nil if i is out of range, k is less than 1, the matrix is nil or it failed to compute.
+func (m *SimilarityMatrixResult) TopK(i, k int) []SimilarityResult {
+	if m == nil || m.err != nil || k < 1 || i < 0 || i >= m.Len() {
+		return nil
+	}
+	indexes := make([]int, 0, m.Len()-1)
+	for j := range m.items {
+		if j != i {
+			indexes = append(indexes, j)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return m.scores[i][indexes[a]] > m.scores[i][indexes[b]]
+	})
+	if k > len(indexes) {
+		k = len(indexes)
+	}
+	results := make([]SimilarityResult, 0, k)
+	for _, j := range indexes[:k] {
+		score := m.scores[i][j]
+		results = append(results, *NewSimilarityResult(m.algorithm, m.items[i], m.items[j], &score, nil))
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// ToCSV renders the matrix as CSV with a header row and a leading column of item labels.
+// Returns an error if the matrix is nil or failed to compute.
+func (m *SimilarityMatrixResult) ToCSV() (string, error) {
+	if m == nil {
+		return "", errors.ErrNilSimilarityMatrix
+	}
+	if m.err != nil {
+		return "", m.err
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := append([]string{""}, m.items...)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for i, item := range m.items {
+		record := make([]string, 0, m.Len()+1)
+		record = append(record, item)
+		for _, score := range m.scores[i] {
+			record = append(record, strconv.FormatFloat(float64(score), 'f', -1, 32))
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// similarityMatrixJSON is the serialized form of a SimilarityMatrixResult.
+type similarityMatrixJSON struct {
+	Algorithm string      `json:"algorithm"`
+	Items     []string    `json:"items"`
+	Scores    [][]float32 `json:"scores"`
+}
+
+// ToJSON renders the matrix as a JSON object containing the algorithm name, the items and the score rows.
+// Returns an error if the matrix is nil or failed to compute.
+func (m *SimilarityMatrixResult) ToJSON() (string, error) {
+	if m == nil {
+		return "", errors.ErrNilSimilarityMatrix
+	}
+	if m.err != nil {
+		return "", m.err
+	}
+	out, err := json.Marshal(similarityMatrixJSON{
+		Algorithm: m.GetAlgorithmName(),
+		Items:     m.items,
+		Scores:    m.scores,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// IsMatch compares the current SimilarityMatrixResult with another based on algorithm, items, scores and error.
+func (m *SimilarityMatrixResult) IsMatch(other *SimilarityMatrixResult) bool {
+	if m == nil || other == nil {
+		return false
+	}
+	if m.algorithm != other.algorithm ||
+		m.Len() != other.Len() ||
+		!errors.CompareErrors(m.err, other.err) ||
+		!comparison.CompareStringSlices(m.items, other.items, true) {
+		return false
+	}
+	if len(m.scores) != len(other.scores) {
+		return false
+	}
+	for i := range m.scores {
+		if len(m.scores[i]) != len(other.scores[i]) {
+			return false
+		}
+		for j := range m.scores[i] {
+			if math.Abs(float64(m.scores[i][j]-other.scores[i][j])) > types.Float64EqualityThreshold {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Print outputs the matrix, or its error, based on the verbosity flag.
+func (m *SimilarityMatrixResult) Print(v bool) {
+	fmt.Print(formatSimilarityMatrixOutput(m, v))
+}
+
+// formatSimilarityMatrixOutput formats a SimilarityMatrixResult for display.
+// Verbose output includes every score row; concise output only reports the matrix size.
+func formatSimilarityMatrixOutput(m *SimilarityMatrixResult, v bool) string {
+	if m == nil {
+		return ""
+	}
+	if m.err != nil {
+		return fmt.Sprintf("%s Similarity Matrix Error: %s\n", m.GetAlgorithmName(), m.err.Error())
+	}
+	if !v {
+		return fmt.Sprintf("%s Similarity Matrix: %d×%d\n", m.GetAlgorithmName(), m.Len(), m.Len())
+	}
+	output := fmt.Sprintf("%s Similarity Matrix:\n", m.GetAlgorithmName())
+	for i, item := range m.items {
+		output += fmt.Sprintf("%q:", item)
+		for _, score := range m.scores[i] {
+			output += fmt.Sprintf(" %.4f", score)
+		}
+		output += "\n"
+	}
+	return output
+}
@@ -0,0 +1,69 @@
+package strutil
+
+import "fmt"
+
+// SimilarityMatrixMap is keyed by algorithm and holds the most recently added matrix for each algorithm.
+type SimilarityMatrixMap map[Algorithm]*SimilarityMatrixResult
+
+// NewSimilarityMatrixMap initializes and returns a new, empty SimilarityMatrixMap.
+func NewSimilarityMatrixMap() SimilarityMatrixMap {
+	return make(map[Algorithm]*SimilarityMatrixResult)
+}
+
+// Add inserts or replaces the matrix stored for the matrix's algorithm.
+func (smm SimilarityMatrixMap) Add(result SimilarityMatrixResult) {
+	smm[result.GetAlgorithm()] = &result
+}
+
+// Get retrieves the matrix for the given algorithm, or nil if none exists.
+func (smm SimilarityMatrixMap) Get(algo Algorithm) *SimilarityMatrixResult {
+	return smm[algo]
+}
+
+// GetCopy creates and returns a copy of the SimilarityMatrixMap with cloned item and score slices.
+func (smm SimilarityMatrixMap) GetCopy() SimilarityMatrixMap {
+	cloned := NewSimilarityMatrixMap()
+	for algo, m := range smm {
+		if m == nil {
+			continue
+		}
+		items := make([]string, len(m.items))
+		copy(items, m.items)
+		var scores [][]float32
+		if m.scores != nil {
+			scores = make([][]float32, len(m.scores))
+			for i, row := range m.scores {
+				scores[i] = make([]float32, len(row))
+				copy(scores[i], row)
+			}
+		}
+		cloned[algo] = NewSimilarityMatrixResult(m.algorithm, items, scores, m.err)
+	}
+	return cloned
+}
+
+// TypeCount returns the number of algorithms with a stored matrix.
+func (smm SimilarityMatrixMap) TypeCount() int {
+	return len(smm)
+}
+
+// IsMatch compares two SimilarityMatrixMap objects for equality of every stored matrix.
+func (smm SimilarityMatrixMap) IsMatch(other SimilarityMatrixMap) bool {
+	if smm.TypeCount() != other.TypeCount() {
+		return false
+	}
+	for algo, m := range smm {
+		if !m.IsMatch(other[algo]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Print outputs every stored matrix, optionally in verbose mode.
+func (smm SimilarityMatrixMap) Print(v bool) SimilarityMatrixMap {
+	for _, m := range smm {
+		fmt.Print(formatSimilarityMatrixOutput(m, v) + "\n")
+	}
+	return smm
+}
@@ -0,0 +1,227 @@
+package strutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+	"github.com/bmj2728/utils/pkg/internal/types"
+)
+
+var matrixItems = []string{"kitten", "sitting", "mitten", "kitchen", "smitten"}
+
+func TestSimilarityMatrix(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []string
+		algorithm Algorithm
+		err       error
+	}{
+		{"SimilarityMatrixLevenshtein", matrixItems, Levenshtein, nil},
+		{"SimilarityMatrixJaroWinkler", matrixItems, JaroWinkler, nil},
+		{"SimilarityMatrixHamming", matrixItems, Hamming, nil},
+		{"SimilarityMatrixSingle", []string{"alone"}, Jaro, nil},
+		{"SimilarityMatrixEmpty", nil, Levenshtein, nil},
+		{"SimilarityMatrixInvalid", matrixItems, Algorithm(99), errors2.ErrInvalidAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := similarityMatrix(tt.items, tt.algorithm)
+			result := SimilarityMatrix(tt.items, tt.algorithm)
+			if !errors.Is(helperResult.GetError(), tt.err) || !errors.Is(result.GetError(), tt.err) {
+				t.Fatalf("SimilarityMatrix - expected error %v, got %v / %v", tt.err, helperResult.GetError(), result.GetError())
+			}
+			if !helperResult.IsMatch(result) {
+				t.Errorf("SimilarityMatrix - helper and functional results differ")
+			}
+			if tt.err != nil {
+				return
+			}
+			if result.Len() != len(tt.items) {
+				t.Errorf("SimilarityMatrix - expected %d items, got %d", len(tt.items), result.Len())
+			}
+			for i := range tt.items {
+				for j := range tt.items {
+					score, err := result.Get(i, j)
+					if err != nil {
+						t.Fatalf("SimilarityMatrix - Get(%d, %d) error %v", i, j, err)
+					}
+					mirror, _ := result.Get(j, i)
+					if score != mirror {
+						t.Errorf("SimilarityMatrix - asymmetric at (%d, %d): %f / %f", i, j, score, mirror)
+					}
+					if i == j && score != 1 {
+						t.Errorf("SimilarityMatrix - diagonal (%d) not 1: %f", i, score)
+					}
+					if i != j {
+						expected, _ := similarity(tt.items[i], tt.items[j], tt.algorithm).GetScore()
+						if math.Abs(float64(score-expected)) > types.Float64EqualityThreshold {
+							t.Errorf("SimilarityMatrix - (%d, %d) expected %f, got %f", i, j, expected, score)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSimilarityMatrixResultAccessors(t *testing.T) {
+	m := SimilarityMatrix(matrixItems, Levenshtein)
+	if m.GetAlgorithm() != Levenshtein || m.GetAlgorithmName() != "Levenshtein" || m.GetError() != nil {
+		t.Errorf("SimilarityMatrixResult - unexpected metadata %v %q %v",
+			m.GetAlgorithm(), m.GetAlgorithmName(), m.GetError())
+	}
+	if len(m.GetItems()) != len(matrixItems) {
+		t.Errorf("SimilarityMatrixResult - unexpected items %v", m.GetItems())
+	}
+	if _, err := m.Get(-1, 0); !errors.Is(err, errors2.ErrMatrixIndexOutOfRange) {
+		t.Errorf("SimilarityMatrixResult - expected out of range error, got %v", err)
+	}
+	if _, err := m.Get(0, len(matrixItems)); !errors.Is(err, errors2.ErrMatrixIndexOutOfRange) {
+		t.Errorf("SimilarityMatrixResult - expected out of range error, got %v", err)
+	}
+	row := m.GetRow(0)
+	row[1] = 42
+	if score, _ := m.Get(0, 1); score == 42 {
+		t.Errorf("SimilarityMatrixResult - GetRow did not return a copy")
+	}
+	if m.GetRow(len(matrixItems)) != nil {
+		t.Errorf("SimilarityMatrixResult - expected nil row for out of range index")
+	}
+	invalid := SimilarityMatrix(matrixItems, Algorithm(99))
+	if _, err := invalid.Get(0, 0); !errors.Is(err, errors2.ErrInvalidAlgorithm) {
+		t.Errorf("SimilarityMatrixResult - expected algorithm error, got %v", err)
+	}
+	if invalid.GetRow(0) != nil || invalid.TopK(0, 1) != nil {
+		t.Errorf("SimilarityMatrixResult - expected nil results for failed matrix")
+	}
+	if _, err := invalid.ToCSV(); err == nil {
+		t.Errorf("SimilarityMatrixResult - expected CSV error for failed matrix")
+	}
+	if _, err := invalid.ToJSON(); err == nil {
+		t.Errorf("SimilarityMatrixResult - expected JSON error for failed matrix")
+	}
+	var missing *SimilarityMatrixResult
+	if _, err := missing.Get(0, 0); !errors.Is(err, errors2.ErrNilSimilarityMatrix) {
+		t.Errorf("SimilarityMatrixResult - expected nil matrix error, got %v", err)
+	}
+	if err := missing.GetError(); !errors.Is(err, errors2.ErrNilSimilarityMatrix) {
+		t.Errorf("SimilarityMatrixResult - expected nil matrix error from GetError, got %v", err)
+	}
+	if _, err := missing.ToCSV(); !errors.Is(err, errors2.ErrNilSimilarityMatrix) {
+		t.Errorf("SimilarityMatrixResult - expected nil matrix error from ToCSV, got %v", err)
+	}
+	if _, err := missing.ToJSON(); !errors.Is(err, errors2.ErrNilSimilarityMatrix) {
+		t.Errorf("SimilarityMatrixResult - expected nil matrix error from ToJSON, got %v", err)
+	}
+	if missing.Len() != 0 || missing.GetRow(0) != nil || missing.TopK(0, 1) != nil || missing.GetItems() != nil {
+		t.Errorf("SimilarityMatrixResult - expected no results for nil matrix")
+	}
+	if missing.GetAlgorithm() != Levenshtein || missing.GetAlgorithmName() != "" {
+		t.Errorf("SimilarityMatrixResult - expected no algorithm for nil matrix")
+	}
+}
+
+func TestSimilarityMatrixTopK(t *testing.T) {
+	m := SimilarityMatrix(matrixItems, Levenshtein)
+	tests := []struct {
+		name     string
+		i        int
+		k        int
+		expected int
+	}{
+		{"TopK2", 0, 2, 2},
+		{"TopKAll", 1, 10, len(matrixItems) - 1},
+		{"TopKZero", 0, 0, 0},
+		{"TopKNegativeIndex", -1, 2, 0},
+		{"TopKIndexOutOfRange", len(matrixItems), 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := m.TopK(tt.i, tt.k)
+			if len(results) != tt.expected {
+				t.Fatalf("TopK(%d, %d) - expected %d results, got %d", tt.i, tt.k, tt.expected, len(results))
+			}
+			var last float32 = 2
+			for _, r := range results {
+				score, err := r.GetScore()
+				if err != nil || score > last {
+					t.Errorf("TopK(%d, %d) - results not ordered: %v", tt.i, tt.k, results)
+				}
+				if r.GetString1() != matrixItems[tt.i] || r.GetString2() == matrixItems[tt.i] {
+					t.Errorf("TopK(%d, %d) - unexpected pair %q/%q", tt.i, tt.k, r.GetString1(), r.GetString2())
+				}
+				last = score
+			}
+		})
+	}
+	if best := m.TopK(0, 1); best[0].GetString2() != "mitten" {
+		t.Errorf("TopK - expected mitten closest to kitten, got %q", best[0].GetString2())
+	}
+}
+
+func TestSimilarityMatrixExport(t *testing.T) {
+	m := SimilarityMatrix([]string{"a,b", "ab", "b"}, Levenshtein)
+	out, err := m.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV - unexpected error %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("ToCSV - invalid CSV %q: %v", out, err)
+	}
+	if len(records) != 4 || records[0][1] != "a,b" || records[1][0] != "a,b" || records[1][1] != "1" {
+		t.Errorf("ToCSV - unexpected records %v", records)
+	}
+	js, err := m.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON - unexpected error %v", err)
+	}
+	var decoded similarityMatrixJSON
+	if err := json.Unmarshal([]byte(js), &decoded); err != nil {
+		t.Fatalf("ToJSON - invalid JSON %q: %v", js, err)
+	}
+	if decoded.Algorithm != "Levenshtein" || len(decoded.Items) != 3 || len(decoded.Scores) != 3 {
+		t.Errorf("ToJSON - unexpected output %+v", decoded)
+	}
+	if !strings.Contains(formatSimilarityMatrixOutput(m, false), "3×3") ||
+		!strings.Contains(formatSimilarityMatrixOutput(m, true), `"ab":`) {
+		t.Errorf("formatSimilarityMatrixOutput - unexpected output")
+	}
+}
+
+func TestSimilarityMatrixBuilder(t *testing.T) {
+	sb := New("kitten").SimilarityMatrix(matrixItems[1:], Levenshtein)
+	if sb.Error() != nil {
+		t.Fatalf("StringBuilder.SimilarityMatrix - unexpected error %v", sb.Error())
+	}
+	cm := sb.GetComparisonManager()
+	stored := cm.GetSimilarityMatrix(Levenshtein)
+	if !stored.IsMatch(SimilarityMatrix(matrixItems, Levenshtein)) {
+		t.Errorf("StringBuilder.SimilarityMatrix - stored matrix does not match")
+	}
+	if cm.GetSimilarityMatrixMap().TypeCount() != 1 || !cm.CopySimilarityMatrixMap().IsMatch(cm.GetSimilarityMatrixMap()) {
+		t.Errorf("StringBuilder.SimilarityMatrix - unexpected matrix map")
+	}
+	if cm.GetSimilarityResult(Levenshtein, "mitten") == nil ||
+		len(cm.GetSimilarityResultsByType(Levenshtein)) != len(matrixItems)-1 {
+		t.Errorf("StringBuilder.SimilarityMatrix - row not ingested into similarity results")
+	}
+	errBuilder := New("kitten").SimilarityMatrix(matrixItems, Algorithm(99))
+	if !errors.Is(errBuilder.Error(), errors2.ErrInvalidAlgorithm) || errBuilder.String() != "kitten" {
+		t.Errorf("StringBuilder.SimilarityMatrix - expected non-fatal algorithm error, got %v", errBuilder.Error())
+	}
+	empty := &ComparisonManager{}
+	if empty.GetSimilarityMatrix(Levenshtein) != nil || empty.GetSimilarityMatrixMap() != nil ||
+		empty.CopySimilarityMatrixMap() != nil {
+		t.Errorf("ComparisonManager - expected nil matrices for uninitialized manager")
+	}
+	empty.AddSimilarityMatrix(*stored)
+	if empty.GetSimilarityMatrix(Levenshtein) == nil {
+		t.Errorf("ComparisonManager - AddSimilarityMatrix did not initialize map")
+	}
+}
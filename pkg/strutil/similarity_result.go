@@ -43,6 +43,20 @@ const (
 	QGram                 = Algorithm(edlib.Qgram)
 )
 
+// FuzzyRatio represents the normalized Indel similarity of the processed strings.
+// FuzzyPartialRatio represents the best Ratio of the shorter string against substrings of the longer string.
+// FuzzyTokenSortRatio represents the Ratio of the strings with their tokens sorted.
+// FuzzyTokenSetRatio represents the best Ratio between the shared and remaining tokens of the strings.
+// FuzzyWeightedRatio represents the best length-weighted combination of the other token ratios.
+// Token ratio algorithms are computed by this package rather than edlib and use NewTokenizeOptions.
+const (
+	FuzzyRatio Algorithm = QGram + 1 + iota
+	FuzzyPartialRatio
+	FuzzyTokenSortRatio
+	FuzzyTokenSetRatio
+	FuzzyWeightedRatio
+)
+
 // AlgorithmTypeMap maps edlib.Algorithm constants to their corresponding string representations for display purposes.
 var AlgorithmTypeMap = map[Algorithm]string{
 	Levenshtein:           "Levenshtein",
@@ -56,6 +70,11 @@ var AlgorithmTypeMap = map[Algorithm]string{
 	Jaccard:               "Jaccard",
 	SorensenDice:          "Sorensen-Dice",
 	QGram:                 "Q-Gram",
+	FuzzyRatio:            "Ratio",
+	FuzzyPartialRatio:     "Partial Ratio",
+	FuzzyTokenSortRatio:   "Token Sort Ratio",
+	FuzzyTokenSetRatio:    "Token Set Ratio",
+	FuzzyWeightedRatio:    "Weighted Ratio",
 }
 
 // SimilarityResult represents the result of a score computation between two strings.
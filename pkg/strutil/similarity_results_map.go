@@ -4,12 +4,7 @@ import "fmt"
 
 // SimilarityResultsMap is keyed by algorithm and holds maps of score results keyed by comparison string.
 //
-//	map[Levenshtein]["comparison text"]SimilarityResult{
-//		algorithm: Levenshtein,
-//		string1: "original",
-//		string2: "comparison text",
-//		score: 0.12,
-//		err: nil}
+//	map[Levenshtein]["comparison text"]NewSimilarityResult(Levenshtein, "original", "comparison text", &score, nil)
 type SimilarityResultsMap map[Algorithm]map[string]*SimilarityResult
 
 // NewSimilarityResultsMap initializes and returns a new SimilarityResultsMap as an empty map.
@@ -19,27 +14,17 @@ func NewSimilarityResultsMap() SimilarityResultsMap {
 
 // Add inserts or updates a SimilarityResult in the SimilarityResultsMap based on its algorithm and comparison word.
 func (smr SimilarityResultsMap) Add(result SimilarityResult) {
-	// if there's not a map for this algorithm, add one
-	if smr[result.GetAlgorithm()] == nil {
-		smr[result.GetAlgorithm()] = make(map[string]*SimilarityResult)
-	}
-	// add or update score for this comparison word
-	smr[result.GetAlgorithm()][result.GetString2()] = &result
+	smr.index().add(result.GetAlgorithm(), result.GetString2(), result)
+}
+
+// index returns the map as the resultIndex its methods delegate to.
+func (smr SimilarityResultsMap) index() resultIndex[Algorithm, string, SimilarityResult] {
+	return resultIndex[Algorithm, string, SimilarityResult](smr)
 }
 
 // GetCopy creates and returns a deep copy of the SimilarityResultsMap, ensuring all nested maps and values are cloned.
 func (smr SimilarityResultsMap) GetCopy() SimilarityResultsMap {
-	cloned := NewSimilarityResultsMap()
-	for algo, val := range smr {
-		cloned[algo] = make(map[string]*SimilarityResult)
-		for compStr, result := range val {
-			if result != nil {
-				resultCopy := *result
-				cloned[algo][compStr] = &resultCopy
-			}
-		}
-	}
-	return cloned
+	return SimilarityResultsMap(smr.index().clone())
 }
 
 // Get retrieves a pointer to the SimilarityResult for the given Algorithm and
@@ -56,69 +41,27 @@ func (smr SimilarityResultsMap) Get(algo Algorithm, compStr string) *SimilarityR
 
 // GetByType retrieves all SimilarityResult entries for a specified Algorithm from the SimilarityResultsMap.
 func (smr SimilarityResultsMap) GetByType(algo Algorithm) []SimilarityResult {
-	if smr[algo] == nil {
-		return nil
-	}
-	var results []SimilarityResult
-	for _, v := range smr[algo] {
-		results = append(results, *v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(smr.index().values(func(a Algorithm, _ string) bool { return a == algo }),
+		derefResult[SimilarityResult])
 }
 
 // FilterByType filters the SimilarityResultsMap to include only results of the
 // specified algorithm and returns a new map.
 func (smr SimilarityResultsMap) FilterByType(algo Algorithm) SimilarityResultsMap {
-	if smr[algo] == nil {
-		return nil
-	}
-	results := NewSimilarityResultsMap()
-	for _, v := range smr[algo] {
-		results.Add(*v)
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return SimilarityResultsMap(smr.index().filter(func(a Algorithm, _ string) bool { return a == algo }))
 }
 
 // GetByComparisonString retrieves all SimilarityResult entries that match the specified
 // comparison string across all algorithms.
 func (smr SimilarityResultsMap) GetByComparisonString(compStr string) []SimilarityResult {
-	if len(smr) == 0 {
-		return nil
-	}
-	var results []SimilarityResult
-	for _, v := range smr {
-		if v[compStr] != nil {
-			results = append(results, *v[compStr])
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return convertResults(smr.index().values(func(_ Algorithm, s string) bool { return s == compStr }),
+		derefResult[SimilarityResult])
 }
 
 // FilterByComparisonString filters the map to include only results matching the given
 // comparison string across all algorithms.
 func (smr SimilarityResultsMap) FilterByComparisonString(compStr string) SimilarityResultsMap {
-	if len(smr) == 0 {
-		return nil
-	}
-	results := NewSimilarityResultsMap()
-	for _, v := range smr {
-		if v[compStr] != nil {
-			results.Add(*v[compStr])
-		}
-	}
-	if len(results) == 0 {
-		return nil
-	}
-	return results
+	return SimilarityResultsMap(smr.index().filter(func(_ Algorithm, s string) bool { return s == compStr }))
 }
 
 // TypeCount returns the number of algorithms present in the SimilarityResultsMap.
@@ -128,38 +71,13 @@ func (smr SimilarityResultsMap) TypeCount() int {
 
 // EntryCount returns the total number of non-nil SimilarityResult entries within the SimilarityResultsMap.
 func (smr SimilarityResultsMap) EntryCount() int {
-	mapLength := 0
-	for _, v := range smr {
-		for _, v2 := range v {
-			if v2 != nil {
-				mapLength++
-			}
-		}
-	}
-	return mapLength
+	return smr.index().entryCount()
 }
 
 // IsMatch compares two SimilarityResultsMap objects for equality by checking type
 // and entry counts, and all nested results.
 func (smr SimilarityResultsMap) IsMatch(other SimilarityResultsMap) bool {
-	if smr.TypeCount() != other.TypeCount() || smr.EntryCount() != other.EntryCount() {
-		return false
-	}
-	for algo, v := range smr {
-		if other[algo] == nil {
-			return false
-		}
-		for compStr, v2 := range v {
-			if other[algo][compStr] == nil {
-				return false
-			} else {
-				if !v2.IsMatch(other[algo][compStr]) {
-					return false
-				}
-			}
-		}
-	}
-	return true
+	return smr.index().isMatch(other.index(), (*SimilarityResult).IsMatch)
 }
 
 // Print iterates through the SimilarityResultsMap and prints score results for each algorithm and comparison word.
@@ -0,0 +1,85 @@
+package strutil
+
+// RestrictionLevel is a UTS #39 restriction level, describing how freely a string mixes scripts. Lower levels
+// are more restrictive.
+type RestrictionLevel int
+
+// String returns the string representation of the RestrictionLevel using RestrictionLevelMap.
+func (rl RestrictionLevel) String() string {
+	return RestrictionLevelMap[rl]
+}
+
+// ASCIIOnly strings contain only ASCII characters.
+// SingleScript strings are written in a single script, where Common and Inherited characters such as digits and
+// punctuation count as every script and Han, Hiragana and Katakana together count as Japanese.
+// HighlyRestrictive strings are single script or written in Latin with Han and Hiragana or Katakana, Latin with
+// Han and Bopomofo, or Latin with Han and Hangul.
+// ModeratelyRestrictive strings are highly restrictive or written in Latin and one other recommended script
+// except Cyrillic and Greek.
+// Unrestricted strings mix scripts arbitrarily.
+const (
+	ASCIIOnly RestrictionLevel = iota
+	SingleScript
+	HighlyRestrictive
+	ModeratelyRestrictive
+	Unrestricted
+)
+
+// RestrictionLevelMap maps RestrictionLevel constants to their corresponding string representations.
+var RestrictionLevelMap = map[RestrictionLevel]string{
+	ASCIIOnly:             "ASCII Only",
+	SingleScript:          "Single Script",
+	HighlyRestrictive:     "Highly Restrictive",
+	ModeratelyRestrictive: "Moderately Restrictive",
+	Unrestricted:          "Unrestricted",
+}
+
+// Skeleton returns the UTS #39 confusable skeleton of s: s is decomposed, default ignorable characters are
+// removed and every character is replaced by its prototype from the Unicode confusables data. Strings that look
+// alike, such as "рaypal" with a Cyrillic "р" and "paypal", share a skeleton. Skeletons are meant for
+// comparison and are not suitable for display.
+func Skeleton(s string) string {
+	return skeleton(s)
+}
+
+// AreConfusable reports whether a and b are visually confusable, that is whether they have the same skeleton.
+// Skeletons are case-sensitive, so case-insensitive identifiers such as domain names should be case folded
+// first.
+func AreConfusable(a, b string) bool {
+	return areConfusable(a, b)
+}
+
+// Scripts returns the sorted names of the scripts used by s, as named in unicode.Scripts. Characters of the
+// Common and Inherited scripts are ignored and unassigned characters are reported as "Unknown".
+func Scripts(s string) []string {
+	return scripts(s)
+}
+
+// IsMixedScript reports whether s mixes characters of scripts that are not used together, such as Latin and
+// Cyrillic. Common and Inherited characters are compatible with every script, and Han may be mixed with
+// Hiragana and Katakana, Hangul or Bopomofo.
+func IsMixedScript(s string) bool {
+	return isMixedScript(s)
+}
+
+// IsMixedScriptConfusable reports whether s mixes scripts but its skeleton is written in a single script, like
+// "рaypal" with a Cyrillic "р" whose skeleton is the Latin "paypal".
+func IsMixedScriptConfusable(s string) bool {
+	return isMixedScriptConfusable(s)
+}
+
+// IsWholeScriptConfusable reports whether s is written in a single script and its skeleton is written in a
+// different one, like the all Cyrillic "ѕсоре" whose skeleton is the Latin "scope".
+func IsWholeScriptConfusable(s string) bool {
+	return isWholeScriptConfusable(s)
+}
+
+// GetRestrictionLevel returns the most restrictive RestrictionLevel s satisfies.
+func GetRestrictionLevel(s string) RestrictionLevel {
+	return getRestrictionLevel(s)
+}
+
+// IsRestrictionLevel reports whether s satisfies the given RestrictionLevel.
+func IsRestrictionLevel(s string, level RestrictionLevel) bool {
+	return isRestrictionLevel(s, level)
+}
@@ -0,0 +1,84 @@
+package strutil
+
+import (
+	"strconv"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// Skeleton replaces the string with its UTS #39 confusable skeleton.
+func (sb *StringBuilder) Skeleton() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(skeleton(sb.value))
+	return sb
+}
+
+// AreConfusable reports whether the string is visually confusable with other.
+func (sb *StringBuilder) AreConfusable(other string) bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return areConfusable(sb.value, other)
+}
+
+// IsMixedScript reports whether the string mixes characters of scripts that are not used together.
+func (sb *StringBuilder) IsMixedScript() bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return isMixedScript(sb.value)
+}
+
+// IsRestrictionLevel reports whether the string satisfies the given RestrictionLevel.
+func (sb *StringBuilder) IsRestrictionLevel(level RestrictionLevel) bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return isRestrictionLevel(sb.value, level)
+}
+
+// RequireSingleScript ensures the string does not mix scripts, setting ErrMixedScript otherwise.
+func (sb *StringBuilder) RequireSingleScript() *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	if isMixedScript(value) {
+		return sb.failValidation(newValidationError(value, "single_script", ReasonMixedScript, -1,
+			errors.ErrMixedScript))
+	}
+	return sb
+}
+
+// RequireRestrictionLevel ensures the string satisfies the given RestrictionLevel, setting ErrRestrictionLevel
+// otherwise.
+func (sb *StringBuilder) RequireRestrictionLevel(level RestrictionLevel) *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	if !isRestrictionLevel(value, level) {
+		return sb.failValidation(newExpectationError(value, "restriction_level", ReasonRestrictionLevel,
+			level.String(), errors.ErrRestrictionLevel))
+	}
+	return sb
+}
+
+// RequireNotConfusable ensures the string is not visually confusable with any of the protected strings, such as
+// existing usernames or well known domains, setting ErrConfusable otherwise.
+func (sb *StringBuilder) RequireNotConfusable(protected []string) *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	s := skeleton(value)
+	for _, p := range protected {
+		if skeleton(p) == s {
+			return sb.failValidation(newExpectationError(value, "not_confusable", ReasonConfusable,
+				strconv.Quote(p), errors.ErrConfusable))
+		}
+	}
+	return sb
+}
@@ -0,0 +1,277 @@
+package strutil
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Script names used besides the names of unicode.Scripts. Han with Bopomofo, Japanese and Korean are the
+// augmented scripts of UTS #39 that let Han mix with the scripts written alongside it, and Unknown is the script
+// of unassigned characters.
+const (
+	scriptCommon    = "Common"
+	scriptInherited = "Inherited"
+	scriptLatin     = "Latin"
+	scriptHanb      = "Han with Bopomofo"
+	scriptJpan      = "Japanese"
+	scriptKore      = "Korean"
+	scriptUnknown   = "Unknown"
+)
+
+// scriptAugmentations lists the augmented scripts each script belongs to.
+var scriptAugmentations = map[string][]string{
+	"Han":      {scriptHanb, scriptJpan, scriptKore},
+	"Hiragana": {scriptJpan},
+	"Katakana": {scriptJpan},
+	"Hangul":   {scriptKore},
+	"Bopomofo": {scriptHanb},
+}
+
+// highlyRestrictiveScripts are the script combinations allowed by HighlyRestrictive besides single scripts.
+var highlyRestrictiveScripts = [][]string{
+	{scriptLatin, "Han", "Hiragana", "Katakana"},
+	{scriptLatin, "Han", "Bopomofo"},
+	{scriptLatin, "Han", "Hangul"},
+}
+
+// recommendedScripts are the scripts UAX #31 recommends for use in identifiers.
+var recommendedScripts = []string{
+	"Arabic", "Armenian", "Bengali", "Bopomofo", "Cyrillic", "Devanagari", "Ethiopic", "Georgian", "Greek",
+	"Gujarati", "Gurmukhi", "Han", "Hangul", "Hebrew", "Hiragana", "Kannada", "Katakana", "Khmer", "Lao",
+	scriptLatin, "Malayalam", "Myanmar", "Oriya", "Sinhala", "Tamil", "Telugu", "Thaana", "Thai", "Tibetan",
+}
+
+// scriptSet is a set of scripts, stored as bits indexed like scriptTable.names.
+type scriptSet [4]uint64
+
+// add adds the script with index i to the set.
+func (ss *scriptSet) add(i int) {
+	ss[i/64] |= 1 << (i % 64)
+}
+
+// intersect returns the scripts in both ss and other.
+func (ss scriptSet) intersect(other scriptSet) scriptSet {
+	for i := range ss {
+		ss[i] &= other[i]
+	}
+	return ss
+}
+
+// isEmpty reports whether the set contains no scripts.
+func (ss scriptSet) isEmpty() bool {
+	return ss == scriptSet{}
+}
+
+// scriptRange assigns the characters lo through hi to the script with the given index.
+type scriptRange struct {
+	lo, hi rune
+	script int
+}
+
+// scriptTable indexes the scripts of unicode.Scripts, followed by the extra script names, for fast lookup.
+type scriptTable struct {
+	names  []string
+	index  map[string]int
+	ranges []scriptRange
+}
+
+// scriptData returns the script table, building it on first use.
+var scriptData = sync.OnceValue(func() *scriptTable {
+	st := &scriptTable{index: make(map[string]int)}
+	for name := range unicode.Scripts {
+		st.names = append(st.names, name)
+	}
+	sort.Strings(st.names)
+	st.names = append(st.names, scriptHanb, scriptJpan, scriptKore, scriptUnknown)
+	for i, name := range st.names {
+		st.index[name] = i
+	}
+	for name, table := range unicode.Scripts {
+		st.addRanges(st.index[name], table)
+	}
+	sort.Slice(st.ranges, func(i, j int) bool { return st.ranges[i].lo < st.ranges[j].lo })
+	return st
+})
+
+// addRanges adds the characters of table to the ranges of the script with index i.
+func (st *scriptTable) addRanges(i int, table *unicode.RangeTable) {
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			st.ranges = append(st.ranges, scriptRange{lo: lo, hi: hi, script: i})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			st.ranges = append(st.ranges, scriptRange{lo: r, hi: r, script: i})
+		}
+	}
+	for _, r := range table.R16 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+}
+
+// script returns the name of the script of r.
+func (st *scriptTable) script(r rune) string {
+	i := sort.Search(len(st.ranges), func(i int) bool { return st.ranges[i].hi >= r })
+	if i < len(st.ranges) && st.ranges[i].lo <= r {
+		return st.names[st.ranges[i].script]
+	}
+	return scriptUnknown
+}
+
+// runeScripts returns the script of r together with the augmented scripts it belongs to. The boolean is true if
+// r is a Common or Inherited character, which is compatible with every script.
+func (st *scriptTable) runeScripts(r rune) (scriptSet, bool) {
+	var ss scriptSet
+	name := st.script(r)
+	if name == scriptCommon || name == scriptInherited {
+		return ss, true
+	}
+	ss.add(st.index[name])
+	for _, augmented := range scriptAugmentations[name] {
+		ss.add(st.index[augmented])
+	}
+	return ss, false
+}
+
+// resolvedScripts returns the UTS #39 resolved script set of s, the scripts every character of s is compatible
+// with. The boolean is true if s contains only Common and Inherited characters and so resolves to every script.
+func resolvedScripts(s string) (scriptSet, bool) {
+	st := scriptData()
+	var resolved scriptSet
+	all := true
+	for _, r := range s {
+		ss, common := st.runeScripts(r)
+		switch {
+		case common:
+		case all:
+			resolved, all = ss, false
+		default:
+			resolved = resolved.intersect(ss)
+		}
+	}
+	return resolved, all
+}
+
+// isDefaultIgnorable reports whether r has the Default_Ignorable_Code_Point property, derived as in
+// DerivedCoreProperties.txt.
+func isDefaultIgnorable(r rune) bool {
+	if unicode.Is(unicode.White_Space, r) || unicode.Is(unicode.Prepended_Concatenation_Mark, r) ||
+		(r >= 0xFFF9 && r <= 0xFFFB) || (r >= 0x13430 && r <= 0x1343F) {
+		return false
+	}
+	return unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) || unicode.Is(unicode.Cf, r) ||
+		unicode.Is(unicode.Variation_Selector, r)
+}
+
+// skeleton returns the UTS #39 skeleton of s.
+func skeleton(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range norm.NFD.String(s) {
+		if isDefaultIgnorable(r) {
+			continue
+		}
+		if prototype, ok := confusables[r]; ok {
+			b.WriteString(prototype)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFD.String(b.String())
+}
+
+// areConfusable reports whether a and b have the same skeleton.
+func areConfusable(a, b string) bool {
+	return skeleton(a) == skeleton(b)
+}
+
+// scripts returns the sorted names of the scripts of the characters of s, ignoring Common and Inherited.
+func scripts(s string) []string {
+	st := scriptData()
+	var names []string
+	for _, r := range s {
+		name := st.script(r)
+		if name != scriptCommon && name != scriptInherited && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isMixedScript reports whether the resolved script set of s is empty.
+func isMixedScript(s string) bool {
+	resolved, all := resolvedScripts(s)
+	return !all && resolved.isEmpty()
+}
+
+// isMixedScriptConfusable reports whether s is mixed script while its skeleton is not.
+func isMixedScriptConfusable(s string) bool {
+	return isMixedScript(s) && !isMixedScript(skeleton(s))
+}
+
+// isWholeScriptConfusable reports whether s resolves to a single script and its skeleton resolves to scripts
+// that s is not compatible with.
+func isWholeScriptConfusable(s string) bool {
+	resolved, all := resolvedScripts(s)
+	if all || resolved.isEmpty() {
+		return false
+	}
+	skeletonResolved, skeletonAll := resolvedScripts(skeleton(s))
+	return !skeletonAll && !skeletonResolved.isEmpty() && resolved.intersect(skeletonResolved).isEmpty()
+}
+
+// getRestrictionLevel returns the most restrictive RestrictionLevel s satisfies.
+func getRestrictionLevel(s string) RestrictionLevel {
+	if isASCII(s) {
+		return ASCIIOnly
+	}
+	if !isMixedScript(s) {
+		return SingleScript
+	}
+	used := scripts(s)
+	for _, allowed := range highlyRestrictiveScripts {
+		if isSubset(used, allowed) {
+			return HighlyRestrictive
+		}
+	}
+	others := slices.DeleteFunc(slices.Clone(used), func(name string) bool { return name == scriptLatin })
+	if len(others) == 1 && others[0] != "Cyrillic" && others[0] != "Greek" &&
+		slices.Contains(recommendedScripts, others[0]) {
+		return ModeratelyRestrictive
+	}
+	return Unrestricted
+}
+
+// isRestrictionLevel reports whether s satisfies level.
+func isRestrictionLevel(s string, level RestrictionLevel) bool {
+	return getRestrictionLevel(s) <= level
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset reports whether every element of names is in set.
+func isSubset(names, set []string) bool {
+	for _, name := range names {
+		if !slices.Contains(set, name) {
+			return false
+		}
+	}
+	return true
+}
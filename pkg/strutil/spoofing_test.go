@@ -0,0 +1,181 @@
+package strutil
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"ASCII", "paypal", "paypal"},
+		{"CyrillicLookalike", "рaypal", "paypal"},
+		{"Fullwidth", "ｐａｙｐａｌ", "paypal"},
+		{"MathBold", "𝐩𝐚𝐲𝐩𝐚𝐥", "paypal"},
+		{"Digits", "10", "lO"},
+		{"RnM", "m", "rn"},
+		{"ZeroWidthRemoved", "pay\u200bpal\u00ad", "paypal"},
+		{"Decomposed", "caf\u00e9", "cafe\u0301"},
+		{"Unmapped", "東京", "東京"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := skeleton(tt.input)
+			result := Skeleton(tt.input)
+			builderResult := New(tt.input).Skeleton().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("Skeleton - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestAreConfusable(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{"Identical", "paypal", "paypal", true},
+		{"CyrillicDomain", "рaypal.com", "paypal.com", true},
+		{"DigitOne", "paypa1", "paypal", true},
+		{"RnM", "rnicrosoft", "microsoft", true},
+		{"WholeCyrillic", "ѕсоре", "scope", true},
+		{"Different", "paypal", "palpay", false},
+		{"CaseSensitive", "PayPal", "paypal", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := areConfusable(tt.a, tt.b)
+			result := AreConfusable(tt.a, tt.b)
+			builderResult := New(tt.a).AreConfusable(tt.b)
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("AreConfusable(%q, %q) = %v / %v / %v; want %v",
+					tt.a, tt.b, helperResult, result, builderResult, tt.expected)
+			}
+			builderErr := New(tt.a).RequireNotConfusable([]string{"unrelated", tt.b}).Error()
+			if tt.expected != errors.Is(builderErr, errors2.ErrConfusable) {
+				t.Errorf("RequireNotConfusable(%q, %q) = %v; want confusable %v", tt.a, tt.b, builderErr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScripts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"Empty", "", nil},
+		{"CommonOnly", "123 !?", nil},
+		{"Latin", "hello, world", []string{"Latin"}},
+		{"Mixed", "рaypal", []string{"Cyrillic", "Latin"}},
+		{"Japanese", "東京タワーへ", []string{"Han", "Hiragana", "Katakana"}},
+		{"CombiningMark", "é", []string{"Latin"}},
+		{"Unassigned", "a\u0378", []string{"Latin", "Unknown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := scripts(tt.input)
+			result := Scripts(tt.input)
+			if !slices.Equal(helperResult, tt.expected) || !slices.Equal(result, tt.expected) {
+				t.Errorf("Scripts - expected %q - got %q / %q", tt.expected, helperResult, result)
+			}
+		})
+	}
+}
+
+func TestIsMixedScript(t *testing.T) {
+	tests := []struct {
+		name                  string
+		input                 string
+		mixed                 bool
+		mixedConfusable       bool
+		wholeScriptConfusable bool
+	}{
+		{"Empty", "", false, false, false},
+		{"ASCII", "paypal", false, false, false},
+		{"CommonOnly", "1234", false, false, false},
+		{"CyrillicLookalike", "рaypal", true, true, false},
+		{"WholeCyrillic", "ѕсоре", false, false, true},
+		{"RussianWord", "привет", false, false, false},
+		{"Greek", "Ωmega", true, false, false},
+		{"Japanese", "東京タワー", false, false, false},
+		{"LatinAndHan", "abc東京", true, false, false},
+		{"Katakana", "カ", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := isMixedScript(tt.input)
+			result := IsMixedScript(tt.input)
+			builderResult := New(tt.input).IsMixedScript()
+			if helperResult != tt.mixed || result != tt.mixed || builderResult != tt.mixed {
+				t.Errorf("IsMixedScript(%q) = %v / %v / %v; want %v",
+					tt.input, helperResult, result, builderResult, tt.mixed)
+			}
+			builderErr := New(tt.input).RequireSingleScript().Error()
+			if tt.mixed != errors.Is(builderErr, errors2.ErrMixedScript) {
+				t.Errorf("RequireSingleScript(%q) = %v; want mixed %v", tt.input, builderErr, tt.mixed)
+			}
+			if got := IsMixedScriptConfusable(tt.input); got != tt.mixedConfusable ||
+				isMixedScriptConfusable(tt.input) != tt.mixedConfusable {
+				t.Errorf("IsMixedScriptConfusable(%q) = %v; want %v", tt.input, got, tt.mixedConfusable)
+			}
+			if got := IsWholeScriptConfusable(tt.input); got != tt.wholeScriptConfusable ||
+				isWholeScriptConfusable(tt.input) != tt.wholeScriptConfusable {
+				t.Errorf("IsWholeScriptConfusable(%q) = %v; want %v", tt.input, got, tt.wholeScriptConfusable)
+			}
+		})
+	}
+}
+
+func TestGetRestrictionLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected RestrictionLevel
+	}{
+		{"Empty", "", ASCIIOnly},
+		{"ASCII", "user_42", ASCIIOnly},
+		{"LatinAccents", "josé", SingleScript},
+		{"Cyrillic", "привет", SingleScript},
+		{"Japanese", "東京タワー", SingleScript},
+		{"LatinJapanese", "abc東京カ", HighlyRestrictive},
+		{"LatinKorean", "abc한국", HighlyRestrictive},
+		{"LatinHebrew", "abcשלום", ModeratelyRestrictive},
+		{"LatinCyrillic", "рaypal", Unrestricted},
+		{"LatinGreek", "abcαβγ", Unrestricted},
+		{"ThreeScripts", "abcשלוםا", Unrestricted},
+		{"NotRecommended", "abcᚠ", Unrestricted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := getRestrictionLevel(tt.input)
+			result := GetRestrictionLevel(tt.input)
+			if helperResult != tt.expected || result != tt.expected {
+				t.Errorf("GetRestrictionLevel(%q) = %v / %v; want %v", tt.input, helperResult, result, tt.expected)
+			}
+			for level := ASCIIOnly; level <= Unrestricted; level++ {
+				want := level >= tt.expected
+				if got := isRestrictionLevel(tt.input, level); got != want || IsRestrictionLevel(tt.input, level) != want ||
+					New(tt.input).IsRestrictionLevel(level) != want {
+					t.Errorf("IsRestrictionLevel(%q, %v) = %v; want %v", tt.input, level, got, want)
+				}
+				builderErr := New(tt.input).RequireRestrictionLevel(level).Error()
+				if want == errors.Is(builderErr, errors2.ErrRestrictionLevel) {
+					t.Errorf("RequireRestrictionLevel(%q, %v) = %v; want %v", tt.input, level, builderErr, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package strutil
+
+// DiffAlgorithm selects the algorithm used to align the tokens of two texts.
+type DiffAlgorithm int
+
+// String returns the string representation of the DiffAlgorithm using DiffAlgorithmMap.
+func (d DiffAlgorithm) String() string {
+	return DiffAlgorithmMap[d]
+}
+
+// DiffMyers finds a minimal diff with Myers' O(ND) algorithm in linear space.
+// DiffPatience anchors the diff on tokens that occur exactly once in both texts, which keeps moved blocks and
+// repeated lines such as braces from being matched out of place, and falls back to Myers between anchors.
+// DiffHistogram extends patience by anchoring on the least frequent common tokens, so it still finds anchors
+// when no token is unique; it falls back to Myers when every candidate is too frequent.
+const (
+	DiffMyers DiffAlgorithm = iota
+	DiffPatience
+	DiffHistogram
+)
+
+// DiffAlgorithmMap maps DiffAlgorithm constants to their corresponding string representations.
+var DiffAlgorithmMap = map[DiffAlgorithm]string{
+	DiffMyers:     "Myers",
+	DiffPatience:  "Patience",
+	DiffHistogram: "Histogram",
+}
+
+// DiffGranularity selects how texts are split into tokens before they are compared.
+type DiffGranularity int
+
+// String returns the string representation of the DiffGranularity using DiffGranularityMap.
+func (d DiffGranularity) String() string {
+	return DiffGranularityMap[d]
+}
+
+// DiffLines compares texts line by line; each token keeps its trailing line feed.
+// DiffWords compares texts word by word; runs of whitespace are tokens of their own so the texts can be rebuilt.
+const (
+	DiffLines DiffGranularity = iota
+	DiffWords
+)
+
+// DiffGranularityMap maps DiffGranularity constants to their corresponding string representations.
+var DiffGranularityMap = map[DiffGranularity]string{
+	DiffLines: "Lines",
+	DiffWords: "Words",
+}
+
+// DiffOptions configures the algorithm and granularity of Diff.
+type DiffOptions struct {
+	// Algorithm selects the alignment algorithm.
+	Algorithm DiffAlgorithm
+	// Granularity selects whether lines or words are compared.
+	Granularity DiffGranularity
+}
+
+// NewDiffOptions returns DiffOptions for a line diff using Myers' algorithm.
+func NewDiffOptions() DiffOptions {
+	return DiffOptions{
+		Algorithm:   DiffMyers,
+		Granularity: DiffLines,
+	}
+}
+
+// DiffEdit is a run of consecutive tokens that are equal in, inserted into or deleted from the first text.
+type DiffEdit struct {
+	// Type is EditEqual, EditInsert or EditDelete.
+	Type EditOpType
+	// Index1 is the token offset in the first text where the run starts.
+	Index1 int
+	// Index2 is the token offset in the second text where the run starts.
+	Index2 int
+	// Tokens holds the lines or words of the run.
+	Tokens []string
+}
+
+// Diff compares s1 and s2 line by line or word by word using the algorithm in opts and returns a TextDiffResult,
+// which renders as a unified diff or inline markup. Unknown algorithms fall back to Myers and unknown
+// granularities to lines.
+func Diff(s1, s2 string, opts DiffOptions) *TextDiffResult {
+	return textDiff(s1, s2, opts)
+}
+
+// ParseUnifiedDiff parses a unified diff, such as the output of TextDiffResult.Unified or diff -u, into a Patch.
+// Only the first file of a multi-file diff is read. Returns ErrInvalidPatch if the diff is malformed.
+func ParseUnifiedDiff(diff string) (*Patch, error) {
+	return parseUnifiedDiff(diff)
+}
+
+// ApplyPatch parses a unified diff and applies it to original. Hunks whose context has moved are searched for
+// nearby, as patch does without fuzz. Returns ErrInvalidPatch if the diff is malformed or ErrPatchConflict if a
+// hunk does not match original.
+func ApplyPatch(original, patch string) (string, error) {
+	return applyPatch(original, patch)
+}
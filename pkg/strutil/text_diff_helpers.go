@@ -0,0 +1,380 @@
+package strutil
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// histogramMaxChain is the most occurrences a token may have in the first text to anchor a histogram diff.
+const histogramMaxChain = 64
+
+// diffScript collects the edits between two token sequences, merging consecutive edits of the same type.
+// Tokens are interned to integers so the algorithms compare ints instead of strings.
+type diffScript struct {
+	a     []string
+	b     []string
+	x     []int
+	y     []int
+	edits []DiffEdit
+}
+
+// histogramCandidate is a run of tokens common to both texts, scored by its least frequent token in the first text.
+type histogramCandidate struct {
+	i      int
+	j      int
+	length int
+	count  int
+}
+
+// diffBisect holds the state of a search for the middle of a Myers diff path.
+type diffBisect struct {
+	s       *diffScript
+	alo     int
+	blo     int
+	n       int
+	m       int
+	delta   int
+	offset  int
+	v1      []int
+	v2      []int
+	k1start int
+	k1end   int
+	k2start int
+	k2end   int
+}
+
+// textDiff tokenizes s1 and s2 and diffs the tokens with the algorithm in opts.
+func textDiff(s1, s2 string, opts DiffOptions) *TextDiffResult {
+	s := newDiffScript(diffTokens(s1, opts.Granularity), diffTokens(s2, opts.Granularity))
+	n, m := len(s.a), len(s.b)
+	switch opts.Algorithm {
+	case DiffPatience:
+		s.patience(0, n, 0, m)
+	case DiffHistogram:
+		s.histogram(0, n, 0, m)
+	default:
+		s.myers(0, n, 0, m)
+	}
+	return NewTextDiffResult(s1, s2, opts, s.edits)
+}
+
+// diffTokens splits s into lines that keep their line feeds, or into alternating runs of words and whitespace.
+func diffTokens(s string, granularity DiffGranularity) []string {
+	if s == "" {
+		return nil
+	}
+	if granularity == DiffWords {
+		return diffWordTokens(s)
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffWordTokens splits s wherever it switches between whitespace and non-whitespace runes.
+func diffWordTokens(s string) []string {
+	var tokens []string
+	start, prevSpace := 0, false
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if i > start && space != prevSpace {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		prevSpace = space
+	}
+	return append(tokens, s[start:])
+}
+
+// newDiffScript interns the tokens of a and b and returns an empty diffScript over them.
+func newDiffScript(a, b []string) *diffScript {
+	ids := make(map[string]int)
+	intern := func(tokens []string) []int {
+		out := make([]int, len(tokens))
+		for i, t := range tokens {
+			id, ok := ids[t]
+			if !ok {
+				id = len(ids)
+				ids[t] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+	return &diffScript{a: a, b: b, x: intern(a), y: intern(b)}
+}
+
+// emit appends one token to the script: a[i] for equal and deleted tokens, b[j] for inserted ones.
+func (s *diffScript) emit(t EditOpType, i, j int) {
+	var token string
+	if t == EditInsert {
+		token = s.b[j]
+	} else {
+		token = s.a[i]
+	}
+	if n := len(s.edits); n > 0 && s.edits[n-1].Type == t {
+		s.edits[n-1].Tokens = append(s.edits[n-1].Tokens, token)
+		return
+	}
+	s.edits = append(s.edits, DiffEdit{Type: t, Index1: i, Index2: j, Tokens: []string{token}})
+}
+
+// replace emits a[alo:ahi] as deleted and b[blo:bhi] as inserted.
+func (s *diffScript) replace(alo, ahi, blo, bhi int) {
+	for i := alo; i < ahi; i++ {
+		s.emit(EditDelete, i, blo)
+	}
+	for j := blo; j < bhi; j++ {
+		s.emit(EditInsert, ahi, j)
+	}
+}
+
+// trimCommon emits the common prefix of the two ranges and returns the ranges without their common prefix and
+// suffix, followed by the length of the suffix, which the caller emits with emitSuffix once the middle is done.
+func (s *diffScript) trimCommon(alo, ahi, blo, bhi int) (int, int, int, int, int) {
+	for alo < ahi && blo < bhi && s.x[alo] == s.y[blo] {
+		s.emit(EditEqual, alo, blo)
+		alo, blo = alo+1, blo+1
+	}
+	suffix := 0
+	for ahi > alo && bhi > blo && s.x[ahi-1] == s.y[bhi-1] {
+		ahi, bhi, suffix = ahi-1, bhi-1, suffix+1
+	}
+	return alo, ahi, blo, bhi, suffix
+}
+
+// emitSuffix emits the length tokens starting at ahi and bhi as equal.
+func (s *diffScript) emitSuffix(ahi, bhi, length int) {
+	for t := 0; t < length; t++ {
+		s.emit(EditEqual, ahi+t, bhi+t)
+	}
+}
+
+// myers diffs the ranges with Myers' algorithm, splitting them at the middle of an optimal path and recursing,
+// so memory stays linear in the length of the texts.
+func (s *diffScript) myers(alo, ahi, blo, bhi int) {
+	alo, ahi, blo, bhi, suffix := s.trimCommon(alo, ahi, blo, bhi)
+	x, y, ok := s.bisect(alo, ahi, blo, bhi)
+	if ok && (x != alo || y != blo) && (x != ahi || y != bhi) {
+		s.myers(alo, x, blo, y)
+		s.myers(x, ahi, y, bhi)
+	} else {
+		s.replace(alo, ahi, blo, bhi)
+	}
+	s.emitSuffix(ahi, bhi, suffix)
+}
+
+// bisect walks Myers' paths forward from the start and backward from the end of the ranges at the same time and
+// returns the point where they first overlap. Reports false if either range is empty or nothing is in common.
+func (s *diffScript) bisect(alo, ahi, blo, bhi int) (int, int, bool) {
+	n, m := ahi-alo, bhi-blo
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	maxD := (n + m + 1) / 2
+	b := &diffBisect{s: s, alo: alo, blo: blo, n: n, m: m, delta: n - m, offset: maxD}
+	b.v1, b.v2 = make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for k := range b.v1 {
+		b.v1[k], b.v2[k] = -1, -1
+	}
+	b.v1[maxD+1], b.v2[maxD+1] = 0, 0
+	for d := 0; d < maxD; d++ {
+		if x, y, ok := b.forward(d); ok {
+			return alo + x, blo + y, true
+		}
+		if x, y, ok := b.backward(d); ok {
+			return alo + x, blo + y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// forward extends the forward paths by one edit and reports where one overlaps a backward path, which can only
+// happen on this pass when the length difference is odd.
+func (b *diffBisect) forward(d int) (int, int, bool) {
+	for k1 := -d + b.k1start; k1 <= d-b.k1end; k1 += 2 {
+		k1Offset := b.offset + k1
+		var x1 int
+		if k1 == -d || (k1 != d && b.v1[k1Offset-1] < b.v1[k1Offset+1]) {
+			x1 = b.v1[k1Offset+1]
+		} else {
+			x1 = b.v1[k1Offset-1] + 1
+		}
+		y1 := x1 - k1
+		for x1 < b.n && y1 < b.m && b.s.x[b.alo+x1] == b.s.y[b.blo+y1] {
+			x1, y1 = x1+1, y1+1
+		}
+		b.v1[k1Offset] = x1
+		switch {
+		case x1 > b.n:
+			b.k1end += 2
+		case y1 > b.m:
+			b.k1start += 2
+		case b.delta%2 != 0:
+			k2Offset := b.offset + b.delta - k1
+			if k2Offset >= 0 && k2Offset < len(b.v2) && b.v2[k2Offset] != -1 && x1 >= b.n-b.v2[k2Offset] {
+				return x1, y1, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// backward extends the backward paths by one edit and reports where one overlaps a forward path, which can only
+// happen on this pass when the length difference is even.
+func (b *diffBisect) backward(d int) (int, int, bool) {
+	for k2 := -d + b.k2start; k2 <= d-b.k2end; k2 += 2 {
+		k2Offset := b.offset + k2
+		var x2 int
+		if k2 == -d || (k2 != d && b.v2[k2Offset-1] < b.v2[k2Offset+1]) {
+			x2 = b.v2[k2Offset+1]
+		} else {
+			x2 = b.v2[k2Offset-1] + 1
+		}
+		y2 := x2 - k2
+		for x2 < b.n && y2 < b.m && b.s.x[b.alo+b.n-x2-1] == b.s.y[b.blo+b.m-y2-1] {
+			x2, y2 = x2+1, y2+1
+		}
+		b.v2[k2Offset] = x2
+		switch {
+		case x2 > b.n:
+			b.k2end += 2
+		case y2 > b.m:
+			b.k2start += 2
+		case b.delta%2 == 0:
+			k1Offset := b.offset + b.delta - k2
+			if k1Offset >= 0 && k1Offset < len(b.v1) && b.v1[k1Offset] != -1 && b.v1[k1Offset] >= b.n-x2 {
+				x1 := b.v1[k1Offset]
+				return x1, b.offset + x1 - k1Offset, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// patience anchors the diff on the longest increasing sequence of tokens that occur exactly once in both ranges
+// and recurses between the anchors, falling back to Myers when there are none.
+func (s *diffScript) patience(alo, ahi, blo, bhi int) {
+	alo, ahi, blo, bhi, suffix := s.trimCommon(alo, ahi, blo, bhi)
+	anchors := s.uniqueAnchors(alo, ahi, blo, bhi)
+	if len(anchors) == 0 {
+		s.myers(alo, ahi, blo, bhi)
+	} else {
+		i, j := alo, blo
+		for _, anchor := range anchors {
+			s.patience(i, anchor[0], j, anchor[1])
+			s.emit(EditEqual, anchor[0], anchor[1])
+			i, j = anchor[0]+1, anchor[1]+1
+		}
+		s.patience(i, ahi, j, bhi)
+	}
+	s.emitSuffix(ahi, bhi, suffix)
+}
+
+// uniqueAnchors returns the positions of tokens occurring exactly once in each range that form the longest
+// sequence in the same order in both.
+func (s *diffScript) uniqueAnchors(alo, ahi, blo, bhi int) [][2]int {
+	countA, countB, posB := make(map[int]int), make(map[int]int), make(map[int]int)
+	for i := alo; i < ahi; i++ {
+		countA[s.x[i]]++
+	}
+	for j := blo; j < bhi; j++ {
+		countB[s.y[j]]++
+		posB[s.y[j]] = j
+	}
+	var pairs [][2]int
+	for i := alo; i < ahi; i++ {
+		if id := s.x[i]; countA[id] == 1 && countB[id] == 1 {
+			pairs = append(pairs, [2]int{i, posB[id]})
+		}
+	}
+	return longestIncreasingPairs(pairs)
+}
+
+// longestIncreasingPairs returns the longest subsequence of pairs whose second elements increase,
+// found with patience sorting. The pairs must be sorted by their first element.
+func longestIncreasingPairs(pairs [][2]int) [][2]int {
+	if len(pairs) == 0 {
+		return nil
+	}
+	var tails []int
+	prev := make([]int, len(pairs))
+	for p := range pairs {
+		pos := sort.Search(len(tails), func(t int) bool { return pairs[tails[t]][1] >= pairs[p][1] })
+		prev[p] = -1
+		if pos > 0 {
+			prev[p] = tails[pos-1]
+		}
+		if pos == len(tails) {
+			tails = append(tails, p)
+		} else {
+			tails[pos] = p
+		}
+	}
+	out := make([][2]int, len(tails))
+	for k, p := len(tails)-1, tails[len(tails)-1]; k >= 0; k, p = k-1, prev[p] {
+		out[k] = pairs[p]
+	}
+	return out
+}
+
+// histogram splits the ranges around the common run whose rarest token occurs least often in the first range,
+// preferring longer runs on ties, and recurses on both sides. Falls back to Myers when no run qualifies.
+func (s *diffScript) histogram(alo, ahi, blo, bhi int) {
+	alo, ahi, blo, bhi, suffix := s.trimCommon(alo, ahi, blo, bhi)
+	if c, ok := s.histogramRegion(alo, ahi, blo, bhi); ok {
+		s.histogram(alo, c.i, blo, c.j)
+		for t := 0; t < c.length; t++ {
+			s.emit(EditEqual, c.i+t, c.j+t)
+		}
+		s.histogram(c.i+c.length, ahi, c.j+c.length, bhi)
+	} else {
+		s.myers(alo, ahi, blo, bhi)
+	}
+	s.emitSuffix(ahi, bhi, suffix)
+}
+
+// histogramRegion finds the best common run for histogram, skipping tokens that occur more than
+// histogramMaxChain times in the first range.
+func (s *diffScript) histogramRegion(alo, ahi, blo, bhi int) (histogramCandidate, bool) {
+	occurrences := make(map[int][]int)
+	for i := alo; i < ahi; i++ {
+		occurrences[s.x[i]] = append(occurrences[s.x[i]], i)
+	}
+	best := histogramCandidate{count: histogramMaxChain + 1}
+	for j := blo; j < bhi; {
+		next := j + 1
+		positions := occurrences[s.y[j]]
+		if len(positions) > histogramMaxChain || len(positions) > best.count {
+			j = next
+			continue
+		}
+		for _, i := range positions {
+			c := s.histogramExtend(occurrences, i, j, [4]int{alo, ahi, blo, bhi})
+			if c.count < best.count || (c.count == best.count && c.length > best.length) {
+				best = c
+			}
+			next = max(next, c.j+c.length)
+		}
+		j = next
+	}
+	return best, best.length > 0
+}
+
+// histogramExtend grows the match between a[i] and b[j] in both directions within bounds and records the
+// occurrence count of its rarest token.
+func (s *diffScript) histogramExtend(occurrences map[int][]int, i, j int, bounds [4]int) histogramCandidate {
+	c := histogramCandidate{i: i, j: j, length: 1, count: len(occurrences[s.x[i]])}
+	for c.i > bounds[0] && c.j > bounds[2] && s.x[c.i-1] == s.y[c.j-1] {
+		c.i, c.j, c.length = c.i-1, c.j-1, c.length+1
+		c.count = min(c.count, len(occurrences[s.x[c.i]]))
+	}
+	for c.i+c.length < bounds[1] && c.j+c.length < bounds[3] && s.x[c.i+c.length] == s.y[c.j+c.length] {
+		c.count = min(c.count, len(occurrences[s.x[c.i+c.length]]))
+		c.length++
+	}
+	return c
+}
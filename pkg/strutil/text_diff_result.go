@@ -0,0 +1,149 @@
+package strutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TextDiffResult holds the line or word edits that turn one text into another.
+type TextDiffResult struct {
+	string1 string
+	string2 string
+	options DiffOptions
+	edits   []DiffEdit
+}
+
+// NewTextDiffResult initializes and returns a new TextDiffResult with the provided parameters.
+func NewTextDiffResult(string1 string,
+	string2 string,
+	options DiffOptions,
+	edits []DiffEdit) *TextDiffResult {
+	return &TextDiffResult{
+		string1: string1,
+		string2: string2,
+		options: options,
+		edits:   edits,
+	}
+}
+
+// GetString1 returns the original text.
+func (td *TextDiffResult) GetString1() string {
+	return td.string1
+}
+
+// GetString2 returns the changed text.
+func (td *TextDiffResult) GetString2() string {
+	return td.string2
+}
+
+// GetStrings returns the original and changed texts.
+func (td *TextDiffResult) GetStrings() (string, string) {
+	return td.string1, td.string2
+}
+
+// GetOptions returns the options the diff was computed with.
+func (td *TextDiffResult) GetOptions() DiffOptions {
+	return td.options
+}
+
+// GetEdits returns a copy of the edits in order.
+func (td *TextDiffResult) GetEdits() []DiffEdit {
+	edits := make([]DiffEdit, len(td.edits))
+	for i, e := range td.edits {
+		edits[i] = e
+		edits[i].Tokens = slices.Clone(e.Tokens)
+	}
+	return edits
+}
+
+// HasChanges reports whether the texts differ.
+func (td *TextDiffResult) HasChanges() bool {
+	for _, e := range td.edits {
+		if e.Type != EditEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats returns the number of inserted and deleted tokens.
+func (td *TextDiffResult) Stats() (int, int) {
+	insertions, deletions := 0, 0
+	for _, e := range td.edits {
+		switch e.Type {
+		case EditInsert:
+			insertions += len(e.Tokens)
+		case EditDelete:
+			deletions += len(e.Tokens)
+		default:
+		}
+	}
+	return insertions, deletions
+}
+
+// Patch groups the edits into hunks with up to context unchanged tokens around each change and returns them as a
+// Patch with the given labels. For word diffs each token becomes one line with its line breaks escaped.
+func (td *TextDiffResult) Patch(label1, label2 string, context int) *Patch {
+	return diffPatch(td.edits, td.options.Granularity, label1, label2, max(context, 0))
+}
+
+// Unified renders the diff in unified format with up to context unchanged lines around each change, as
+// diff -u does with its default of 3. Returns an empty string if the texts are equal.
+func (td *TextDiffResult) Unified(label1, label2 string, context int) string {
+	if !td.HasChanges() {
+		return ""
+	}
+	return td.Patch(label1, label2, context).String()
+}
+
+// Inline renders the changed text with deleted runs marked as [-del-] and inserted runs as {+ins+}.
+func (td *TextDiffResult) Inline() string {
+	var sb strings.Builder
+	for _, e := range td.edits {
+		text := strings.Join(e.Tokens, "")
+		switch e.Type {
+		case EditDelete:
+			sb.WriteString(inlineDeletion(text))
+		case EditInsert:
+			sb.WriteString(inlineInsertion(text))
+		default:
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// IsMatch compares two TextDiffResult objects for equality of texts, options and edits.
+func (td *TextDiffResult) IsMatch(other *TextDiffResult) bool {
+	if td == nil || other == nil {
+		return false
+	}
+	return td.string1 == other.string1 &&
+		td.string2 == other.string2 &&
+		td.options == other.options &&
+		slices.EqualFunc(td.edits, other.edits, func(e1, e2 DiffEdit) bool {
+			return e1.Type == e2.Type && e1.Index1 == e2.Index1 && e1.Index2 == e2.Index2 &&
+				slices.Equal(e1.Tokens, e2.Tokens)
+		})
+}
+
+// Print outputs the TextDiffResult to the console: verbose output is the unified diff with 3 context lines,
+// concise output summarizes the number of inserted and deleted tokens.
+func (td *TextDiffResult) Print(v bool) {
+	fmt.Print(formatTextDiffResultOutput(td, v))
+}
+
+// formatTextDiffResultOutput formats a TextDiffResult for display.
+func formatTextDiffResultOutput(td *TextDiffResult, v bool) string {
+	if td == nil {
+		return ""
+	}
+	insertions, deletions := td.Stats()
+	output := fmt.Sprintf("%s Diff (%s): %d insertions, %d deletions\n",
+		td.options.Algorithm, td.options.Granularity, insertions, deletions)
+	if v {
+		output += td.Unified("a", "b", 3)
+	}
+	return output
+}
@@ -0,0 +1,203 @@
+package strutil
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	errors2 "github.com/bmj2728/utils/pkg/internal/errors"
+)
+
+// lcsTokens returns the length of the longest common subsequence of a and b.
+func lcsTokens(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j-1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// randomLines builds a text of n lines drawn from a small alphabet so lines repeat.
+func randomLines(r *rand.Rand, n int) string {
+	var sb strings.Builder
+	for range n {
+		sb.WriteString(string(rune('a'+r.Intn(5))) + "\n")
+	}
+	return sb.String()
+}
+
+// rebuildDiff joins the tokens of every edit whose type is not skip.
+func rebuildDiff(edits []DiffEdit, skip EditOpType) string {
+	var sb strings.Builder
+	for _, e := range edits {
+		if e.Type != skip {
+			sb.WriteString(strings.Join(e.Tokens, ""))
+		}
+	}
+	return sb.String()
+}
+
+func TestDiffRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(35))
+	for _, algorithm := range []DiffAlgorithm{DiffMyers, DiffPatience, DiffHistogram} {
+		for _, granularity := range []DiffGranularity{DiffLines, DiffWords} {
+			opts := DiffOptions{Algorithm: algorithm, Granularity: granularity}
+			for range 200 {
+				s1, s2 := randomLines(r, r.Intn(30)), randomLines(r, r.Intn(30))
+				if granularity == DiffWords {
+					s1, s2 = strings.ReplaceAll(s1, "\n", " "), strings.ReplaceAll(s2, "\n", "  ")
+				}
+				result := Diff(s1, s2, opts)
+				if !textDiff(s1, s2, opts).IsMatch(result) {
+					t.Fatalf("Diff(%q, %q, %v) - helper and functional results differ", s1, s2, opts)
+				}
+				edits := result.GetEdits()
+				if rebuildDiff(edits, EditInsert) != s1 || rebuildDiff(edits, EditDelete) != s2 {
+					t.Fatalf("Diff(%q, %q, %v) does not rebuild both texts: %v", s1, s2, opts, edits)
+				}
+				if algorithm != DiffMyers {
+					continue
+				}
+				a, b := diffTokens(s1, granularity), diffTokens(s2, granularity)
+				insertions, deletions := result.Stats()
+				if want := len(a) + len(b) - 2*lcsTokens(a, b); insertions+deletions != want {
+					t.Fatalf("Diff(%q, %q, %v) has %d edits; want %d", s1, s2, opts, insertions+deletions, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDiffUnified(t *testing.T) {
+	s1 := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	s2 := "one\ntwo\nthree\nfour\nFIVE\nsix\nseven\neight\nnine\nten\neleven\n"
+	tests := []struct {
+		name     string
+		s1       string
+		s2       string
+		context  int
+		expected string
+	}{
+		{"UnifiedMerged", s1, s2, 3, "--- a\n+++ b\n@@ -2,9 +2,10 @@\n two\n three\n four\n-five\n+FIVE\n six\n" +
+			" seven\n eight\n nine\n ten\n+eleven\n"},
+		{"UnifiedSplit", s1, s2, 1, "--- a\n+++ b\n@@ -4,3 +4,3 @@\n four\n-five\n+FIVE\n six\n" +
+			"@@ -10 +10,2 @@\n ten\n+eleven\n"},
+		{"UnifiedNoContext", "a\nb\n", "b\n", 0, "--- a\n+++ b\n@@ -1 +0,0 @@\n-a\n"},
+		{"UnifiedInsertEmpty", "", "x\n", 3, "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n"},
+		{"UnifiedNoNewline", "a\nb", "a\nc", 3, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n" +
+			"\\ No newline at end of file\n+c\n\\ No newline at end of file\n"},
+		{"UnifiedEqual", s1, s1, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Diff(tt.s1, tt.s2, NewDiffOptions())
+			unified := result.Unified("a", "b", tt.context)
+			if unified != tt.expected {
+				t.Fatalf("Unified() = %q; want %q", unified, tt.expected)
+			}
+			if unified == "" {
+				return
+			}
+			patched, err := ApplyPatch(tt.s1, unified)
+			if err != nil || patched != tt.s2 {
+				t.Errorf("ApplyPatch(%q) = %q, %v; want %q", tt.s1, patched, err, tt.s2)
+			}
+			p, err := ParseUnifiedDiff(unified)
+			if err != nil || !p.IsMatch(result.Patch("a", "b", tt.context)) || p.String() != unified {
+				t.Errorf("ParseUnifiedDiff(%q) = %v, %v; want the generated patch", unified, p, err)
+			}
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	diff := "diff -u old new\n--- old\t2024-01-01\n+++ new\t2024-01-02\n@@ -2,3 +2,3 @@\n b\n-c\n+C\n d\n"
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+		err      error
+	}{
+		{"ApplyExact", "a\nb\nc\nd\ne\n", diff, "a\nb\nC\nd\ne\n", nil},
+		{"ApplyShifted", "x\ny\na\nb\nc\nd\ne\n", diff, "x\ny\na\nb\nC\nd\ne\n", nil},
+		{"ApplyConflict", "a\nb\nX\nd\n", diff, "", errors2.ErrPatchConflict},
+		{"ApplyBadHeader", "a\n", "--- a\n+++ b\n@@ -x +1 @@\n", "", errors2.ErrInvalidPatch},
+		{"ApplyShortHunk", "a\n", "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n", "", errors2.ErrInvalidPatch},
+		{"ApplyBadLine", "a\n", "--- a\n+++ b\n@@ -1 +1 @@\n*a\n", "", errors2.ErrInvalidPatch},
+		{"ApplyGarbage", "a\n", "not a diff\n", "", errors2.ErrInvalidPatch},
+		{"ApplyEmpty", "a\n", "", "a\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyPatch(tt.original, tt.patch)
+			if !errors.Is(err, tt.err) || result != tt.expected {
+				t.Errorf("ApplyPatch(%q, %q) = %q, %v; want %q, %v",
+					tt.original, tt.patch, result, err, tt.expected, tt.err)
+			}
+		})
+	}
+}
+
+func TestDiffPatienceAnchors(t *testing.T) {
+	s1 := "func a() {\n\treturn 1\n}\n\nfunc b() {\n\treturn 2\n}\n"
+	s2 := "func b() {\n\treturn 2\n}\n\nfunc a() {\n\treturn 1\n}\n"
+	for _, algorithm := range []DiffAlgorithm{DiffPatience, DiffHistogram} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			edits := Diff(s1, s2, DiffOptions{Algorithm: algorithm}).GetEdits()
+			if len(edits) < 2 || edits[1].Type != EditEqual ||
+				strings.Join(edits[1].Tokens, "") != "func b() {\n\treturn 2\n" {
+				t.Errorf("Diff(%v) did not anchor on the moved function: %v", algorithm, edits)
+			}
+		})
+	}
+}
+
+func TestLongestIncreasingPairs(t *testing.T) {
+	pairs := [][2]int{{0, 3}, {1, 0}, {2, 4}, {3, 1}, {4, 2}, {5, 5}}
+	expected := [][2]int{{1, 0}, {3, 1}, {4, 2}, {5, 5}}
+	if result := longestIncreasingPairs(pairs); !slices.Equal(result, expected) {
+		t.Errorf("longestIncreasingPairs(%v) = %v; want %v", pairs, result, expected)
+	}
+	if result := longestIncreasingPairs(nil); result != nil {
+		t.Errorf("longestIncreasingPairs(nil) = %v; want nil", result)
+	}
+}
+
+func TestDiffWordsInline(t *testing.T) {
+	opts := DiffOptions{Algorithm: DiffMyers, Granularity: DiffWords}
+	result := Diff("the quick brown fox", "the slow brown fox jumps", opts)
+	if inline := result.Inline(); inline != "the [-quick-]{+slow+} brown fox{+ jumps+}" {
+		t.Errorf("Inline() = %q", inline)
+	}
+	if insertions, deletions := result.Stats(); insertions != 3 || deletions != 1 {
+		t.Errorf("Stats() = %d, %d; want 3, 1", insertions, deletions)
+	}
+	unified := result.Unified("a", "b", 0)
+	if unified != "--- a\n+++ b\n@@ -3 +3 @@\n-quick\n+slow\n@@ -7,0 +8,2 @@\n+ \n+jumps\n" {
+		t.Errorf("Unified() = %q", unified)
+	}
+}
+
+func TestHistoryDiff(t *testing.T) {
+	history := New("Hello World").WithHistory(10).ToUpper().GetHistory()
+	result, err := history.Diff(0, 1, DiffOptions{Granularity: DiffWords})
+	if err != nil {
+		t.Fatalf("Diff(0, 1) returned error %v", err)
+	}
+	if inline := result.Inline(); inline != "[-Hello-]{+HELLO+} [-World-]{+WORLD+}" {
+		t.Errorf("Diff(0, 1).Inline() = %q", inline)
+	}
+	if _, err := history.Diff(0, 5, NewDiffOptions()); !errors.Is(err, errors2.ErrInvalidHistoryIndex) {
+		t.Errorf("Diff(0, 5) error = %v; want %v", err, errors2.ErrInvalidHistoryIndex)
+	}
+}
@@ -0,0 +1,54 @@
+package strutil
+
+// TokenizeOptions controls how strings are prepared and split into word tokens by the token ratio scorers.
+// Whitespace always separates tokens; runs of whitespace are collapsed.
+type TokenizeOptions struct {
+	Lowercase           bool   // lower-case the input before splitting
+	NormalizeDiacritics bool   // strip diacritical marks before splitting
+	StripPunctuation    bool   // treat every non-alphanumeric rune as a separator
+	KeepChars           string // runes kept as part of tokens when StripPunctuation is set, e.g. "&-"
+}
+
+// NewTokenizeOptions returns TokenizeOptions matching the default processing of fuzzywuzzy:
+// the input is lower-cased and punctuation is treated as a separator.
+func NewTokenizeOptions() TokenizeOptions {
+	return TokenizeOptions{
+		Lowercase:        true,
+		StripPunctuation: true,
+	}
+}
+
+// Tokenize prepares s as configured by opts and splits it into word tokens.
+func Tokenize(s string, opts TokenizeOptions) []string {
+	return tokenize(s, opts)
+}
+
+// Ratio computes the normalized Indel similarity (2 * matches / total length) between the processed strings.
+// Scores range from 0 to 1; identical processed strings, including two empty strings, score 1.
+func Ratio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	return ratio(s1, s2, opts)
+}
+
+// PartialRatio computes the best Ratio between the shorter processed string and every substring
+// of the longer processed string with the same length, so "Yankees" scores 1 against "New York Yankees".
+func PartialRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	return partialRatio(s1, s2, opts)
+}
+
+// TokenSortRatio computes the Ratio between the strings after sorting their tokens alphabetically,
+// so word order is ignored.
+func TokenSortRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	return tokenSortRatio(s1, s2, opts)
+}
+
+// TokenSetRatio compares the shared tokens of both strings with each string's shared and remaining tokens
+// and returns the best Ratio, so duplicated and extra words in one string are largely ignored.
+func TokenSetRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	return tokenSetRatio(s1, s2, opts)
+}
+
+// WeightedRatio combines Ratio, PartialRatio, TokenSortRatio and TokenSetRatio, weighting each by the
+// difference in length between the strings, and returns the best weighted score.
+func WeightedRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	return weightedRatio(s1, s2, opts)
+}
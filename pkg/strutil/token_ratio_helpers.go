@@ -0,0 +1,173 @@
+package strutil
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hbollon/go-edlib"
+)
+
+// tokenScorer scores two processed strings between 0 and 1.
+type tokenScorer func(s1, s2 string) float32
+
+// tokenRatioAlgorithms maps each token ratio Algorithm to its scoring function, used by similarity.
+var tokenRatioAlgorithms = map[Algorithm]func(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat{
+	FuzzyRatio:          ratio,
+	FuzzyPartialRatio:   partialRatio,
+	FuzzyTokenSortRatio: tokenSortRatio,
+	FuzzyTokenSetRatio:  tokenSetRatio,
+	FuzzyWeightedRatio:  weightedRatio,
+}
+
+// tokenize prepares s as configured by opts and splits it on whitespace.
+func tokenize(s string, opts TokenizeOptions) []string {
+	if opts.NormalizeDiacritics {
+		s = normalizeDiacritics(s)
+	}
+	if opts.Lowercase {
+		s = toLower(s)
+	}
+	if opts.StripPunctuation {
+		s = replaceNonAlphaNumericWithIgnore(s, " ", opts.KeepChars)
+	}
+	s = collapseWhitespace(normalizeWhitespace(s, ' '))
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, " ")
+}
+
+// tokenProcess prepares s as configured by opts and rejoins its tokens with single spaces.
+func tokenProcess(s string, opts TokenizeOptions) string {
+	return strings.Join(tokenize(s, opts), " ")
+}
+
+// indelRatio returns 2 * LCS / (len(s1) + len(s2)) over runes; two empty strings score 1.
+func indelRatio(s1, s2 string) float32 {
+	total := len([]rune(s1)) + len([]rune(s2))
+	if total == 0 {
+		return 1
+	}
+	return float32(2*edlib.LCS(s1, s2)) / float32(total)
+}
+
+// partialIndelRatio returns the best indelRatio between the shorter string and each equally long
+// window of the longer string.
+func partialIndelRatio(s1, s2 string) float32 {
+	short, long := []rune(s1), []rune(s2)
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	if len(short) == 0 {
+		if len(long) == 0 {
+			return 1
+		}
+		return 0
+	}
+	var best float32
+	for i := 0; i+len(short) <= len(long) && best < 1; i++ {
+		best = max(best, indelRatio(string(short), string(long[i:i+len(short)])))
+	}
+	return best
+}
+
+// tokenSortScore sorts the tokens of both strings and scores the rejoined strings with scorer.
+func tokenSortScore(t1, t2 []string, scorer tokenScorer) float32 {
+	sorted1 := append([]string(nil), t1...)
+	sorted2 := append([]string(nil), t2...)
+	sort.Strings(sorted1)
+	sort.Strings(sorted2)
+	return scorer(strings.Join(sorted1, " "), strings.Join(sorted2, " "))
+}
+
+// tokenSetScore scores the sorted intersection of the token sets against the intersection extended with
+// each string's remaining tokens, and the two extended strings against each other, returning the best score.
+func tokenSetScore(t1, t2 []string, scorer tokenScorer) float32 {
+	set1, set2 := tokenSet(t1), tokenSet(t2)
+	var common, diff1, diff2 []string
+	for t := range set1 {
+		if set2[t] {
+			common = append(common, t)
+		} else {
+			diff1 = append(diff1, t)
+		}
+	}
+	for t := range set2 {
+		if !set1[t] {
+			diff2 = append(diff2, t)
+		}
+	}
+	sort.Strings(common)
+	sort.Strings(diff1)
+	sort.Strings(diff2)
+	base := strings.Join(common, " ")
+	combined1 := strings.TrimSpace(base + " " + strings.Join(diff1, " "))
+	combined2 := strings.TrimSpace(base + " " + strings.Join(diff2, " "))
+	best := scorer(combined1, combined2)
+	if base != "" {
+		best = max(best, scorer(base, combined1), scorer(base, combined2))
+	}
+	return best
+}
+
+// tokenSet returns the unique tokens of t.
+func tokenSet(t []string) map[string]bool {
+	set := make(map[string]bool, len(t))
+	for _, token := range t {
+		set[token] = true
+	}
+	return set
+}
+
+// ratio processes both strings and scores them with indelRatio.
+func ratio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	r := indelRatio(tokenProcess(s1, opts), tokenProcess(s2, opts))
+	return NewComparisonResultFloat(RatioSim, s1, s2, nil, &r, nil)
+}
+
+// partialRatio processes both strings and scores them with partialIndelRatio.
+func partialRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	r := partialIndelRatio(tokenProcess(s1, opts), tokenProcess(s2, opts))
+	return NewComparisonResultFloat(PartialRatioSim, s1, s2, nil, &r, nil)
+}
+
+// tokenSortRatio tokenizes both strings and scores their sorted tokens with indelRatio.
+func tokenSortRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	r := tokenSortScore(tokenize(s1, opts), tokenize(s2, opts), indelRatio)
+	return NewComparisonResultFloat(TokenSortRatioSim, s1, s2, nil, &r, nil)
+}
+
+// tokenSetRatio tokenizes both strings and scores their token sets with indelRatio.
+func tokenSetRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	r := tokenSetScore(tokenize(s1, opts), tokenize(s2, opts), indelRatio)
+	return NewComparisonResultFloat(TokenSetRatioSim, s1, s2, nil, &r, nil)
+}
+
+// weightedRatio follows fuzzywuzzy's WRatio: token scores are scaled by 0.95, and when one string is at
+// least 1.5 times longer than the other the partial scorers are used instead, scaled by 0.9
+// (or 0.6 when it is more than 8 times longer). Identical processed strings score 1 and an empty
+// processed string scores 0 against a non-empty one.
+func weightedRatio(s1, s2 string, opts TokenizeOptions) *ComparisonResultFloat {
+	t1, t2 := tokenize(s1, opts), tokenize(s2, opts)
+	p1, p2 := strings.Join(t1, " "), strings.Join(t2, " ")
+	var r float32
+	if p1 == p2 {
+		r = 1
+	} else if p1 != "" && p2 != "" {
+		l1, l2 := float32(len([]rune(p1))), float32(len([]rune(p2)))
+		lengthRatio := max(l1, l2) / min(l1, l2)
+		r = indelRatio(p1, p2)
+		if lengthRatio < 1.5 {
+			r = max(r, tokenSortScore(t1, t2, indelRatio)*0.95, tokenSetScore(t1, t2, indelRatio)*0.95)
+		} else {
+			scale := float32(0.9)
+			if lengthRatio > 8 {
+				scale = 0.6
+			}
+			r = max(r, partialIndelRatio(p1, p2)*scale,
+				tokenSortScore(t1, t2, partialIndelRatio)*0.95*scale,
+				tokenSetScore(t1, t2, partialIndelRatio)*0.95*scale)
+		}
+	}
+	return NewComparisonResultFloat(WeightedRatioSim, s1, s2, nil, &r, nil)
+}
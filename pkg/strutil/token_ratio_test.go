@@ -0,0 +1,109 @@
+package strutil
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bmj2728/utils/pkg/internal/types"
+)
+
+func TestTokenize(t *testing.T) {
+	keep := NewTokenizeOptions()
+	keep.KeepChars = "&"
+	tests := []struct {
+		name     string
+		input    string
+		opts     TokenizeOptions
+		expected []string
+	}{
+		{"TokenizeDefault", "  Hello,   World! ", NewTokenizeOptions(), []string{"hello", "world"}},
+		{"TokenizeRaw", "Hello,  World!", TokenizeOptions{}, []string{"Hello,", "World!"}},
+		{"TokenizeDiacritics", "Café Crème", TokenizeOptions{NormalizeDiacritics: true}, []string{"Cafe", "Creme"}},
+		{"TokenizeKeepChars", "AT&T Inc.", keep, []string{"at&t", "inc"}},
+		{"TokenizeEmpty", " ... ", NewTokenizeOptions(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := tokenize(tt.input, tt.opts)
+			result := Tokenize(tt.input, tt.opts)
+			if !reflect.DeepEqual(helperResult, tt.expected) || !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Tokenize(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTokenRatios(t *testing.T) {
+	type scorer struct {
+		helper     func(string, string, TokenizeOptions) *ComparisonResultFloat
+		public     func(string, string, TokenizeOptions) *ComparisonResultFloat
+		builder    func(*StringBuilder, string, TokenizeOptions) *StringBuilder
+		algorithm  Algorithm
+		resultType ComparisonResultType
+	}
+	scorers := map[string]scorer{
+		"Ratio": {ratio, Ratio, (*StringBuilder).Ratio, FuzzyRatio, RatioSim},
+		"PartialRatio": {
+			partialRatio, PartialRatio, (*StringBuilder).PartialRatio,
+			FuzzyPartialRatio, PartialRatioSim,
+		},
+		"TokenSortRatio": {
+			tokenSortRatio, TokenSortRatio, (*StringBuilder).TokenSortRatio,
+			FuzzyTokenSortRatio, TokenSortRatioSim,
+		},
+		"TokenSetRatio": {
+			tokenSetRatio, TokenSetRatio, (*StringBuilder).TokenSetRatio,
+			FuzzyTokenSetRatio, TokenSetRatioSim,
+		},
+		"WeightedRatio": {weightedRatio, WeightedRatio, (*StringBuilder).WeightedRatio, FuzzyWeightedRatio, WeightedRatioSim},
+	}
+	tests := []struct {
+		name     string
+		scorer   string
+		s1       string
+		s2       string
+		expected float32
+	}{
+		{"RatioPunctuation", "Ratio", "this is a test", "this is a test!", 1},
+		{"RatioTypo", "Ratio", "New York Mets", "New York Meats", 0.963},
+		{"RatioEmpty", "Ratio", "", "", 1},
+		{"RatioOneEmpty", "Ratio", "abc", "", 0},
+		{"PartialRatioSubstring", "PartialRatio", "Yankees", "New York Yankees", 1},
+		{"PartialRatioTypo", "PartialRatio", "New York Mets", "New York Meats", 0.923},
+		{"TokenSortRatioOrder", "TokenSortRatio", "fuzzy wuzzy was a bear", "wuzzy fuzzy was a bear", 1},
+		{"TokenSortRatioDuplicate", "TokenSortRatio", "fuzzy was a bear", "fuzzy fuzzy was a bear", 0.842},
+		{"TokenSetRatioDuplicate", "TokenSetRatio", "fuzzy was a bear", "fuzzy fuzzy was a bear", 1},
+		{"TokenSetRatioSubset", "TokenSetRatio", "mariners vs angels",
+			"los angeles angels of anaheim at seattle mariners", 0.909},
+		{"TokenSetRatioDisjoint", "TokenSetRatio", "abc", "xyz", 0},
+		{"WeightedRatioOrder", "WeightedRatio", "fuzzy wuzzy was a bear", "wuzzy fuzzy was a bear", 0.95},
+		{"WeightedRatioPartial", "WeightedRatio", "Yankees", "New York Yankees", 0.9},
+		{"WeightedRatioLongPartial", "WeightedRatio", "Acme", "Acme Corporation International Holdings", 0.6},
+		{"WeightedRatioIdentical", "WeightedRatio", "", "...", 1},
+		{"WeightedRatioOneEmpty", "WeightedRatio", "abc", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := scorers[tt.scorer]
+			opts := NewTokenizeOptions()
+			helperResult := sc.helper(tt.s1, tt.s2, opts)
+			result := sc.public(tt.s1, tt.s2, opts)
+			if !helperResult.IsMatch(result) || result.GetType() != sc.resultType {
+				t.Fatalf("%s(%q, %q) - helper and functional results differ", tt.scorer, tt.s1, tt.s2)
+			}
+			score, err := result.GetScoreFloat()
+			if err != nil || math.Abs(float64(score-tt.expected)) > 0.001 {
+				t.Errorf("%s(%q, %q) = %f (%v); want %f", tt.scorer, tt.s1, tt.s2, score, err, tt.expected)
+			}
+			sim, err := Similarity(tt.s1, tt.s2, sc.algorithm).GetScore()
+			if err != nil || math.Abs(float64(sim-score)) > types.Float64EqualityThreshold {
+				t.Errorf("Similarity(%q, %q, %s) = %f (%v); want %f", tt.s1, tt.s2, sc.algorithm, sim, err, score)
+			}
+			stored := sc.builder(New(tt.s1), tt.s2, opts).GetComparisonManager().GetComparisonResult(sc.resultType, tt.s2)
+			if !result.IsMatch(CastComparisonResult(&stored)) {
+				t.Errorf("StringBuilder.%s(%q, %q) - result not stored in ComparisonManager", tt.scorer, tt.s1, tt.s2)
+			}
+		})
+	}
+}
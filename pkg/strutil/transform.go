@@ -47,6 +47,48 @@ func Slugify(s string, length int) string {
 	return slugify(s, length)
 }
 
+// FilenameOptions configures SanitizeFilename.
+type FilenameOptions struct {
+	// Replacement is written in place of each run of separators, control and reserved characters.
+	Replacement string
+	// MaxBytes limits the length of the name in bytes, keeping its extension. Zero or less means no limit.
+	MaxBytes int
+	// Transliterate replaces accented letters with their ASCII counterparts using NormalizeDiacritics.
+	Transliterate bool
+	// Fallback is returned when nothing usable is left of the name.
+	Fallback string
+}
+
+// NewFilenameOptions returns FilenameOptions that replace invalid characters with "_", limit names to the 255
+// bytes most file systems allow, keep non-ASCII letters and fall back to "untitled".
+func NewFilenameOptions() FilenameOptions {
+	return FilenameOptions{
+		Replacement: "_",
+		MaxBytes:    255,
+		Fallback:    "untitled",
+	}
+}
+
+// SanitizeFilename makes s safe to use as a file name on Windows and Unix systems. Path separators, control and
+// reserved characters are replaced, trailing dots and spaces are trimmed, Windows device names such as CON or
+// COM1 are prefixed and the name is truncated to opts.MaxBytes on a UTF-8 boundary, preserving its extension.
+func SanitizeFilename(s string, opts FilenameOptions) string {
+	return sanitizeFilename(s, opts)
+}
+
+// SanitizePathComponent makes s safe to use as a single directory or file name within a path, using the
+// defaults of NewFilenameOptions without treating any part of s as an extension.
+func SanitizePathComponent(s string) string {
+	return sanitizePathComponent(s)
+}
+
+// SafeJoin joins the untrusted relative path userPath to base. Both slashes and backslashes are treated as
+// separators, and paths that are empty, absolute, start with a drive letter, contain a NUL byte or escape base
+// return ErrUnsafePath.
+func SafeJoin(base, userPath string) (string, error) {
+	return safeJoin(base, userPath)
+}
+
 // Truncate shortens the input string s to the specified length and appends the given suffix if truncation occurs.
 func Truncate(s string, length int, suffix string) string {
 	return truncate(s, length, suffix)
@@ -93,6 +93,38 @@ func (sb *StringBuilder) Slugify(length int) *StringBuilder {
 	return sb
 }
 
+// SanitizeFilename makes the string safe to use as a file name using the provided options.
+func (sb *StringBuilder) SanitizeFilename(opts FilenameOptions) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizeFilename(sb.value, opts))
+	return sb
+}
+
+// SanitizePathComponent makes the string safe to use as a single directory or file name within a path.
+func (sb *StringBuilder) SanitizePathComponent() *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	sb.setValue(sanitizePathComponent(sb.value))
+	return sb
+}
+
+// SafeJoin joins the string, treated as an untrusted relative path, to base. If the path is unsafe a fatal
+// ErrUnsafePath is recorded so the untrusted value cannot be used further.
+func (sb *StringBuilder) SafeJoin(base string) *StringBuilder {
+	if !sb.shouldContinueProcessing() {
+		return sb
+	}
+	joined, err := safeJoin(base, sb.value)
+	if err != nil {
+		return sb.setError(err, true)
+	}
+	sb.setValue(joined)
+	return sb
+}
+
 // Truncate shortens the string to the specified length and appends the provided suffix if truncation occurs.
 func (sb *StringBuilder) Truncate(length int, suffix string) *StringBuilder {
 	if !sb.shouldContinueProcessing() {
@@ -2,8 +2,12 @@ package strutil
 
 import (
 	"math"
+	"path/filepath"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/bmj2728/utils/pkg/internal/errors"
 
 	godiacritics "github.com/Regis24GmbH/go-diacritics"
 	"golang.org/x/text/unicode/norm"
@@ -107,6 +111,123 @@ func slugify(s string, length int) string {
 	return s
 }
 
+// filenameReservedChars are the characters that cannot appear in file names on Windows or Unix systems.
+const filenameReservedChars = `/\<>:"|?*`
+
+// windowsReservedNames are the device names Windows reserves with or without an extension.
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true, "CONIN$": true, "CONOUT$": true,
+	"COM0": true, "COM1": true, "COM2": true, "COM3": true, "COM4": true,
+	"COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"COM¹": true, "COM²": true, "COM³": true,
+	"LPT0": true, "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true,
+	"LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+	"LPT¹": true, "LPT²": true, "LPT³": true,
+}
+
+// sanitizeFilename makes s safe to use as a file name, preserving its extension when truncating.
+func sanitizeFilename(s string, opts FilenameOptions) string {
+	return cleanFilename(s, opts, true)
+}
+
+// sanitizePathComponent makes s safe to use as a single element of a path using the default filename options.
+func sanitizePathComponent(s string) string {
+	return cleanFilename(s, NewFilenameOptions(), false)
+}
+
+// cleanFilename replaces invalid characters in s, trims trailing dots and spaces, avoids Windows device names
+// and truncates the result to opts.MaxBytes. If keepExtension is set the text after the last dot is kept when
+// truncating. If nothing is left the sanitized opts.Fallback is returned.
+func cleanFilename(s string, opts FilenameOptions, keepExtension bool) string {
+	if opts.Transliterate {
+		s = normalizeDiacritics(s)
+	}
+	replacement := strings.Map(func(r rune) rune {
+		if isInvalidFilenameRune(r) {
+			return -1
+		}
+		return r
+	}, opts.Replacement)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	replaced := false
+	for _, r := range s {
+		if isInvalidFilenameRune(r) {
+			// write a single replacement for each run of invalid characters
+			if !replaced {
+				b.WriteString(replacement)
+			}
+			replaced = true
+			continue
+		}
+		replaced = false
+		b.WriteRune(r)
+	}
+
+	name := strings.TrimRight(strings.TrimSpace(b.String()), ". ")
+	name = truncateFilename(name, opts.MaxBytes, keepExtension)
+	if isWindowsReservedName(name) {
+		prefix := replacement
+		if prefix == "" {
+			prefix = "_"
+		}
+		name = truncateFilename(prefix+name, opts.MaxBytes, keepExtension)
+	}
+
+	if name == "" && opts.Fallback != "" {
+		fallback := opts.Fallback
+		opts.Fallback = ""
+		return cleanFilename(fallback, opts, keepExtension)
+	}
+	return name
+}
+
+// isInvalidFilenameRune reports whether r is a path separator, a reserved or control character, or an invalid
+// UTF-8 sequence.
+func isInvalidFilenameRune(r rune) bool {
+	return r == utf8.RuneError || unicode.IsControl(r) || strings.ContainsRune(filenameReservedChars, r)
+}
+
+// isWindowsReservedName reports whether the part of name before its first dot is a Windows device name.
+func isWindowsReservedName(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	return windowsReservedNames[strings.ToUpper(strings.TrimRight(stem, " "))]
+}
+
+// truncateFilename shortens name to at most maxBytes bytes without splitting a UTF-8 sequence. If keepExtension
+// is set and the extension leaves room for part of the stem, the stem is shortened instead of the extension.
+func truncateFilename(name string, maxBytes int, keepExtension bool) string {
+	if maxBytes <= 0 || len(name) <= maxBytes {
+		return name
+	}
+	ext := ""
+	if i := strings.LastIndexByte(name, '.'); keepExtension && i > 0 && len(name)-i < maxBytes {
+		ext = name[i:]
+	}
+	stem := name[:len(name)-len(ext)]
+	n := maxBytes - len(ext)
+	for n > 0 && !utf8.RuneStart(stem[n]) {
+		n--
+	}
+	return strings.TrimRight(stem[:n], ". ") + ext
+}
+
+// safeJoin joins the untrusted relative path userPath to base, returning ErrUnsafePath if it is empty, absolute,
+// starts with a drive letter, contains a NUL byte or escapes base.
+func safeJoin(base, userPath string) (string, error) {
+	p := strings.ReplaceAll(userPath, `\`, "/")
+	if strings.ContainsRune(p, 0) || hasDriveLetter(p) || !filepath.IsLocal(filepath.FromSlash(p)) {
+		return "", errors.ErrUnsafePath
+	}
+	return filepath.Join(base, filepath.FromSlash(p)), nil
+}
+
+// hasDriveLetter reports whether p starts with a Windows drive letter such as "C:".
+func hasDriveLetter(p string) bool {
+	return len(p) >= 2 && p[1] == ':' && 'a' <= p[0]|0x20 && p[0]|0x20 <= 'z'
+}
+
 // normalizeDiacritics removes diacritical marks (accents) from the input string, returning the normalized version.
 func normalizeDiacritics(s string) string {
 	return godiacritics.Normalize(s)
@@ -163,6 +163,117 @@ func TestSlugify(t *testing.T) {
 	}
 }
 
+func TestSanitizeFilename(t *testing.T) {
+	short := NewFilenameOptions()
+	short.MaxBytes = 10
+	ascii := NewFilenameOptions()
+	ascii.Transliterate = true
+	dashes := NewFilenameOptions()
+	dashes.Replacement = "-/"
+	empty := NewFilenameOptions()
+	empty.Replacement = ""
+	empty.Fallback = ""
+	tests := []struct {
+		name     string
+		input    string
+		opts     FilenameOptions
+		expected string
+	}{
+		{"Plain", "report.pdf", NewFilenameOptions(), "report.pdf"},
+		{"Separators", "../../etc/passwd", NewFilenameOptions(), ".._.._etc_passwd"},
+		{"Reserved", `a<b>c:"d"|e?f*.txt`, NewFilenameOptions(), "a_b_c_d_e_f_.txt"},
+		{"Control", "a\x00b\tc\x7f.txt", NewFilenameOptions(), "a_b_c_.txt"},
+		{"InvalidUTF8", "a\xffb.txt", NewFilenameOptions(), "a_b.txt"},
+		{"TrailingDotsAndSpaces", "  notes . .. ", NewFilenameOptions(), "notes"},
+		{"HiddenFile", ".gitignore", NewFilenameOptions(), ".gitignore"},
+		{"WindowsReserved", "con", NewFilenameOptions(), "_con"},
+		{"WindowsReservedExtension", "LPT1.tar.gz", NewFilenameOptions(), "_LPT1.tar.gz"},
+		{"WindowsReservedSpace", "NUL .txt", NewFilenameOptions(), "_NUL .txt"},
+		{"NotReserved", "console.log", NewFilenameOptions(), "console.log"},
+		{"TruncateKeepsExtension", "averylongname.txt", short, "averyl.txt"},
+		{"TruncateUTF8", "ééééé.md", short, "ééé.md"},
+		{"TruncateLongExtension", "a.verylongextension", short, "a.verylong"},
+		{"Transliterate", "Café Résumé.txt", ascii, "Cafe Resume.txt"},
+		{"KeepsUnicode", "Café Résumé.txt", NewFilenameOptions(), "Café Résumé.txt"},
+		{"ReplacementSanitized", "a/b", dashes, "a-b"},
+		{"NoReplacement", "a/b", empty, "ab"},
+		{"Fallback", "...", NewFilenameOptions(), "untitled"},
+		{"FallbackDotDot", "..", NewFilenameOptions(), "untitled"},
+		{"NoFallback", "", empty, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizeFilename(tt.input, tt.opts)
+			result := SanitizeFilename(tt.input, tt.opts)
+			builderResult := New(tt.input).SanitizeFilename(tt.opts).String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizeFilename - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestSanitizePathComponent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain", "photos", "photos"},
+		{"Separators", `a/b\c`, "a_b_c"},
+		{"Traversal", "..", "untitled"},
+		{"Reserved", "aux", "_aux"},
+		{"Empty", "", "untitled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult := sanitizePathComponent(tt.input)
+			result := SanitizePathComponent(tt.input)
+			builderResult := New(tt.input).SanitizePathComponent().String()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SanitizePathComponent - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+		})
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		input    string
+		expected string
+		err      error
+	}{
+		{"Relative", "/srv/files", "a/b.txt", "/srv/files/a/b.txt", nil},
+		{"Backslashes", "/srv/files", `a\b.txt`, "/srv/files/a/b.txt", nil},
+		{"InnerDotDot", "/srv/files", "a/../b.txt", "/srv/files/b.txt", nil},
+		{"Traversal", "/srv/files", "../secret", "", errors.ErrUnsafePath},
+		{"HiddenTraversal", "/srv/files", "a/../../secret", "", errors.ErrUnsafePath},
+		{"BackslashTraversal", "/srv/files", `..\secret`, "", errors.ErrUnsafePath},
+		{"Absolute", "/srv/files", "/etc/passwd", "", errors.ErrUnsafePath},
+		{"DriveLetter", "/srv/files", "C:/Windows", "", errors.ErrUnsafePath},
+		{"NulByte", "/srv/files", "a\x00.txt", "", errors.ErrUnsafePath},
+		{"Empty", "/srv/files", "", "", errors.ErrUnsafePath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helperResult, helperErr := safeJoin(tt.base, tt.input)
+			result, err := SafeJoin(tt.base, tt.input)
+			builderResult, builderErr := New(tt.input).SafeJoin(tt.base).Result()
+			if helperResult != tt.expected || result != tt.expected || builderResult != tt.expected {
+				t.Errorf("SafeJoin - expected %q - got %q / %q / %q",
+					tt.expected, helperResult, result, builderResult)
+			}
+			if helperErr != tt.err || err != tt.err || builderErr != tt.err {
+				t.Errorf("SafeJoin - expected error %v - got %v / %v / %v", tt.err, helperErr, err, builderErr)
+			}
+		})
+	}
+}
+
 func TestNormalizeDiacritics(t *testing.T) {
 	test := []struct {
 		name     string
@@ -103,3 +103,117 @@ func HasPrefix(s string, prefix string) bool {
 func HasSuffix(s string, suffix string) bool {
 	return hasSuffix(s, suffix)
 }
+
+// ValidateEmail checks s like IsEmail, returning nil if it is valid and otherwise a *ValidationError explaining
+// why, such as a missing @ sign or an invalid character.
+func ValidateEmail(s string) error {
+	return validateEmail(s)
+}
+
+// ValidateURL checks s like IsURL, returning nil if it is valid and otherwise a *ValidationError explaining why,
+// such as a missing scheme or host.
+func ValidateURL(s string) error {
+	return validateURL(s)
+}
+
+// ValidateDomain checks domain like IsDomain, returning nil if it is valid and otherwise a *ValidationError
+// explaining why, such as an invalid character or a missing top-level domain.
+func ValidateDomain(domain string) error {
+	return validateDomain(domain)
+}
+
+// ValidateUUID checks s like IsUUID, returning nil if it is valid and otherwise a *ValidationError.
+func ValidateUUID(s string) error {
+	return validateUUID(s)
+}
+
+// ValidateLength checks s like IsLengthInRange, returning nil if its length in bytes is within [min, max] and
+// otherwise a *ValidationError with the reason ReasonTooShort, ReasonTooLong or, if the range is invalid,
+// ReasonInvalidArgument.
+func ValidateLength(s string, min, max int) error {
+	return validateLength(s, min, max)
+}
+
+// ValidateNotEmpty returns a *ValidationError if s is empty, otherwise nil.
+func ValidateNotEmpty(s string) error {
+	return validateNotEmpty(s)
+}
+
+// ValidateNotEmptyNormalized returns a *ValidationError if s is empty or contains only whitespace, otherwise nil.
+func ValidateNotEmptyNormalized(s string) error {
+	return validateNotEmptyNormalized(s)
+}
+
+// ValidateAlphaNumeric checks s like IsAlphaNumeric, returning nil if it is valid and otherwise a
+// *ValidationError with the first character that is not a letter or digit.
+func ValidateAlphaNumeric(s string) error {
+	return validateAlphaNumeric(s)
+}
+
+// ValidateAlpha checks s like IsAlpha, returning nil if it is valid and otherwise a *ValidationError with the
+// first character that is not a letter.
+func ValidateAlpha(s string) error {
+	return validateAlpha(s)
+}
+
+// ValidateNumeric checks s like IsNumeric, returning nil if it is valid and otherwise a *ValidationError with the
+// first character that is not numeric.
+func ValidateNumeric(s string, strict bool) error {
+	return validateNumeric(s, strict)
+}
+
+// ValidateNormalizedUnicode checks s like IsNormalizedUnicode, returning nil if it is normalized and otherwise a
+// *ValidationError with the position from which it is not.
+func ValidateNormalizedUnicode(s string, format NormalizationFormat) error {
+	return validateNormalizedUnicode(s, format)
+}
+
+// ValidateContains checks s like Contains, returning nil if it contains substr and otherwise a *ValidationError
+// whose expected parameter is the quoted substring.
+func ValidateContains(s string, substr string) error {
+	return validateContains(s, []string{substr}, false, contains)
+}
+
+// ValidateContainsIgnoreCase checks s like ContainsIgnoreCase, returning nil if it contains substr in any case and
+// otherwise a *ValidationError.
+func ValidateContainsIgnoreCase(s string, substr string) error {
+	return validateContains(s, []string{substr}, false, containsIgnoreCase)
+}
+
+// ValidateContainsAny checks s like ContainsAny, returning nil if it contains one of substrs and otherwise a
+// *ValidationError whose expected parameter lists the substrings joined by "or". An empty substrs fails with
+// ReasonInvalidArgument.
+func ValidateContainsAny(s string, substrs []string) error {
+	return validateContains(s, substrs, false, contains)
+}
+
+// ValidateContainsAnyIgnoreCase checks s like ContainsAnyIgnoreCase, returning nil if it contains one of substrs
+// in any case and otherwise a *ValidationError.
+func ValidateContainsAnyIgnoreCase(s string, substrs []string) error {
+	return validateContains(s, substrs, false, containsIgnoreCase)
+}
+
+// ValidateContainsAll checks s like ContainsAll, returning nil if it contains every one of substrs and otherwise
+// a *ValidationError whose expected parameter lists the missing substrings joined by "and". An empty substrs
+// fails with ReasonInvalidArgument.
+func ValidateContainsAll(s string, substrs []string) error {
+	return validateContains(s, substrs, true, contains)
+}
+
+// ValidateContainsAllIgnoreCase checks s like ContainsAllIgnoreCase, returning nil if it contains every one of
+// substrs in any case and otherwise a *ValidationError.
+func ValidateContainsAllIgnoreCase(s string, substrs []string) error {
+	return validateContains(s, substrs, true, containsIgnoreCase)
+}
+
+// ValidateHasPrefix checks s like HasPrefix, returning nil if it starts with prefix and otherwise a
+// *ValidationError whose expected parameter is the quoted prefix.
+func ValidateHasPrefix(s string, prefix string) error {
+	return validateHasPrefix(s, prefix)
+}
+
+// ValidateHasSuffix checks s like HasSuffix, returning nil if it ends with suffix and otherwise a
+// *ValidationError whose expected parameter is the quoted suffix.
+func ValidateHasSuffix(s string, suffix string) error {
+	return validateHasSuffix(s, suffix)
+}
@@ -164,231 +164,223 @@ func (sb *StringBuilder) HasSuffix(suffix string) bool {
 	return hasSuffix(sb.value, suffix)
 }
 
+// IsSimilarTo reports whether the similarity between the StringBuilder's value and other, computed
+// with the given algorithm, is at least threshold.
+func (sb *StringBuilder) IsSimilarTo(other string, algorithm Algorithm, threshold float32) bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return isSimilarTo(sb.value, other, algorithm, threshold)
+}
+
+// IsFuzzyEqual reports whether the StringBuilder's value and other are equal under the provided FuzzyOptions.
+func (sb *StringBuilder) IsFuzzyEqual(other string, opts FuzzyOptions) bool {
+	if !sb.shouldContinueProcessing() {
+		return false
+	}
+	return fuzzyEqual(sb.value, other, opts)
+}
+
 // RequireEmail validates if the StringBuilder's value is a valid email format,
-// sets an error if invalid, and returns the instance.
+// sets an error if invalid, and returns the instance. The error is a ValidationErrors holding a ValidationError
+// for this and any other failed Require method.
 func (sb *StringBuilder) RequireEmail() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isEmail(sb.value) {
-		return sb.setError(errors.ErrInvalidEmail, true)
-	}
-	return sb
+	return sb.failValidation(validateEmail(value))
 }
 
 // RequireDomain ensures that the value of the StringBuilder is a valid domain, setting an error if validation fails.
 func (sb *StringBuilder) RequireDomain() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isDomain(sb.value) {
-		return sb.setError(errors.ErrInvalidDomain, true)
-	}
-	return sb
+	return sb.failValidation(validateDomain(value))
 }
 
 // RequireURL validates if the StringBuilder's value is a properly formatted URL,
 // sets an error if invalid, and returns the instance.
 func (sb *StringBuilder) RequireURL() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isURL(sb.value) {
-		return sb.setError(errors.ErrInvalidURL, true)
-	}
-	return sb
+	return sb.failValidation(validateURL(value))
 }
 
 // RequireUUID validates whether the StringBuilder's value conforms to a valid UUID format,
 // sets an error if invalid, and returns the instance.
 func (sb *StringBuilder) RequireUUID() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isUUID(sb.value) {
-		return sb.setError(errors.ErrInvalidUUID, true)
-	}
-	return sb
+	return sb.failValidation(validateUUID(value))
 }
 
 // RequireLength validates that the StringBuilder's value length is within the specified min and max range.
 // Sets an error if invalid.
 func (sb *StringBuilder) RequireLength(min, max int) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if min < 0 || max < 0 {
-		return sb.setError(errors.ErrInvalidLengthRange, true)
-
-	} else if min > max {
-		return sb.setError(errors.ErrInvalidLengthRange, true)
-	} else if !isLengthInRange(sb.value, min, max) {
-		return sb.setError(errors.ErrInvalidLength, true)
-	}
-	return sb
+	return sb.failValidation(validateLength(value, min, max))
 }
 
 // RequireNotEmpty ensures the StringBuilder's value is not empty, sets an error if it is, and returns the instance.
 func (sb *StringBuilder) RequireNotEmpty() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if isEmpty(sb.value) {
-		return sb.setError(errors.ErrInvalidEmpty, true)
-	}
-	return sb
+	return sb.failValidation(validateNotEmpty(value))
 }
 
 // RequireNotEmptyNormalized ensures the StringBuilder's value is not empty after normalizing whitespace,
 // setting an error otherwise.
 func (sb *StringBuilder) RequireNotEmptyNormalized() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if isEmptyNormalized(sb.value) {
-		return sb.setError(errors.ErrInvalidEmptyAfterNormalization, true)
-	}
-	return sb
+	return sb.failValidation(validateNotEmptyNormalized(value))
 }
 
 // RequireAlphaNumeric ensures the StringBuilder's value contains only alphanumeric characters,
 // setting an error if invalid.
 func (sb *StringBuilder) RequireAlphaNumeric() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isAlphaNumeric(sb.value) {
-		return sb.setError(errors.ErrInvalidNotAlphaNumeric, true)
-	}
-	return sb
+	return sb.failValidation(validateAlphaNumeric(value))
 }
 
 // RequireNumeric validates if the StringBuilder's value contains only numeric characters, with optional strict mode.
 // If validation fails, it sets an error and halts further processing in the builder.
 func (sb *StringBuilder) RequireNumeric(strict bool) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isNumeric(sb.value, strict) {
-		return sb.setError(errors.ErrInvalidNotNumeric, true)
-	}
-	return sb
+	return sb.failValidation(validateNumeric(value, strict))
 }
 
 // RequireAlpha ensures the StringBuilder's value contains only alphabetic characters, setting an error if invalid.
 func (sb *StringBuilder) RequireAlpha() *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isAlpha(sb.value) {
-		return sb.setError(errors.ErrInvalidNotAlpha, true)
-	}
-	return sb
+	return sb.failValidation(validateAlpha(value))
 }
 
 // RequireNormalizedUnicode ensures the string is normalized according to the specified Unicode normalization format.
 // If not, it sets an error state in the StringBuilder and returns itself for chaining.
 func (sb *StringBuilder) RequireNormalizedUnicode(format NormalizationFormat) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !isNormalizedUnicode(sb.value, format) {
-		return sb.setError(errors.ErrNotNormalizedUnicode, true)
-	}
-	return sb
+	return sb.failValidation(validateNormalizedUnicode(value, format))
 }
 
 // RequireContains ensures that the StringBuilder's value contains the specified substring.
 // If the substring is not found, it sets an error and halts further processing.
 func (sb *StringBuilder) RequireContains(substr string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !contains(sb.value, substr) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, []string{substr}, false, contains))
 }
 
 // RequireContainsIgnoreCase verifies that the StringBuilder's value contains the given substring, ignoring case.
 // Returns the StringBuilder itself, or sets an error if the substring is not found.
 func (sb *StringBuilder) RequireContainsIgnoreCase(substr string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !containsIgnoreCase(sb.value, substr) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, []string{substr}, false, containsIgnoreCase))
 }
 
 // RequireContainsAny ensures the string contains at least one of the specified substrings, else sets an error.
 func (sb *StringBuilder) RequireContainsAny(substrs []string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !containsAny(sb.value, substrs) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, substrs, false, contains))
 }
 
 // RequireContainsAnyIgnoreCase ensures the string contains at least one of the specified substrings, disregarding case.
 // If none of the substrings are found, it sets an error and halts further processing.
 // Returns the updated StringBuilder instance.
 func (sb *StringBuilder) RequireContainsAnyIgnoreCase(substrs []string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !containsAnyIgnoreCase(sb.value, substrs) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, substrs, false, containsIgnoreCase))
 }
 
 // RequireContainsAll ensures the StringBuilder's value contains all provided substrings; sets an error if not.
 func (sb *StringBuilder) RequireContainsAll(substrs []string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !containsAll(sb.value, substrs) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, substrs, true, contains))
 }
 
 // RequireContainsAllIgnoreCase checks if all substrings in the slice exist in the current value,
 // ignoring case sensitivity.
 // Returns the StringBuilder instance with an error set if any substring is missing.
 func (sb *StringBuilder) RequireContainsAllIgnoreCase(substrs []string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !containsAllIgnoreCase(sb.value, substrs) {
-		return sb.setError(errors.ErrDoesNotContainSubstring, true)
-	}
-	return sb
+	return sb.failValidation(validateContains(value, substrs, true, containsIgnoreCase))
 }
 
 // RequireHasPrefix ensures the builder's string value has the specified prefix or sets an error
 // if the prefix is missing.
 func (sb *StringBuilder) RequireHasPrefix(prefix string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !hasPrefix(sb.value, prefix) {
-		return sb.setError(errors.ErrMissingPrefix, true)
-	}
-	return sb
+	return sb.failValidation(validateHasPrefix(value, prefix))
 }
 
 // RequireHasSuffix ensures the StringBuilder's value ends with the specified suffix, setting an error if not.
 func (sb *StringBuilder) RequireHasSuffix(suffix string) *StringBuilder {
-	if !sb.shouldContinueProcessing() {
+	value, ok := sb.validationValue()
+	if !ok {
 		return sb
 	}
-	if !hasSuffix(sb.value, suffix) {
-		return sb.setError(errors.ErrMissingSuffix, true)
+	return sb.failValidation(validateHasSuffix(value, suffix))
+}
+
+// RequireSimilarTo ensures the similarity between the StringBuilder's value and other, computed with the
+// given algorithm, is at least threshold, setting an error otherwise.
+func (sb *StringBuilder) RequireSimilarTo(other string, algorithm Algorithm, threshold float32) *StringBuilder {
+	value, ok := sb.validationValue()
+	if !ok {
+		return sb
+	}
+	if _, ok := AlgorithmTypeMap[algorithm]; !ok {
+		return sb.failValidation(newValidationError(value, "similar", ReasonInvalidArgument, -1,
+			errors.ErrInvalidAlgorithm))
+	}
+	if !isSimilarTo(value, other, algorithm, threshold) {
+		return sb.failValidation(newValidationError(value, "similar", ReasonNotSimilar, -1, errors.ErrNotSimilar))
 	}
 	return sb
 }
@@ -0,0 +1,226 @@
+package strutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReasonCode identifies why a validation failed. Its String form is a stable code, such as "invalid_character",
+// that can be used as a translation key.
+type ReasonCode int
+
+// String returns the string representation of the ReasonCode using ReasonCodeMap.
+func (rc ReasonCode) String() string {
+	return ReasonCodeMap[rc]
+}
+
+// ReasonInvalidFormat is a value that does not have the required format, when no more specific reason applies.
+// ReasonEmpty is an empty value.
+// ReasonBlank is a value that contains only whitespace.
+// ReasonInvalidCharacter is a character that is not allowed.
+// ReasonMissingAt is an email address without an @ sign.
+// ReasonMissingLocalPart is an email address without a part before the @ sign.
+// ReasonMissingDomain is an email address without a domain after the @ sign.
+// ReasonLeadingDot is a value or domain that starts with a dot.
+// ReasonTrailingDot is a value or domain that ends with a dot.
+// ReasonConsecutiveDots is a value with two dots in a row.
+// ReasonMissingScheme is a URL without a scheme such as "https".
+// ReasonMissingHost is a URL without a host.
+// ReasonMissingTLD is a domain without a top-level domain.
+// ReasonInvalidTLD is a top-level domain that is not at least two letters.
+// ReasonLabelTooLong is a domain label longer than 63 characters.
+// ReasonInvalidHyphen is a domain label that starts or ends with a hyphen.
+// ReasonTooShort is a value shorter than the minimum length.
+// ReasonTooLong is a value longer than the maximum length.
+// ReasonInvalidArgument is a validation that cannot run because its arguments, such as a length range, are
+// invalid.
+// ReasonNotNormalized is a value that is not in the required Unicode normalization form.
+// ReasonMissingSubstring is a value that does not contain the required text.
+// ReasonMissingPrefix is a value that does not start with the required prefix.
+// ReasonMissingSuffix is a value that does not end with the required suffix.
+// ReasonNotSimilar is a value that is not similar enough to the value it was compared with.
+// ReasonContainsSecret is a value that contains a credential found by ScanSecretsWith.
+// ReasonMixedScript is a value that mixes scripts that are not used together.
+// ReasonRestrictionLevel is a value that does not satisfy the required RestrictionLevel.
+// ReasonConfusable is a value that is visually confusable with a protected value.
+const (
+	ReasonInvalidFormat ReasonCode = iota
+	ReasonEmpty
+	ReasonBlank
+	ReasonInvalidCharacter
+	ReasonMissingAt
+	ReasonMissingLocalPart
+	ReasonMissingDomain
+	ReasonLeadingDot
+	ReasonTrailingDot
+	ReasonConsecutiveDots
+	ReasonMissingScheme
+	ReasonMissingHost
+	ReasonMissingTLD
+	ReasonInvalidTLD
+	ReasonLabelTooLong
+	ReasonInvalidHyphen
+	ReasonTooShort
+	ReasonTooLong
+	ReasonInvalidArgument
+	ReasonNotNormalized
+	ReasonMissingSubstring
+	ReasonMissingPrefix
+	ReasonMissingSuffix
+	ReasonNotSimilar
+	ReasonContainsSecret
+	ReasonMixedScript
+	ReasonRestrictionLevel
+	ReasonConfusable
+)
+
+// ReasonCodeMap maps ReasonCode constants to their stable codes.
+var ReasonCodeMap = map[ReasonCode]string{
+	ReasonInvalidFormat:    "invalid_format",
+	ReasonEmpty:            "empty",
+	ReasonBlank:            "blank",
+	ReasonInvalidCharacter: "invalid_character",
+	ReasonMissingAt:        "missing_at",
+	ReasonMissingLocalPart: "missing_local_part",
+	ReasonMissingDomain:    "missing_domain",
+	ReasonLeadingDot:       "leading_dot",
+	ReasonTrailingDot:      "trailing_dot",
+	ReasonConsecutiveDots:  "consecutive_dots",
+	ReasonMissingScheme:    "missing_scheme",
+	ReasonMissingHost:      "missing_host",
+	ReasonMissingTLD:       "missing_tld",
+	ReasonInvalidTLD:       "invalid_tld",
+	ReasonLabelTooLong:     "label_too_long",
+	ReasonInvalidHyphen:    "invalid_hyphen",
+	ReasonTooShort:         "too_short",
+	ReasonTooLong:          "too_long",
+	ReasonInvalidArgument:  "invalid_argument",
+	ReasonNotNormalized:    "not_normalized",
+	ReasonMissingSubstring: "missing_substring",
+	ReasonMissingPrefix:    "missing_prefix",
+	ReasonMissingSuffix:    "missing_suffix",
+	ReasonNotSimilar:       "not_similar",
+	ReasonContainsSecret:   "contains_secret",
+	ReasonMixedScript:      "mixed_script",
+	ReasonRestrictionLevel: "restriction_level",
+	ReasonConfusable:       "confusable",
+}
+
+// ValidationMessages maps reason codes to message templates. Templates can use the placeholders {rule}, {rune},
+// {position}, which is one-based, and the names of ValidationError.Params, such as {min}, {max} and {expected}.
+type ValidationMessages map[ReasonCode]string
+
+// DefaultValidationMessages are the English messages of ValidationError.Message.
+var DefaultValidationMessages = ValidationMessages{
+	ReasonInvalidFormat:    "is not a valid {rule}",
+	ReasonEmpty:            "must not be empty",
+	ReasonBlank:            "must not be blank",
+	ReasonInvalidCharacter: "contains the invalid character {rune} at position {position}",
+	ReasonMissingAt:        "is missing the @ sign",
+	ReasonMissingLocalPart: "is missing the name before the @ sign",
+	ReasonMissingDomain:    "is missing the domain after the @ sign",
+	ReasonLeadingDot:       "must not start with a dot",
+	ReasonTrailingDot:      "must not end with a dot",
+	ReasonConsecutiveDots:  "contains two dots in a row at position {position}",
+	ReasonMissingScheme:    "is missing a scheme such as https://",
+	ReasonMissingHost:      "is missing a host",
+	ReasonMissingTLD:       "is missing a top-level domain such as .com",
+	ReasonInvalidTLD:       "has an invalid top-level domain at position {position}",
+	ReasonLabelTooLong:     "has a part longer than {max} characters at position {position}",
+	ReasonInvalidHyphen:    "has a part that starts or ends with a hyphen at position {position}",
+	ReasonTooShort:         "must be at least {min} bytes long",
+	ReasonTooLong:          "must be at most {max} bytes long",
+	ReasonInvalidArgument:  "cannot be validated with invalid arguments",
+	ReasonNotNormalized:    "is not normalized from position {position}",
+	ReasonMissingSubstring: "must contain {expected}",
+	ReasonMissingPrefix:    "must start with {expected}",
+	ReasonMissingSuffix:    "must end with {expected}",
+	ReasonNotSimilar:       "is not similar enough",
+	ReasonContainsSecret:   "contains a secret ({secret_rule}) at position {position}",
+	ReasonMixedScript:      "mixes characters of different scripts",
+	ReasonRestrictionLevel: "does not meet the {expected} restriction level",
+	ReasonConfusable:       "can be confused with {expected}",
+}
+
+// ValidationError describes why a value failed a validation rule. It unwraps to the sentinel error of the rule,
+// such as ErrInvalidEmail, so it can be checked with errors.Is.
+type ValidationError struct {
+	// Rule is the name of the validation rule, such as "email" or "length".
+	Rule   string
+	Reason ReasonCode
+	// Offset is the byte offset of the offending character, or -1 if the failure is not at a position.
+	Offset int
+	// Position is the zero-based character index of the offending character, or -1 if the failure is not at a
+	// position.
+	Position int
+	// Rune is the offending character, if there is one.
+	Rune rune
+	// Params holds the values used by the messages of the reason, such as "min" and "max".
+	Params map[string]string
+	// Err is the sentinel error of the rule.
+	Err error
+}
+
+// Error returns the rule followed by the English message, e.g. "email: is missing the @ sign".
+func (ve *ValidationError) Error() string {
+	return ve.Rule + ": " + ve.Message()
+}
+
+// Unwrap returns the sentinel error of the rule.
+func (ve *ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// Message returns the English message of the error from DefaultValidationMessages.
+func (ve *ValidationError) Message() string {
+	return ve.Localize(nil)
+}
+
+// Localize returns the message of the error from messages, falling back to DefaultValidationMessages for the
+// reasons messages does not translate.
+func (ve *ValidationError) Localize(messages ValidationMessages) string {
+	template, ok := messages[ve.Reason]
+	if !ok {
+		template = DefaultValidationMessages[ve.Reason]
+	}
+	replacements := []string{"{rule}", ve.Rule, "{rune}", "", "{position}", ""}
+	if ve.Offset >= 0 {
+		replacements[3], replacements[5] = fmt.Sprintf("%q", ve.Rune), strconv.Itoa(ve.Position+1)
+	}
+	for name, value := range ve.Params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// ValidationErrors is the error of a StringBuilder whose Require methods failed. It holds one ValidationError per
+// failed rule, in the order the rules were checked, and unwraps to each of them.
+type ValidationErrors []*ValidationError
+
+// Error returns the errors separated by semicolons.
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for i, err := range ve {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors, so errors.Is and errors.As match any of them.
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i, err := range ve {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Localize returns the message of each error from messages, falling back to DefaultValidationMessages.
+func (ve ValidationErrors) Localize(messages ValidationMessages) []string {
+	localized := make([]string, len(ve))
+	for i, err := range ve {
+		localized[i] = err.Localize(messages)
+	}
+	return localized
+}
@@ -3,9 +3,12 @@ package strutil
 import (
 	"net/mail"
 	"net/url"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/bmj2728/utils/pkg/internal/errors"
 	"github.com/google/uuid"
 	"golang.org/x/text/unicode/norm"
 )
@@ -203,3 +206,303 @@ func hasSuffix(s string, suffix string) bool {
 	}
 	return strings.HasSuffix(s, suffix)
 }
+
+// newValidationError returns a ValidationError of rule for s, with the offending character at the byte offset,
+// or without a position if offset is negative.
+func newValidationError(s, rule string, reason ReasonCode, offset int, err error) *ValidationError {
+	ve := &ValidationError{Rule: rule, Reason: reason, Offset: -1, Position: -1, Err: err}
+	if offset >= 0 && offset <= len(s) {
+		ve.Offset, ve.Position = offset, utf8.RuneCountInString(s[:offset])
+		ve.Rune, _ = utf8.DecodeRuneInString(s[offset:])
+	}
+	return ve
+}
+
+// validateEmail returns nil if s is a valid email address, otherwise a ValidationError with the reason.
+func validateEmail(s string) error {
+	if isEmail(s) {
+		return nil
+	}
+	reason, offset := emailFailure(s)
+	return newValidationError(s, "email", reason, offset, errors.ErrInvalidEmail)
+}
+
+// emailFailure returns the reason an address rejected by isEmail is invalid and the offset of the offending
+// character, or -1.
+func emailFailure(s string) (ReasonCode, int) {
+	at := strings.IndexByte(s, '@')
+	switch {
+	case s == "":
+		return ReasonEmpty, -1
+	case at < 0:
+		return ReasonMissingAt, -1
+	case at == 0:
+		return ReasonMissingLocalPart, -1
+	case at == len(s)-1:
+		return ReasonMissingDomain, -1
+	}
+	for i, r := range s {
+		// the characters net/mail does not accept in an unquoted address
+		if i != at && (r == utf8.RuneError || unicode.IsSpace(r) || unicode.IsControl(r) ||
+			strings.ContainsRune(`()<>[]:;@\,"`, r)) {
+			return ReasonInvalidCharacter, i
+		}
+	}
+	switch {
+	case s[0] == '.':
+		return ReasonLeadingDot, 0
+	case s[at-1] == '.':
+		return ReasonTrailingDot, at - 1
+	case s[at+1] == '.':
+		return ReasonLeadingDot, at + 1
+	case s[len(s)-1] == '.':
+		return ReasonTrailingDot, len(s) - 1
+	case strings.Contains(s, ".."):
+		return ReasonConsecutiveDots, strings.Index(s, "..")
+	}
+	return ReasonInvalidFormat, -1
+}
+
+// validateURL returns nil if s is a valid URL, otherwise a ValidationError with the reason.
+func validateURL(s string) error {
+	if isURL(s) {
+		return nil
+	}
+	reason, offset := urlFailure(s)
+	return newValidationError(s, "url", reason, offset, errors.ErrInvalidURL)
+}
+
+// urlFailure returns the reason a URL rejected by isURL is invalid and the offset of the offending character,
+// or -1.
+func urlFailure(s string) (ReasonCode, int) {
+	if s == "" {
+		return ReasonEmpty, -1
+	}
+	for i, r := range s {
+		if r == utf8.RuneError || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return ReasonInvalidCharacter, i
+		}
+	}
+	u, err := url.Parse(s)
+	switch {
+	case err != nil:
+		return ReasonInvalidFormat, -1
+	case u.Scheme == "":
+		return ReasonMissingScheme, -1
+	case u.Host == "":
+		return ReasonMissingHost, -1
+	}
+	return ReasonInvalidFormat, -1
+}
+
+// validateDomain returns nil if domain is a valid domain name, otherwise a ValidationError with the reason.
+func validateDomain(domain string) error {
+	if isDomain(domain) {
+		return nil
+	}
+	ve := newValidationError(domain, "domain", ReasonEmpty, -1, errors.ErrInvalidDomain)
+	trimmed := strings.TrimSpace(domain)
+	if trimmed == "" {
+		return ve
+	}
+	// isDomain ignores surrounding whitespace, so positions are relative to the trimmed domain
+	start := strings.Index(domain, trimmed)
+	reason, offset := domainFailure(trimmed)
+	if offset >= 0 {
+		offset += start
+	}
+	ve = newValidationError(domain, "domain", reason, offset, errors.ErrInvalidDomain)
+	if reason == ReasonLabelTooLong {
+		ve.Params = map[string]string{"max": "63"}
+	}
+	return ve
+}
+
+// domainFailure returns the reason a non-empty domain rejected by isDomain is invalid and the offset of the
+// offending character or label, or -1.
+func domainFailure(domain string) (ReasonCode, int) {
+	for i, r := range domain {
+		if r != '.' && r != '-' && (r >= utf8.RuneSelf || !IsAlphaNumericRune(r)) {
+			return ReasonInvalidCharacter, i
+		}
+	}
+	labels := strings.Split(domain, ".")
+	offset := 0
+	for i, label := range labels {
+		switch {
+		case label == "" && i == 0:
+			return ReasonLeadingDot, 0
+		case label == "" && i == len(labels)-1:
+			return ReasonTrailingDot, offset - 1
+		case label == "":
+			return ReasonConsecutiveDots, offset - 1
+		case len(label) > 63:
+			return ReasonLabelTooLong, offset
+		case label[0] == '-':
+			return ReasonInvalidHyphen, offset
+		case label[len(label)-1] == '-':
+			return ReasonInvalidHyphen, offset + len(label) - 1
+		}
+		offset += len(label) + 1
+	}
+	tld := labels[len(labels)-1]
+	switch {
+	case len(labels) == 1:
+		return ReasonMissingTLD, -1
+	case len(tld) < 2 || strings.Trim(tld, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") != "":
+		return ReasonInvalidTLD, len(domain) - len(tld)
+	}
+	return ReasonInvalidFormat, -1
+}
+
+// validateUUID returns nil if s is a valid UUID, otherwise a ValidationError with the first character that is not
+// a hexadecimal digit or hyphen, if there is one.
+func validateUUID(s string) error {
+	if isUUID(s) {
+		return nil
+	}
+	if s == "" {
+		return newValidationError(s, "uuid", ReasonEmpty, -1, errors.ErrInvalidUUID)
+	}
+	body := strings.TrimPrefix(strings.TrimPrefix(s, "urn:uuid:"), "{")
+	body = strings.TrimSuffix(body, "}")
+	start := strings.Index(s, body)
+	for i, r := range body {
+		if r != '-' && !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return newValidationError(s, "uuid", ReasonInvalidCharacter, start+i, errors.ErrInvalidUUID)
+		}
+	}
+	return newValidationError(s, "uuid", ReasonInvalidFormat, -1, errors.ErrInvalidUUID)
+}
+
+// validateLength returns nil if the length of s is within [min, max], otherwise a ValidationError with the
+// reason.
+func validateLength(s string, min, max int) error {
+	if isLengthInRange(s, min, max) {
+		return nil
+	}
+	var ve *ValidationError
+	switch {
+	case min < 0 || max < 0 || min > max:
+		ve = newValidationError(s, "length", ReasonInvalidArgument, -1, errors.ErrInvalidLengthRange)
+	case len(s) < min:
+		ve = newValidationError(s, "length", ReasonTooShort, -1, errors.ErrInvalidLength)
+	default:
+		ve = newValidationError(s, "length", ReasonTooLong, -1, errors.ErrInvalidLength)
+	}
+	ve.Params = map[string]string{"min": strconv.Itoa(min), "max": strconv.Itoa(max)}
+	return ve
+}
+
+// validateNotEmpty returns a ValidationError if s is empty, otherwise nil.
+func validateNotEmpty(s string) error {
+	if !isEmpty(s) {
+		return nil
+	}
+	return newValidationError(s, "not_empty", ReasonEmpty, -1, errors.ErrInvalidEmpty)
+}
+
+// validateNotEmptyNormalized returns a ValidationError if s is empty after normalizing whitespace, otherwise nil.
+func validateNotEmptyNormalized(s string) error {
+	switch {
+	case isEmpty(s):
+		return newValidationError(s, "not_blank", ReasonEmpty, -1, errors.ErrInvalidEmptyAfterNormalization)
+	case isEmptyNormalized(s):
+		return newValidationError(s, "not_blank", ReasonBlank, -1, errors.ErrInvalidEmptyAfterNormalization)
+	}
+	return nil
+}
+
+// validateRunes returns a ValidationError of rule if s is empty or has a character that valid rejects,
+// otherwise nil.
+func validateRunes(s, rule string, valid func(rune) bool, err error) error {
+	if isEmpty(s) {
+		return newValidationError(s, rule, ReasonEmpty, -1, err)
+	}
+	for i, r := range s {
+		if !valid(r) {
+			return newValidationError(s, rule, ReasonInvalidCharacter, i, err)
+		}
+	}
+	return nil
+}
+
+// validateAlphaNumeric returns nil if s contains only letters and digits, otherwise a ValidationError.
+func validateAlphaNumeric(s string) error {
+	return validateRunes(s, "alphanumeric", IsAlphaNumericRune, errors.ErrInvalidNotAlphaNumeric)
+}
+
+// validateAlpha returns nil if s contains only letters, otherwise a ValidationError.
+func validateAlpha(s string) error {
+	return validateRunes(s, "alpha", unicode.IsLetter, errors.ErrInvalidNotAlpha)
+}
+
+// validateNumeric returns nil if s contains only digits, or with strict false only numbers, otherwise a
+// ValidationError.
+func validateNumeric(s string, strict bool) error {
+	if strict {
+		return validateRunes(s, "numeric", unicode.IsDigit, errors.ErrInvalidNotNumeric)
+	}
+	return validateRunes(s, "numeric", unicode.IsNumber, errors.ErrInvalidNotNumeric)
+}
+
+// validateNormalizedUnicode returns nil if s is normalized in format, otherwise a ValidationError whose position
+// is the end of the longest prefix of s known to be normalized.
+func validateNormalizedUnicode(s string, format NormalizationFormat) error {
+	if isNormalizedUnicode(s, format) {
+		return nil
+	}
+	offset := norm.Form(format).QuickSpanString(s)
+	return newValidationError(s, "normalized_unicode", ReasonNotNormalized, offset, errors.ErrNotNormalizedUnicode)
+}
+
+// newExpectationError returns a ValidationError of rule without a position whose expected parameter is expected.
+func newExpectationError(s, rule string, reason ReasonCode, expected string, err error) *ValidationError {
+	ve := newValidationError(s, rule, reason, -1, err)
+	ve.Params = map[string]string{"expected": expected}
+	return ve
+}
+
+// validateContains returns nil if s contains any, or with all set every, one of substrs according to match,
+// otherwise a ValidationError whose expected parameter lists the substrings that are missing.
+// Returns a ValidationError with ReasonInvalidArgument if substrs is empty.
+func validateContains(s string, substrs []string, all bool, match func(s, substr string) bool) error {
+	if len(substrs) == 0 {
+		return newValidationError(s, "contains", ReasonInvalidArgument, -1, errors.ErrNoSubstrings)
+	}
+	var missing []string
+	for _, substr := range substrs {
+		if !match(s, substr) {
+			missing = append(missing, strconv.Quote(substr))
+		} else if !all {
+			return nil
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	separator := " or "
+	if all {
+		separator = " and "
+	}
+	return newExpectationError(s, "contains", ReasonMissingSubstring, strings.Join(missing, separator),
+		errors.ErrDoesNotContainSubstring)
+}
+
+// validateHasPrefix returns nil if s starts with prefix, otherwise a ValidationError whose expected parameter is
+// the prefix.
+func validateHasPrefix(s string, prefix string) error {
+	if hasPrefix(s, prefix) {
+		return nil
+	}
+	return newExpectationError(s, "prefix", ReasonMissingPrefix, strconv.Quote(prefix), errors.ErrMissingPrefix)
+}
+
+// validateHasSuffix returns nil if s ends with suffix, otherwise a ValidationError whose expected parameter is
+// the suffix.
+func validateHasSuffix(s string, suffix string) error {
+	if hasSuffix(s, suffix) {
+		return nil
+	}
+	return newExpectationError(s, "suffix", ReasonMissingSuffix, strconv.Quote(suffix), errors.ErrMissingSuffix)
+}